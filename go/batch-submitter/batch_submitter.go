@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/go/bss-core/metrics"
 	"github.com/ethereum-optimism/optimism/go/bss-core/txmgr"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/getsentry/sentry-go"
 	"github.com/urfave/cli"
@@ -88,10 +89,11 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 
 		// Connect to L1 and L2 providers. Perform these last since they are the
 		// most expensive.
-		l1Client, err := dial.L1EthClientWithTimeout(ctx, cfg.L1EthRpc, cfg.DisableHTTP2)
+		l1RPCClient, err := dial.L1RPCClientWithTimeout(ctx, cfg.L1EthRpc, cfg.DisableHTTP2)
 		if err != nil {
 			return err
 		}
+		l1Client := ethclient.NewClient(l1RPCClient)
 
 		l2Client, err := dial.L2EthClientWithTimeout(ctx, cfg.L2EthRpc, cfg.DisableHTTP2)
 		if err != nil {
@@ -132,6 +134,22 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 			}
 			keyAddr := crypto.PubkeyToAddress(*pubkey)
 			log.Info("Batch submitter sequencer account address", "keyAddr", keyAddr)
+
+			batchType, err := sequencer.ParseBatchType(cfg.SequencerBatchType)
+			if err != nil {
+				return err
+			}
+			if cfg.SequencerZstdDictionary != "" {
+				dict, err := os.ReadFile(cfg.SequencerZstdDictionary)
+				if err != nil {
+					return err
+				}
+				if err := sequencer.SetZstdDictionary(dict); err != nil {
+					return err
+				}
+			}
+			log.Info("Batch submitter sequencer batch type", "type", batchType)
+
 			batchTxDriver, err := sequencer.NewDriver(sequencer.Config{
 				Name:        "Sequencer",
 				L1Client:    l1Client,
@@ -143,6 +161,10 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 				KeyId:       cfg.SequencerKeyId,
 				KeyAddress:  keyAddr,
 				KMS:         *svc,
+				BatchType:   batchType,
+
+				FetchConcurrency: cfg.SequencerFetchConcurrency,
+				BlockCacheSize:   cfg.SequencerBlockCacheSize,
 			})
 			if err != nil {
 				return err
@@ -154,10 +176,19 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 				PollInterval:           cfg.PollInterval,
 				ClearPendingTx:         cfg.ClearPendingTxs,
 				L1Client:               l1Client,
+				L1RPCClient:            l1RPCClient,
 				TxManagerConfig:        txManagerConfig,
 				MinTxSize:              cfg.MinL1TxSize,
 				MaxBatchSubmissionTime: cfg.MaxBatchSubmissionTime,
 				MaxL1GasPrice:          cfg.MaxL1GasPrice,
+				MaxTxSize:              cfg.MaxL1TxSize,
+				MaxL2BlockLag:          cfg.MaxL2BlockLag,
+				MaxSubmissionDelay:     cfg.MaxSubmissionDelay,
+				BaseFeePercentile:      cfg.BaseFeePercentile,
+				BaseFeeHistorySize:     cfg.BaseFeeHistorySize,
+				FullBatchRatio:         cfg.FullBatchRatio,
+
+				SafeMinimumEtherBalance: cfg.SafeMinimumEtherBalance,
 			}))
 		}
 
@@ -168,6 +199,18 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 			}
 			keyAddr := crypto.PubkeyToAddress(*pubkey)
 			log.Info("Batch submitter proposer account address", "keyAddr", keyAddr)
+
+			var verifiers []proposer.L2HeaderClient
+			for _, verifierRpc := range cfg.ProposerVerifierRpcs {
+				verifier, err := dial.L2EthClientWithTimeout(
+					ctx, verifierRpc, cfg.DisableHTTP2,
+				)
+				if err != nil {
+					return err
+				}
+				verifiers = append(verifiers, verifier)
+			}
+			log.Info("Batch submitter proposer verifiers", "num_verifiers", len(verifiers))
 			batchStateDriver, err := proposer.NewDriver(proposer.Config{
 				Name:        "Proposer",
 				L1Client:    l1Client,
@@ -180,6 +223,7 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 				KeyId:       cfg.ProposerKeyId,
 				KeyAddress:  keyAddr,
 				KMS:         *svc,
+				Verifiers:   verifiers,
 			})
 			if err != nil {
 				return err
@@ -191,13 +235,29 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 				PollInterval:           cfg.PollInterval,
 				ClearPendingTx:         cfg.ClearPendingTxs,
 				L1Client:               l1Client,
+				L1RPCClient:            l1RPCClient,
 				TxManagerConfig:        txManagerConfig,
 				MinTxSize:              cfg.MinL1TxSize,
 				MaxBatchSubmissionTime: cfg.MaxBatchSubmissionTime,
 				MaxL1GasPrice:          cfg.MaxL1GasPrice,
+				MaxTxSize:              cfg.MaxL1TxSize,
+				MaxL2BlockLag:          cfg.MaxL2BlockLag,
+				MaxSubmissionDelay:     cfg.MaxSubmissionDelay,
+				BaseFeePercentile:      cfg.BaseFeePercentile,
+				BaseFeeHistorySize:     cfg.BaseFeeHistorySize,
+				FullBatchRatio:         cfg.FullBatchRatio,
+
+				SafeMinimumEtherBalance: cfg.SafeMinimumEtherBalance,
 			}))
 		}
 
+		if cfg.ControlServerEnable {
+			go bsscore.RunControlServer(
+				cfg.ControlServerHostname, cfg.ControlServerPort,
+				cfg.ControlServerToken, services,
+			)
+		}
+
 		batchSubmitter, err := bsscore.NewBatchSubmitter(ctx, cancel, services)
 		if err != nil {
 			log.Error("Unable to create batch submitter", "error", err)
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
 
+	"github.com/ethereum-optimism/optimism/go/batch-submitter/drivers/sequencer"
 	"github.com/ethereum-optimism/optimism/go/batch-submitter/flags"
 )
 
@@ -30,6 +31,21 @@ var (
 	ErrKmsEndpointNotSet = errors.New("kms endpoint not set")
 
 	ErrKmsRegionNotSet = errors.New("kms region not Set")
+
+	// ErrInvalidSequencerBatchType signals that the configured sequencer
+	// batch type is not supported.
+	ErrInvalidSequencerBatchType = errors.New("sequencer-batch-type must be " +
+		"one of legacy, brotli, zstd or zstd-columnar")
+
+	// ErrInvalidBaseFeePercentile signals that the configured base fee
+	// percentile is outside of the range [0, 100].
+	ErrInvalidBaseFeePercentile = errors.New("base-fee-percentile must be " +
+		"between 0 and 100")
+
+	// ErrControlServerTokenNotSet signals that the control server was enabled
+	// without a token with which to authenticate requests.
+	ErrControlServerTokenNotSet = errors.New("control-server-token must be " +
+		"set if control-server-enable is true")
 )
 
 type Config struct {
@@ -121,6 +137,27 @@ type Config struct {
 	// batch submitter can accept
 	MaxL1GasPrice uint64
 
+	// MaxL2BlockLag is the number of unsubmitted L2 blocks at which a batch
+	// is submitted regardless of L1 gas prices. Disabled if zero.
+	MaxL2BlockLag uint64
+
+	// MaxSubmissionDelay is the time since the last submission after which a
+	// batch is submitted regardless of L1 gas prices. Disabled if zero.
+	MaxSubmissionDelay time.Duration
+
+	// BaseFeePercentile is the percentile of the recent L1 base fee history
+	// at or below which batches that are not full are submitted. Disabled if
+	// zero.
+	BaseFeePercentile float64
+
+	// BaseFeeHistorySize is the number of recent L1 blocks used to compute
+	// the base fee percentile.
+	BaseFeeHistorySize uint64
+
+	// FullBatchRatio is the fraction of MaxL1TxSize at which a batch no
+	// longer waits for a cheap L1 base fee.
+	FullBatchRatio float64
+
 	// LogLevel is the lowest log level that will be output.
 	LogLevel string
 
@@ -156,6 +193,41 @@ type Config struct {
 
 	// DisableHTTP2 disables HTTP2 support.
 	DisableHTTP2 bool
+
+	// SequencerBatchType is the name of the encoding used for sequencer
+	// batches.
+	SequencerBatchType string
+
+	// SequencerZstdDictionary is the optional path to a pre-trained zstd
+	// dictionary used to compress zstd sequencer batches.
+	SequencerZstdDictionary string
+
+	// SequencerFetchConcurrency is the maximum number of L2 blocks fetched
+	// concurrently when crafting sequencer batches.
+	SequencerFetchConcurrency int
+
+	// SequencerBlockCacheSize is the maximum number of unsubmitted L2 blocks
+	// cached across polls.
+	SequencerBlockCacheSize int
+
+	// ProposerVerifierRpcs are the HTTP provider URLs of independent L2
+	// verifiers that must agree with each state root before it is proposed.
+	ProposerVerifierRpcs []string
+
+	// ControlServerEnable if true, will run a control server through which
+	// submissions can be paused, resumed, forced and inspected.
+	ControlServerEnable bool
+
+	// ControlServerHostname is the hostname at which the control server is
+	// running.
+	ControlServerHostname string
+
+	// ControlServerPort is the port at which the control server is running.
+	ControlServerPort uint64
+
+	// ControlServerToken is the bearer token required to authenticate
+	// requests to the control server.
+	ControlServerToken string
 }
 
 // NewConfig parses the Config from the provided flags or environment variables.
@@ -182,6 +254,11 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		ClearPendingTxs:         ctx.GlobalBool(flags.ClearPendingTxsFlag.Name),
 		/* Optional Flags */
 		MaxL1GasPrice:       ctx.GlobalUint64(flags.MaxL1GasPriceFlag.Name),
+		MaxL2BlockLag:       ctx.GlobalUint64(flags.MaxL2BlockLagFlag.Name),
+		MaxSubmissionDelay:  ctx.GlobalDuration(flags.MaxSubmissionDelayFlag.Name),
+		BaseFeePercentile:   ctx.GlobalFloat64(flags.BaseFeePercentileFlag.Name),
+		BaseFeeHistorySize:  ctx.GlobalUint64(flags.BaseFeeHistorySizeFlag.Name),
+		FullBatchRatio:      ctx.GlobalFloat64(flags.FullBatchRatioFlag.Name),
 		LogLevel:            ctx.GlobalString(flags.LogLevelFlag.Name),
 		LogTerminal:         ctx.GlobalBool(flags.LogTerminalFlag.Name),
 		SentryEnable:        ctx.GlobalBool(flags.SentryEnableFlag.Name),
@@ -196,6 +273,19 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		MetricsHostname:     ctx.GlobalString(flags.MetricsHostnameFlag.Name),
 		MetricsPort:         ctx.GlobalUint64(flags.MetricsPortFlag.Name),
 		DisableHTTP2:        ctx.GlobalBool(flags.HTTP2DisableFlag.Name),
+
+		SequencerBatchType:      ctx.GlobalString(flags.SequencerBatchTypeFlag.Name),
+		SequencerZstdDictionary: ctx.GlobalString(flags.SequencerZstdDictionaryFlag.Name),
+
+		SequencerFetchConcurrency: ctx.GlobalInt(flags.SequencerFetchConcurrencyFlag.Name),
+		SequencerBlockCacheSize:   ctx.GlobalInt(flags.SequencerBlockCacheSizeFlag.Name),
+
+		ProposerVerifierRpcs: ctx.GlobalStringSlice(flags.ProposerVerifierRpcsFlag.Name),
+
+		ControlServerEnable:   ctx.GlobalBool(flags.ControlServerEnableFlag.Name),
+		ControlServerHostname: ctx.GlobalString(flags.ControlServerHostnameFlag.Name),
+		ControlServerPort:     ctx.GlobalUint64(flags.ControlServerPortFlag.Name),
+		ControlServerToken:    ctx.GlobalString(flags.ControlServerTokenFlag.Name),
 	}
 
 	err := ValidateConfig(&cfg)
@@ -242,5 +332,21 @@ func ValidateConfig(cfg *Config) error {
 		return ErrSentryDSNNotSet
 	}
 
+	// Ensure control requests are authenticated when using the control
+	// server.
+	if cfg.ControlServerEnable && cfg.ControlServerToken == "" {
+		return ErrControlServerTokenNotSet
+	}
+
+	// Ensure the base fee percentile is a valid percentile.
+	if cfg.BaseFeePercentile < 0 || cfg.BaseFeePercentile > 100 {
+		return ErrInvalidBaseFeePercentile
+	}
+
+	// Ensure the sequencer batch type is supported.
+	if _, err := sequencer.ParseBatchType(cfg.SequencerBatchType); err != nil {
+		return ErrInvalidSequencerBatchType
+	}
+
 	return nil
 }
@@ -75,6 +75,42 @@ var validateConfigTests = []struct {
 		},
 		expErr: batchsubmitter.ErrSentryDSNNotSet,
 	},
+	{
+		name: "base fee percentile out of range",
+		cfg: batchsubmitter.Config{
+			LogLevel:          "info",
+			SequencerKeyId:    "a",
+			ProposerKeyId:     "b",
+			KmsEndpoint:       "c",
+			KmsRegion:         "d",
+			BaseFeePercentile: 101,
+		},
+		expErr: batchsubmitter.ErrInvalidBaseFeePercentile,
+	},
+	{
+		name: "unknown sequencer batch type",
+		cfg: batchsubmitter.Config{
+			LogLevel:           "info",
+			SequencerKeyId:     "a",
+			ProposerKeyId:      "b",
+			KmsEndpoint:        "c",
+			KmsRegion:          "d",
+			SequencerBatchType: "gzip",
+		},
+		expErr: batchsubmitter.ErrInvalidSequencerBatchType,
+	},
+	{
+		name: "control server without token",
+		cfg: batchsubmitter.Config{
+			LogLevel:            "info",
+			SequencerKeyId:      "a",
+			ProposerKeyId:       "b",
+			KmsEndpoint:         "c",
+			KmsRegion:           "d",
+			ControlServerEnable: true,
+		},
+		expErr: batchsubmitter.ErrControlServerTokenNotSet,
+	},
 	// Valid configs
 	{
 		name: "valid config with privkeys and no sentry",
@@ -86,6 +122,8 @@ var validateConfigTests = []struct {
 			KmsRegion:      "d",
 			SentryEnable:   false,
 			SentryDsn:      "",
+
+			SequencerBatchType: "brotli",
 		},
 		expErr: nil,
 	},
@@ -100,6 +138,8 @@ var validateConfigTests = []struct {
 			KmsRegion:      "d",
 			SentryEnable:   true,
 			SentryDsn:      "batch-submitter",
+
+			SequencerBatchType: "brotli",
 		},
 		expErr: nil,
 	},
@@ -113,6 +153,8 @@ var validateConfigTests = []struct {
 			KmsRegion:      "d",
 			SentryEnable:   true,
 			SentryDsn:      "batch-submitter",
+
+			SequencerBatchType: "brotli",
 		},
 		expErr: nil,
 	},
@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -40,6 +41,10 @@ type Config struct {
 	KeyId       string
 	KeyAddress  common.Address
 	KMS         kms.KMS
+
+	// Verifiers are optional independent L2 nodes whose state roots must
+	// agree with those of L2Client before they are proposed.
+	Verifiers []L2HeaderClient
 }
 
 type Driver struct {
@@ -49,6 +54,9 @@ type Driver struct {
 	ctcContract    *ctc.CanonicalTransactionChain
 	walletAddr     common.Address
 	metrics        *metrics.Metrics
+
+	// haltErr is the state root mismatch that halted submissions, if any.
+	haltErr error
 }
 
 func NewDriver(cfg Config) (*Driver, error) {
@@ -164,6 +172,13 @@ func (d *Driver) CraftBatchTx(
 
 	name := d.cfg.Name
 
+	// Once a verifier disagrees with the L2 client, no further state roots
+	// are proposed until an operator has investigated and restarted.
+	if d.haltErr != nil {
+		log.Error(name+" state batch submission halted", "err", d.haltErr)
+		return nil, 0, d.haltErr
+	}
+
 	log.Info(name+" crafting batch tx", "start", start, "end", end,
 		"nonce", nonce)
 
@@ -186,6 +201,35 @@ func (d *Driver) CraftBatchTx(
 		stateRoots = append(stateRoots, block.Root())
 	}
 
+	// Only propose the state roots that every verifier agrees with.
+	if len(d.cfg.Verifiers) > 0 {
+		numVerified, err := VerifyStateRoots(
+			ctx, d.cfg.Verifiers, start, stateRoots,
+		)
+		if errors.Is(err, ErrStateRootMismatch) {
+			d.haltErr = err
+			d.metrics.StateRootMismatches.Inc()
+			d.metrics.StateRootVerificationHalted.Set(1)
+			log.Error(name+" state root mismatch, halting state batch "+
+				"submission", "err", err)
+			return nil, 0, err
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		if numVerified == 0 {
+			return nil, 0, fmt.Errorf("verifiers have not synced "+
+				"height %v", start)
+		}
+		if numVerified < len(stateRoots) {
+			log.Info(name+" truncated batch to verified state roots",
+				"num_state_roots", len(stateRoots),
+				"num_verified", numVerified)
+			stateRoots = stateRoots[:numVerified]
+			totalStateRootSize = uint64(numVerified) * stateRootSize
+		}
+	}
+
 	d.metrics.NumElementsPerBatch.Observe(float64(len(stateRoots)))
 
 	log.Info(name+" batch constructed", "num_state_roots", len(stateRoots))
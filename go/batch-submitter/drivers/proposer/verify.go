@@ -0,0 +1,134 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	l2ethereum "github.com/ethereum-optimism/optimism/l2geth"
+	l2common "github.com/ethereum-optimism/optimism/l2geth/common"
+	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+// ErrStateRootMismatch signals that a verifier computed a different state root
+// than the L2 client for the same height.
+var ErrStateRootMismatch = errors.New("state root mismatch")
+
+// L2HeaderClient is the subset of an L2 client used to query the state roots
+// computed by a verifier.
+type L2HeaderClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*l2types.Header, error)
+}
+
+// StateRootMismatch describes the first height at which a verifier disagrees
+// with the L2 client.
+type StateRootMismatch struct {
+	// Verifier is the index of the disagreeing verifier.
+	Verifier int
+
+	// Height is the L2 block height of the disagreeing state root.
+	Height *big.Int
+
+	// Expected is the state root reported by the L2 client.
+	Expected [stateRootSize]byte
+
+	// Actual is the state root reported by the verifier.
+	Actual l2common.Hash
+}
+
+// Error returns a description of the mismatch, wrapping ErrStateRootMismatch.
+func (m *StateRootMismatch) Error() string {
+	return fmt.Sprintf("%v: verifier %d reported %x at height %v, expected %x",
+		ErrStateRootMismatch, m.Verifier, m.Actual, m.Height, m.Expected)
+}
+
+// Unwrap returns ErrStateRootMismatch.
+func (m *StateRootMismatch) Unwrap() error {
+	return ErrStateRootMismatch
+}
+
+// VerifyStateRoots compares the state roots of consecutive L2 blocks starting
+// at start with those computed by each verifier. The number of leading state
+// roots that every verifier agrees with is returned, which may be fewer than
+// provided if a verifier has not yet synced all heights. If any verifier
+// reports a different state root, a *StateRootMismatch is returned for the
+// lowest such height.
+func VerifyStateRoots(
+	ctx context.Context,
+	verifiers []L2HeaderClient,
+	start *big.Int,
+	stateRoots [][stateRootSize]byte,
+) (int, error) {
+
+	var (
+		numVerified = len(stateRoots)
+		mismatch    *StateRootMismatch
+		verifyErr   error
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+	)
+	for i, verifier := range verifiers {
+		wg.Add(1)
+		go func(i int, verifier L2HeaderClient) {
+			defer wg.Done()
+
+			n, m, err := verifyStateRoots(ctx, i, verifier, start, stateRoots)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				verifyErr = err
+			case m != nil:
+				if mismatch == nil || m.Height.Cmp(mismatch.Height) < 0 {
+					mismatch = m
+				}
+			case n < numVerified:
+				numVerified = n
+			}
+		}(i, verifier)
+	}
+	wg.Wait()
+
+	if mismatch != nil {
+		return 0, mismatch
+	}
+	if verifyErr != nil {
+		return 0, verifyErr
+	}
+	return numVerified, nil
+}
+
+// verifyStateRoots compares the state roots with those computed by a single
+// verifier, stopping at the first height the verifier has not yet synced.
+func verifyStateRoots(
+	ctx context.Context,
+	idx int,
+	verifier L2HeaderClient,
+	start *big.Int,
+	stateRoots [][stateRootSize]byte,
+) (int, *StateRootMismatch, error) {
+
+	for i, stateRoot := range stateRoots {
+		height := new(big.Int).Add(start, big.NewInt(int64(i)))
+		header, err := verifier.HeaderByNumber(ctx, height)
+		if err == l2ethereum.NotFound {
+			return i, nil, nil
+		} else if err != nil {
+			return 0, nil, err
+		}
+
+		if header.Root != l2common.Hash(stateRoot) {
+			return 0, &StateRootMismatch{
+				Verifier: idx,
+				Height:   height,
+				Expected: stateRoot,
+				Actual:   header.Root,
+			}, nil
+		}
+	}
+
+	return len(stateRoots), nil, nil
+}
@@ -0,0 +1,122 @@
+package proposer_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/batch-submitter/drivers/proposer"
+	l2ethereum "github.com/ethereum-optimism/optimism/l2geth"
+	l2common "github.com/ethereum-optimism/optimism/l2geth/common"
+	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var errVerifierUnavailable = errors.New("verifier unavailable")
+
+// mockVerifier serves headers whose state root is the height, unless
+// overridden, up to the height it has synced.
+type mockVerifier struct {
+	syncedHeight uint64
+	roots        map[uint64]l2common.Hash
+	err          error
+}
+
+func (v *mockVerifier) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*l2types.Header, error) {
+
+	if v.err != nil {
+		return nil, v.err
+	}
+	if number.Uint64() > v.syncedHeight {
+		return nil, l2ethereum.NotFound
+	}
+	root, ok := v.roots[number.Uint64()]
+	if !ok {
+		root = l2common.BigToHash(number)
+	}
+	return &l2types.Header{Number: number, Root: root}, nil
+}
+
+// stateRootsFrom returns the state roots expected by mockVerifier for the
+// given number of heights starting at start.
+func stateRootsFrom(start, n uint64) [][32]byte {
+	stateRoots := make([][32]byte, 0, n)
+	for i := start; i < start+n; i++ {
+		stateRoots = append(stateRoots,
+			l2common.BigToHash(new(big.Int).SetUint64(i)))
+	}
+	return stateRoots
+}
+
+func TestVerifyStateRoots(t *testing.T) {
+	start := big.NewInt(10)
+	stateRoots := stateRootsFrom(10, 10)
+
+	tests := []struct {
+		name           string
+		verifiers      []proposer.L2HeaderClient
+		expNumVerified int
+		expErr         error
+		expHeight      int64
+	}{
+		{
+			name: "all verifiers agree",
+			verifiers: []proposer.L2HeaderClient{
+				&mockVerifier{syncedHeight: 100},
+				&mockVerifier{syncedHeight: 100},
+			},
+			expNumVerified: 10,
+		},
+		{
+			name: "verifier behind",
+			verifiers: []proposer.L2HeaderClient{
+				&mockVerifier{syncedHeight: 100},
+				&mockVerifier{syncedHeight: 14},
+			},
+			expNumVerified: 5,
+		},
+		{
+			name: "verifier unavailable",
+			verifiers: []proposer.L2HeaderClient{
+				&mockVerifier{syncedHeight: 100},
+				&mockVerifier{err: errVerifierUnavailable},
+			},
+			expErr: errVerifierUnavailable,
+		},
+		{
+			name: "lowest mismatch is reported",
+			verifiers: []proposer.L2HeaderClient{
+				&mockVerifier{
+					syncedHeight: 100,
+					roots:        map[uint64]l2common.Hash{17: {0x01}},
+				},
+				&mockVerifier{
+					syncedHeight: 100,
+					roots:        map[uint64]l2common.Hash{15: {0x01}},
+				},
+			},
+			expErr:    proposer.ErrStateRootMismatch,
+			expHeight: 15,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numVerified, err := proposer.VerifyStateRoots(
+				context.Background(), test.verifiers, start, stateRoots,
+			)
+			require.ErrorIs(t, err, test.expErr)
+			require.Equal(t, test.expNumVerified, numVerified)
+
+			var mismatch *proposer.StateRootMismatch
+			if errors.As(err, &mismatch) {
+				require.Equal(t, test.expHeight, mismatch.Height.Int64())
+				require.Equal(t, 1, mismatch.Verifier)
+			}
+		})
+	}
+}
@@ -238,3 +238,82 @@ func GenSequencerBatchParams(
 		Txs:                   txs,
 	}, nil
 }
+
+// SearchBatchLen returns the largest number of batch elements n, no greater
+// than numElements, for which size(n) does not exceed maxSize, along with the
+// number of times size was evaluated. The size of a compressed batch is
+// assumed to grow monotonically with the number of elements, though not
+// linearly, so the search first evaluates the full batch, then a guess
+// extrapolated from its size along with a slightly larger neighbor, and
+// finally bisects the remaining interval.
+// Zero is returned if no non-empty prefix of the batch fits.
+func SearchBatchLen(
+	numElements int,
+	maxSize uint64,
+	size func(n int) (uint64, error),
+) (int, int, error) {
+
+	var numProbes int
+	fits := func(n int) (uint64, bool, error) {
+		numProbes++
+		s, err := size(n)
+		if err != nil {
+			return 0, false, err
+		}
+		return s, s <= maxSize, nil
+	}
+
+	if numElements == 0 {
+		return 0, numProbes, nil
+	}
+
+	fullSize, ok, err := fits(numElements)
+	if err != nil {
+		return 0, numProbes, err
+	}
+	if ok {
+		return numElements, numProbes, nil
+	}
+
+	// Invariant: the first lo elements fit, the first hi elements do not.
+	lo, hi := 0, numElements
+
+	// Extrapolate from the size of the full batch, erring slightly on the
+	// small side so that the guess is likely to fit.
+	// If the guess fits, probe a slightly larger batch to narrow the interval
+	// that remains to be bisected.
+	guess := int(uint64(numElements) * maxSize / fullSize * 98 / 100)
+	step := numElements / 50
+	if step < 1 {
+		step = 1
+	}
+	for _, n := range []int{guess, guess + step} {
+		if n <= lo || n >= hi {
+			break
+		}
+		_, ok, err := fits(n)
+		if err != nil {
+			return 0, numProbes, err
+		}
+		if !ok {
+			hi = n
+			break
+		}
+		lo = n
+	}
+
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		_, ok, err := fits(mid)
+		if err != nil {
+			return 0, numProbes, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo, numProbes, nil
+}
@@ -2,6 +2,7 @@ package sequencer_test
 
 import (
 	"math/big"
+	"math/bits"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/go/batch-submitter/drivers/sequencer"
@@ -47,3 +48,70 @@ func TestBatchElementFromBlock(t *testing.T) {
 	require.False(t, element.IsSequencerTx())
 	require.Nil(t, element.Tx)
 }
+
+func TestSearchBatchLen(t *testing.T) {
+	tests := []struct {
+		name        string
+		numElements int
+		maxSize     uint64
+		size        func(n int) uint64
+		expLen      int
+	}{
+		{
+			name:        "empty",
+			numElements: 0,
+			maxSize:     100,
+			size:        func(n int) uint64 { return uint64(n) },
+			expLen:      0,
+		},
+		{
+			name:        "all fit",
+			numElements: 50,
+			maxSize:     100,
+			size:        func(n int) uint64 { return uint64(2 * n) },
+			expLen:      50,
+		},
+		{
+			name:        "linear",
+			numElements: 1000,
+			maxSize:     1000,
+			size:        func(n int) uint64 { return uint64(3*n + 10) },
+			expLen:      330,
+		},
+		{
+			name:        "sublinear",
+			numElements: 5000,
+			maxSize:     120000,
+			size: func(n int) uint64 {
+				return uint64(1000 + 40*n - n*n/400)
+			},
+			expLen: 3950,
+		},
+		{
+			name:        "none fit",
+			numElements: 10,
+			maxSize:     100,
+			size:        func(n int) uint64 { return uint64(200 * n) },
+			expLen:      0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var numCalls int
+			n, numProbes, err := sequencer.SearchBatchLen(
+				test.numElements, test.maxSize,
+				func(n int) (uint64, error) {
+					numCalls++
+					return test.size(n), nil
+				},
+			)
+			require.Nil(t, err)
+			require.Equal(t, test.expLen, n)
+			require.Equal(t, numCalls, numProbes)
+
+			// The search should never require more probes than bisection.
+			require.LessOrEqual(t, numProbes, 2+bits.Len(uint(test.numElements)))
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	l2common "github.com/ethereum-optimism/optimism/l2geth/common"
+	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// DefaultFetchConcurrency is the default number of L2 blocks that are
+	// requested concurrently.
+	DefaultFetchConcurrency = 8
+
+	// DefaultBlockCacheSize is the default number of BatchElements retained
+	// across polls.
+	DefaultBlockCacheSize = 10000
+
+	// fetchWindowFactor controls how many blocks are requested per window,
+	// relative to the fetch concurrency.
+	fetchWindowFactor = 4
+)
+
+// ErrL2Reorg signals that the fetched L2 blocks do not form a chain, because
+// the L2 chain was reorged while they were being fetched.
+var ErrL2Reorg = errors.New("fetched L2 blocks do not form a chain")
+
+// L2BlockClient is the subset of the L2 client used to fetch blocks.
+type L2BlockClient interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*l2types.Block, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*l2types.Header, error)
+}
+
+// blockElement is a BatchElement along with the hashes linking it to the L2
+// chain it was fetched from.
+type blockElement struct {
+	BatchElement
+
+	hash       l2common.Hash
+	parentHash l2common.Hash
+
+	// cached is set if the element was served from the cache rather than
+	// fetched from the L2 client.
+	cached bool
+}
+
+// BlockFetcher retrieves the BatchElements for consecutive L2 blocks, issuing a
+// bounded number of concurrent requests and caching the resulting elements in
+// an LRU cache. Since unsubmitted blocks are re-examined on every poll, the
+// cache allows subsequent polls to only fetch blocks that are new.
+//
+// NOTE: Elements are cached rather than blocks, as BatchElementFromBlock
+// mutates the transaction data of the block.
+//
+// Cached elements are keyed by number, so the hashes of the returned blocks
+// are checked to form a chain ending in the live L2 block. On any mismatch,
+// or when the L2 tip moves below cached blocks, the cache is purged.
+type BlockFetcher struct {
+	client      L2BlockClient
+	concurrency int
+	cache       *lru.Cache
+}
+
+// NewBlockFetcher creates a BlockFetcher that fetches at most concurrency
+// blocks at a time and retains at most cacheSize BatchElements.
+func NewBlockFetcher(
+	client L2BlockClient,
+	concurrency int,
+	cacheSize int,
+) (*BlockFetcher, error) {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockFetcher{
+		client:      client,
+		concurrency: concurrency,
+		cache:       cache,
+	}, nil
+}
+
+// FetchBatchElements returns the BatchElements for the L2 blocks between start
+// and end, where end is *exclusive*. Elements are returned in ascending order,
+// stopping before the element that would cause the total size of the
+// length-prefixed sequencer txs to exceed maxTxSize. The total size of the
+// returned sequencer txs is returned alongside the elements.
+//
+// Cached elements below start have already been submitted, and are evicted.
+// Cached elements at or above end were rolled back, and cause the cache to be
+// purged. If the L2 chain reorgs while the elements are fetched, the cache is
+// purged and the elements are fetched once more before ErrL2Reorg is returned.
+func (f *BlockFetcher) FetchBatchElements(
+	ctx context.Context,
+	start, end, maxTxSize uint64,
+) ([]BatchElement, uint64, error) {
+
+	f.evictBelow(start)
+	if f.hasFrom(end) {
+		log.Warn("L2 chain rolled back, purging block cache", "end", end)
+		f.Purge()
+	}
+
+	batchElements, totalTxSize, err := f.fetchBatchElements(
+		ctx, start, end, maxTxSize,
+	)
+	if err == ErrL2Reorg {
+		log.Warn("L2 reorg detected, purging block cache", "start", start,
+			"end", end)
+		f.Purge()
+		batchElements, totalTxSize, err = f.fetchBatchElements(
+			ctx, start, end, maxTxSize,
+		)
+		if err == ErrL2Reorg {
+			f.Purge()
+		}
+	}
+	return batchElements, totalTxSize, err
+}
+
+// fetchBatchElements implements FetchBatchElements, returning ErrL2Reorg if
+// the elements do not form a chain ending in the live L2 block.
+func (f *BlockFetcher) fetchBatchElements(
+	ctx context.Context,
+	start, end, maxTxSize uint64,
+) ([]BatchElement, uint64, error) {
+
+	var (
+		batchElements []BatchElement
+		totalTxSize   uint64
+		last          *blockElement
+	)
+	windowSize := uint64(f.concurrency * fetchWindowFactor)
+windows:
+	for windowStart := start; windowStart < end; windowStart += windowSize {
+		windowEnd := windowStart + windowSize
+		if windowEnd > end {
+			windowEnd = end
+		}
+
+		window, err := f.fetchWindow(ctx, windowStart, windowEnd)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := range window {
+			element := &window[i]
+
+			// For each sequencer transaction, update our running total
+			// with the size of the transaction.
+			if element.IsSequencerTx() {
+				txSize := uint64(TxLenSize + element.Tx.Size())
+				if totalTxSize+txSize > maxTxSize {
+					break windows
+				}
+				totalTxSize += txSize
+			}
+
+			if last != nil && element.parentHash != last.hash {
+				return nil, 0, ErrL2Reorg
+			}
+			last = element
+			batchElements = append(batchElements, element.BatchElement)
+		}
+	}
+
+	// The elements form a chain, so they are all canonical if the last one
+	// is. That is only known for fetched elements, cached ones are checked
+	// against the live header.
+	if last != nil && last.cached {
+		header, err := f.client.HeaderByNumber(
+			ctx, new(big.Int).SetUint64(start+uint64(len(batchElements))-1),
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		if header.Hash() != last.hash {
+			return nil, 0, ErrL2Reorg
+		}
+	}
+
+	return batchElements, totalTxSize, nil
+}
+
+// Purge removes all cached elements, e.g. after an L2 reorg.
+func (f *BlockFetcher) Purge() {
+	f.cache.Purge()
+}
+
+// fetchWindow returns the BatchElements for the blocks between start and end,
+// where end is *exclusive*, fetching any uncached blocks concurrently.
+func (f *BlockFetcher) fetchWindow(
+	ctx context.Context,
+	start, end uint64,
+) ([]blockElement, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		elements = make([]blockElement, end-start)
+		sem      = make(chan struct{}, f.concurrency)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		fetchErr error
+	)
+	for number := start; number < end; number++ {
+		if cached, ok := f.cache.Get(number); ok {
+			element := cached.(blockElement)
+			element.cached = true
+			elements[number-start] = element
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			block, err := f.client.BlockByNumber(
+				ctx, new(big.Int).SetUint64(number),
+			)
+			if err != nil {
+				errOnce.Do(func() {
+					fetchErr = err
+					cancel()
+				})
+				return
+			}
+
+			element := blockElement{
+				BatchElement: BatchElementFromBlock(block),
+				hash:         block.Hash(),
+				parentHash:   block.ParentHash(),
+			}
+			f.cache.Add(number, element)
+			elements[number-start] = element
+		}(number)
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return elements, nil
+}
+
+// evictBelow removes all cached elements for blocks below number.
+func (f *BlockFetcher) evictBelow(number uint64) {
+	for _, key := range f.cache.Keys() {
+		if key.(uint64) < number {
+			f.cache.Remove(key)
+		}
+	}
+}
+
+// hasFrom returns true if any cached element is for a block at or above
+// number.
+func (f *BlockFetcher) hasFrom(number uint64) bool {
+	for _, key := range f.cache.Keys() {
+		if key.(uint64) >= number {
+			return true
+		}
+	}
+	return false
+}
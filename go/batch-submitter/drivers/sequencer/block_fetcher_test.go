@@ -0,0 +1,244 @@
+package sequencer_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/batch-submitter/drivers/sequencer"
+	l2common "github.com/ethereum-optimism/optimism/l2geth/common"
+	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var errBlockNotFound = errors.New("block not found")
+
+// mockL2BlockClient serves single-tx blocks, recording the number of requests
+// per block and the maximum number of requests in flight. Blocks from forkAt
+// onwards are tagged with fork, so that changing it reorgs the chain.
+type mockL2BlockClient struct {
+	mu        sync.Mutex
+	numBlocks uint64
+	requests  map[uint64]int
+	forkAt    uint64
+	fork      byte
+	hashes    map[uint64]l2common.Hash
+
+	inFlight    int32
+	maxInFlight int32
+}
+
+func newMockL2BlockClient(numBlocks uint64) *mockL2BlockClient {
+	return &mockL2BlockClient{
+		numBlocks: numBlocks,
+		requests:  make(map[uint64]int),
+		hashes:    make(map[uint64]l2common.Hash),
+	}
+}
+
+func (c *mockL2BlockClient) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*l2types.Block, error) {
+
+	n := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&c.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&c.maxInFlight, max, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests[number.Uint64()]++
+	if number.Uint64() >= c.numBlocks {
+		return nil, errBlockNotFound
+	}
+	return c.block(number.Uint64()), nil
+}
+
+func (c *mockL2BlockClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*l2types.Header, error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number.Uint64() >= c.numBlocks {
+		return nil, errBlockNotFound
+	}
+	return c.block(number.Uint64()).Header(), nil
+}
+
+// block returns block number, linked to its parent. A fresh block is
+// returned on each call, since the fetcher mutates the tx data.
+func (c *mockL2BlockClient) block(number uint64) *l2types.Block {
+	var parentHash l2common.Hash
+	if number > 0 {
+		parentHash = c.hash(number - 1)
+	}
+
+	tx := l2types.NewTransaction(
+		number, l2common.Address{}, big.NewInt(0), 21000,
+		big.NewInt(1), make([]byte, 97),
+	)
+	tx.SetL1BlockNumber(number)
+	header := &l2types.Header{
+		ParentHash: parentHash,
+		Number:     new(big.Int).SetUint64(number),
+		Time:       number,
+	}
+	if number >= c.forkAt {
+		header.Extra = []byte{c.fork}
+	}
+	return l2types.NewBlock(header, []*l2types.Transaction{tx}, nil, nil)
+}
+
+// hash returns the memoized hash of block number.
+func (c *mockL2BlockClient) hash(number uint64) l2common.Hash {
+	hash, ok := c.hashes[number]
+	if !ok {
+		hash = c.block(number).Hash()
+		c.hashes[number] = hash
+	}
+	return hash
+}
+
+// reorg replaces the blocks from number onwards and sets the chain length.
+func (c *mockL2BlockClient) reorg(number, numBlocks uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.forkAt = number
+	c.fork++
+	c.numBlocks = numBlocks
+	c.hashes = make(map[uint64]l2common.Hash)
+}
+
+func (c *mockL2BlockClient) numRequests(number uint64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests[number]
+}
+
+// TestBlockFetcherFetchBatchElements asserts that elements are returned in
+// order, that requests are bounded by the configured concurrency, and that
+// cached elements are not fetched again on subsequent polls.
+func TestBlockFetcherFetchBatchElements(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 4
+	client := newMockL2BlockClient(100)
+	fetcher, err := sequencer.NewBlockFetcher(client, concurrency, 1000)
+	require.Nil(t, err)
+
+	elements, totalTxSize, err := fetcher.FetchBatchElements(
+		context.Background(), 10, 60, 1<<20,
+	)
+	require.Nil(t, err)
+	require.Len(t, elements, 50)
+	for i, element := range elements {
+		require.Equal(t, uint64(10+i), element.Timestamp)
+		require.Equal(t, uint64(10+i), element.BlockNumber)
+		require.True(t, element.IsSequencerTx())
+	}
+	require.Equal(t, uint64(50*(sequencer.TxLenSize+elements[0].Tx.Size())),
+		totalTxSize)
+	require.LessOrEqual(t, atomic.LoadInt32(&client.maxInFlight), int32(concurrency))
+
+	// Poll again with a later start and end, only the new blocks should be
+	// requested.
+	elements, _, err = fetcher.FetchBatchElements(
+		context.Background(), 20, 80, 1<<20,
+	)
+	require.Nil(t, err)
+	require.Len(t, elements, 60)
+	for number := uint64(20); number < 80; number++ {
+		require.Equal(t, 1, client.numRequests(number))
+	}
+
+	// Purging the cache forces blocks to be fetched again.
+	fetcher.Purge()
+	_, _, err = fetcher.FetchBatchElements(context.Background(), 10, 11, 1<<20)
+	require.Nil(t, err)
+	require.Equal(t, 2, client.numRequests(10))
+}
+
+// TestBlockFetcherMaxTxSize asserts that elements are only returned while the
+// total size of their sequencer txs does not exceed the maximum.
+func TestBlockFetcherMaxTxSize(t *testing.T) {
+	t.Parallel()
+
+	client := newMockL2BlockClient(100)
+	fetcher, err := sequencer.NewBlockFetcher(client, 4, 1000)
+	require.Nil(t, err)
+
+	elements, _, err := fetcher.FetchBatchElements(
+		context.Background(), 0, 100, 1<<20,
+	)
+	require.Nil(t, err)
+	txSize := uint64(sequencer.TxLenSize + elements[0].Tx.Size())
+
+	elements, totalTxSize, err := fetcher.FetchBatchElements(
+		context.Background(), 0, 100, 10*txSize+txSize/2,
+	)
+	require.Nil(t, err)
+	require.Len(t, elements, 10)
+	require.Equal(t, 10*txSize, totalTxSize)
+}
+
+// TestBlockFetcherError asserts that a failure to fetch any block in the range
+// is returned to the caller.
+func TestBlockFetcherError(t *testing.T) {
+	t.Parallel()
+
+	client := newMockL2BlockClient(50)
+	fetcher, err := sequencer.NewBlockFetcher(client, 4, 1000)
+	require.Nil(t, err)
+
+	_, _, err = fetcher.FetchBatchElements(context.Background(), 0, 100, 1<<20)
+	require.ErrorIs(t, err, errBlockNotFound)
+}
+
+// TestBlockFetcherReorg asserts that cached elements are not returned once the
+// L2 chain has reorged or rolled back.
+func TestBlockFetcherReorg(t *testing.T) {
+	t.Parallel()
+
+	client := newMockL2BlockClient(100)
+	fetcher, err := sequencer.NewBlockFetcher(client, 4, 1000)
+	require.Nil(t, err)
+
+	_, _, err = fetcher.FetchBatchElements(context.Background(), 0, 100, 1<<20)
+	require.Nil(t, err)
+
+	// Reorg the tip, the cached elements no longer match the live header
+	// and all blocks are fetched again.
+	client.reorg(90, 100)
+	elements, _, err := fetcher.FetchBatchElements(
+		context.Background(), 50, 100, 1<<20,
+	)
+	require.Nil(t, err)
+	require.Len(t, elements, 50)
+	for number := uint64(50); number < 100; number++ {
+		require.Equal(t, 2, client.numRequests(number))
+	}
+
+	// Roll the chain back and replace the blocks from 70 onwards. The cached
+	// elements above the new tip cause the cache to be purged.
+	client.reorg(70, 80)
+	elements, _, err = fetcher.FetchBatchElements(
+		context.Background(), 50, 80, 1<<20,
+	)
+	require.Nil(t, err)
+	require.Len(t, elements, 30)
+	for number := uint64(50); number < 80; number++ {
+		require.Equal(t, 3, client.numRequests(number))
+	}
+}
@@ -0,0 +1,212 @@
+package sequencer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
+	l2rlp "github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// numTxFields is the number of RLP items in a serialized L2 transaction:
+	// nonce, gas_price, gas_limit, to, value, data, v, r and s.
+	numTxFields = 9
+
+	// MaxDecompressedBatchSize is the maximum size of the decompressed
+	// transactions of a zstd batch, which bounds the memory a small batch
+	// with a high compression ratio can expand into.
+	MaxDecompressedBatchSize = 32 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidZstdDictionary signals that the provided bytes could not be
+	// parsed as a zstd dictionary.
+	ErrInvalidZstdDictionary = errors.New("invalid zstd dictionary")
+
+	// zstdDictMu guards zstdDict.
+	zstdDictMu sync.RWMutex
+
+	// zstdDict is the optional pre-trained dictionary used when encoding and
+	// decoding zstd batches.
+	zstdDict []byte
+)
+
+// SetZstdDictionary installs a pre-trained zstd dictionary that will be used
+// to compress BatchTypeZstd and BatchTypeZstdColumnar batches, and that will
+// be made available when decompressing them. Dictionaries are expected to be
+// trained offline over historical batch data, e.g. using `zstd --train`.
+// Passing a nil dictionary reverts to dictionary-less compression.
+//
+// NOTE: The dictionary ID is recorded in each compressed frame, so any party
+// decoding the batch must have loaded the same dictionary.
+func SetZstdDictionary(dict []byte) error {
+	if dict != nil {
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderDict(dict))
+		if err != nil {
+			return ErrInvalidZstdDictionary
+		}
+		_ = enc.Close()
+	}
+
+	zstdDictMu.Lock()
+	zstdDict = dict
+	zstdDictMu.Unlock()
+
+	return nil
+}
+
+// newZstdWriter returns a zstd encoder writing to w, configured with the
+// installed dictionary, if any.
+func newZstdWriter(w io.Writer) (*zstd.Encoder, error) {
+	opts := []zstd.EOption{
+		zstd.WithEncoderLevel(zstd.SpeedBestCompression),
+		zstd.WithEncoderConcurrency(1),
+	}
+
+	zstdDictMu.RLock()
+	if zstdDict != nil {
+		opts = append(opts, zstd.WithEncoderDict(zstdDict))
+	}
+	zstdDictMu.RUnlock()
+
+	return zstd.NewWriter(w, opts...)
+}
+
+// zstdDecompress fully decompresses the remainder of r using the installed
+// dictionary, if any, and returns a reader over the decompressed bytes.
+// Batches that decompress to more than MaxDecompressedBatchSize bytes are
+// rejected as malformed.
+func zstdDecompress(r io.Reader) (io.Reader, error) {
+	opts := []zstd.DOption{
+		zstd.WithDecoderConcurrency(1),
+	}
+
+	zstdDictMu.RLock()
+	if zstdDict != nil {
+		opts = append(opts, zstd.WithDecoderDicts(zstdDict))
+	}
+	zstdDictMu.RUnlock()
+
+	zr, err := zstd.NewReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var decodedOutput bytes.Buffer
+	n, err := io.Copy(&decodedOutput, io.LimitReader(zr, MaxDecompressedBatchSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > MaxDecompressedBatchSize {
+		return nil, fmt.Errorf("%w: decompressed size exceeds %d bytes",
+			ErrMalformedBatch, MaxDecompressedBatchSize)
+	}
+
+	return bytes.NewReader(decodedOutput.Bytes()), nil
+}
+
+// writeTxs writes each transaction to w as a length-prefixed RLP encoding:
+//  - tx_len:   3 bytes
+//  - tx_bytes: tx_len bytes
+func writeTxs(w io.Writer, txs []*CachedTx) error {
+	for _, tx := range txs {
+		if err := writeUint64(w, uint64(tx.Size()), TxLenSize); err != nil {
+			return err
+		}
+		if _, err := w.Write(tx.RawTx()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeColumnarTxs writes the transactions to w using a columnar layout, such
+// that like fields of every transaction are stored adjacently. Grouping
+// signatures, nonces, calldata, etc. allows the compressor to exploit the
+// similarity between values of the same kind. The layout is:
+//  - num_txs:               3 bytes
+//  - for each of the 9 tx fields, in RLP order:
+//    - num_txs * rlp_item:  the RLP-encoded field of each tx
+// Since each RLP item is self-delimiting, no lengths are required.
+func writeColumnarTxs(w io.Writer, txs []*CachedTx) error {
+	if err := writeUint64(w, uint64(len(txs)), 3); err != nil {
+		return err
+	}
+
+	columns := make([]bytes.Buffer, numTxFields)
+	for _, tx := range txs {
+		content, _, err := l2rlp.SplitList(tx.RawTx())
+		if err != nil {
+			return err
+		}
+		for i := 0; i < numTxFields; i++ {
+			_, _, rest, err := l2rlp.Split(content)
+			if err != nil {
+				return err
+			}
+			columns[i].Write(content[:len(content)-len(rest)])
+			content = rest
+		}
+		if len(content) != 0 {
+			return ErrMalformedBatch
+		}
+	}
+
+	for i := range columns {
+		if _, err := w.Write(columns[i].Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readColumnarTxs decodes the transactions written by writeColumnarTxs,
+// reassembling the RLP encoding of each transaction from its columns.
+func readColumnarTxs(r io.Reader) ([]*CachedTx, error) {
+	var numTxs uint64
+	if err := readUint64(r, &numTxs, 3); err != nil {
+		if err == io.EOF {
+			return nil, ErrMalformedBatch
+		}
+		return nil, err
+	}
+
+	// The number of txs is read from the input, so the columns are grown as
+	// items are decoded rather than allocated up front. This bounds memory
+	// usage by the size of the payload for malformed batches.
+	columns := make([][]l2rlp.RawValue, numTxFields)
+	stream := l2rlp.NewStream(r, 0)
+	for j := range columns {
+		for i := uint64(0); i < numTxs; i++ {
+			item, err := stream.Raw()
+			if err != nil {
+				return nil, ErrMalformedBatch
+			}
+			columns[j] = append(columns[j], item)
+		}
+	}
+
+	txs := make([]*CachedTx, 0, numTxs)
+	txFields := make([]l2rlp.RawValue, numTxFields)
+	for i := uint64(0); i < numTxs; i++ {
+		for j := range columns {
+			txFields[j] = columns[j][i]
+		}
+		rawTx, err := l2rlp.EncodeToBytes(txFields)
+		if err != nil {
+			return nil, err
+		}
+		tx := new(l2types.Transaction)
+		if err := l2rlp.DecodeBytes(rawTx, tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, NewCachedTx(tx))
+	}
+	return txs, nil
+}
@@ -25,6 +25,12 @@ const (
 	appendSequencerBatchMethodName = "appendSequencerBatch"
 )
 
+const (
+	// minCompressionRatio bounds the compression ratio used to estimate the
+	// number of blocks to fetch for a batch.
+	minCompressionRatio = 0.1
+)
+
 var bigOne = new(big.Int).SetUint64(1)
 
 type Config struct {
@@ -38,6 +44,15 @@ type Config struct {
 	KeyId       string
 	KeyAddress  common.Address
 	KMS         kms.KMS
+	BatchType   BatchType
+
+	// FetchConcurrency is the maximum number of L2 blocks requested
+	// concurrently. Defaults to DefaultFetchConcurrency if zero.
+	FetchConcurrency int
+
+	// BlockCacheSize is the maximum number of L2 blocks whose batch elements
+	// are cached across polls. Defaults to DefaultBlockCacheSize if zero.
+	BlockCacheSize int
 }
 
 type Driver struct {
@@ -47,6 +62,12 @@ type Driver struct {
 	walletAddr     common.Address
 	ctcABI         *abi.ABI
 	metrics        *metrics.Metrics
+	fetcher        *BlockFetcher
+
+	// compressionRatio is the ratio between the calldata size and the
+	// uncompressed size of the sequencer txs of the last crafted batch. It is
+	// used to estimate how many blocks to fetch for the next batch.
+	compressionRatio float64
 }
 
 func NewDriver(cfg Config) (*Driver, error) {
@@ -76,13 +97,30 @@ func NewDriver(cfg Config) (*Driver, error) {
 
 	walletAddr := cfg.KeyAddress
 
+	fetchConcurrency := cfg.FetchConcurrency
+	if fetchConcurrency == 0 {
+		fetchConcurrency = DefaultFetchConcurrency
+	}
+	blockCacheSize := cfg.BlockCacheSize
+	if blockCacheSize == 0 {
+		blockCacheSize = DefaultBlockCacheSize
+	}
+	fetcher, err := NewBlockFetcher(
+		cfg.L2Client, fetchConcurrency, blockCacheSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Driver{
-		cfg:            cfg,
-		ctcContract:    ctcContract,
-		rawCtcContract: rawCtcContract,
-		walletAddr:     walletAddr,
-		ctcABI:         ctcABI,
-		metrics:        metrics.NewMetrics(cfg.Name),
+		cfg:              cfg,
+		ctcContract:      ctcContract,
+		rawCtcContract:   rawCtcContract,
+		walletAddr:       walletAddr,
+		ctcABI:           ctcABI,
+		metrics:          metrics.NewMetrics(cfg.Name),
+		fetcher:          fetcher,
+		compressionRatio: 1,
 	}, nil
 }
 
@@ -164,98 +202,129 @@ func (d *Driver) CraftBatchTx(
 	log.Info(name+" crafting batch tx", "start", start, "end", end,
 		"nonce", nonce)
 
-	var (
-		batchElements []BatchElement
-		totalTxSize   uint64
+	// Fetch blocks until the total size of their sequencer txs exceeds the
+	// uncompressed size expected to compress to the configured maximum, based
+	// on the compression ratio of the last batch. This is an estimate, so below
+	// the batch is further whittled until the call data size adheres to the
+	// maximum. A small margin is added to avoid undershooting when the ratio
+	// improves.
+	maxUncompressedSize := uint64(
+		float64(d.cfg.MaxTxSize) / d.compressionRatio * 1.1,
 	)
-	for i := new(big.Int).Set(start); i.Cmp(end) < 0; i.Add(i, bigOne) {
-		block, err := d.cfg.L2Client.BlockByNumber(ctx, i)
-		if err != nil {
-			return nil, totalTxSize, err
-		}
-
-		// For each sequencer transaction, update our running total with the
-		// size of the transaction.
-		batchElement := BatchElementFromBlock(block)
-		if batchElement.IsSequencerTx() {
-			// Abort once the total size estimate is greater than the maximum
-			// configured size. This is a conservative estimate, as the total
-			// calldata size will be greater when batch contexts are included.
-			// Below this set will be further whittled until the raw call data
-			// size also adheres to this constraint.
-			txLen := batchElement.Tx.Size()
-			if totalTxSize+uint64(TxLenSize+txLen) > d.cfg.MaxTxSize {
-				break
-			}
-			totalTxSize += uint64(TxLenSize + txLen)
-		}
-
-		batchElements = append(batchElements, batchElement)
+	batchElements, _, err := d.fetcher.FetchBatchElements(
+		ctx, start.Uint64(), end.Uint64(), maxUncompressedSize,
+	)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	// craftCallData serializes the first n batch elements into the call data
+	// of an appendSequencerBatch call.
 	shouldStartAt := start.Uint64()
-	var pruneCount int
-	for {
+	craftCallData := func(n int) ([]byte, error) {
 		batchParams, err := GenSequencerBatchParams(
-			shouldStartAt, d.cfg.BlockOffset, batchElements,
+			shouldStartAt, d.cfg.BlockOffset, batchElements[:n],
 		)
 		if err != nil {
-			return nil, totalTxSize, err
+			return nil, err
 		}
+		batchParams.Typ = d.cfg.BatchType
 
 		batchArguments, err := batchParams.Serialize()
 		if err != nil {
-			return nil, totalTxSize, err
+			return nil, err
 		}
 
 		appendSequencerBatchID := d.ctcABI.Methods[appendSequencerBatchMethodName].ID
-		batchCallData := append(appendSequencerBatchID, batchArguments...)
-
-		// Continue pruning until calldata size is less than configured max.
-		if uint64(len(batchCallData)) > d.cfg.MaxTxSize {
-			oldLen := len(batchElements)
-			newBatchElementsLen := (oldLen * 9) / 10
-			batchElements = batchElements[:newBatchElementsLen]
-			log.Info(name+" pruned batch", "old_num_txs", oldLen, "new_num_txs", newBatchElementsLen)
-			pruneCount++
-			continue
-		}
+		batchCallData := make([]byte, 0, len(appendSequencerBatchID)+len(batchArguments))
+		batchCallData = append(batchCallData, appendSequencerBatchID...)
+		return append(batchCallData, batchArguments...), nil
+	}
 
-		d.metrics.NumElementsPerBatch.Observe(float64(len(batchElements)))
-		d.metrics.BatchPruneCount.Set(float64(pruneCount))
+	// Search for the largest prefix of the batch elements whose call data
+	// size is less than the configured max. The call data of each candidate
+	// is retained to avoid serializing the final batch twice.
+	batchCallDatas := make(map[int][]byte)
+	numElements, numProbes, err := SearchBatchLen(
+		len(batchElements), d.cfg.MaxTxSize,
+		func(n int) (uint64, error) {
+			batchCallData, err := craftCallData(n)
+			if err != nil {
+				return 0, err
+			}
+			batchCallDatas[n] = batchCallData
+			return uint64(len(batchCallData)), nil
+		},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	if numElements < len(batchElements) {
+		log.Info(name+" pruned batch", "old_num_txs", len(batchElements),
+			"new_num_txs", numElements, "num_probes", numProbes)
+	}
 
-		log.Info(name+" batch constructed", "num_txs", len(batchElements), "length", len(batchCallData))
+	// The empty batch is never probed, so it is serialized separately.
+	batchCallData, ok := batchCallDatas[numElements]
+	if !ok {
+		batchCallData, err = craftCallData(numElements)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	batchElements = batchElements[:numElements]
 
-		opts, err := ethawskmssigner.NewAwsKmsTransactorWithChainID(&d.cfg.KMS, d.cfg.KeyId, d.cfg.ChainID)
+	var totalTxSize uint64
+	for _, batchElement := range batchElements {
+		if batchElement.IsSequencerTx() {
+			totalTxSize += uint64(TxLenSize + batchElement.Tx.Size())
+		}
+	}
 
-		if err != nil {
-			return nil, totalTxSize, err
+	// Record the compression ratio to size the fetch of the next batch,
+	// bounded so that a degenerate batch cannot cause an excessive fetch.
+	if totalTxSize > 0 {
+		d.compressionRatio = float64(len(batchCallData)) / float64(totalTxSize)
+		if d.compressionRatio < minCompressionRatio {
+			d.compressionRatio = minCompressionRatio
 		}
-		opts.Context = ctx
-		opts.Nonce = nonce
-		opts.NoSend = true
+	}
+
+	d.metrics.NumElementsPerBatch.Observe(float64(len(batchElements)))
+	d.metrics.BatchPruneCount.Set(float64(numProbes - 1))
+
+	log.Info(name+" batch constructed", "num_txs", len(batchElements),
+		"length", len(batchCallData), "compression_ratio", d.compressionRatio)
+
+	opts, err := ethawskmssigner.NewAwsKmsTransactorWithChainID(&d.cfg.KMS, d.cfg.KeyId, d.cfg.ChainID)
+
+	if err != nil {
+		return nil, totalTxSize, err
+	}
+	opts.Context = ctx
+	opts.Nonce = nonce
+	opts.NoSend = true
 
+	tx, err := d.rawCtcContract.RawTransact(opts, batchCallData)
+	switch {
+	case err == nil:
+		return tx, totalTxSize, nil
+
+	// If the transaction failed because the backend does not support
+	// eth_maxPriorityFeePerGas, fallback to using the default constant.
+	// Currently Alchemy is the only backend provider that exposes this
+	// method, so in the event their API is unreachable we can fallback to a
+	// degraded mode of operation. This also applies to our test
+	// environments, as hardhat doesn't support the query either.
+	case drivers.IsMaxPriorityFeePerGasNotFoundError(err):
+		log.Warn(d.cfg.Name + " eth_maxPriorityFeePerGas is unsupported " +
+			"by current backend, using fallback gasTipCap")
+		opts.GasTipCap = drivers.FallbackGasTipCap
 		tx, err := d.rawCtcContract.RawTransact(opts, batchCallData)
-		switch {
-		case err == nil:
-			return tx, totalTxSize, nil
-
-		// If the transaction failed because the backend does not support
-		// eth_maxPriorityFeePerGas, fallback to using the default constant.
-		// Currently Alchemy is the only backend provider that exposes this
-		// method, so in the event their API is unreachable we can fallback to a
-		// degraded mode of operation. This also applies to our test
-		// environments, as hardhat doesn't support the query either.
-		case drivers.IsMaxPriorityFeePerGasNotFoundError(err):
-			log.Warn(d.cfg.Name + " eth_maxPriorityFeePerGas is unsupported " +
-				"by current backend, using fallback gasTipCap")
-			opts.GasTipCap = drivers.FallbackGasTipCap
-			tx, err := d.rawCtcContract.RawTransact(opts, batchCallData)
-			return tx, totalTxSize, err
-
-		default:
-			return nil, totalTxSize, err
-		}
+		return tx, totalTxSize, err
+
+	default:
+		return nil, totalTxSize, err
 	}
 }
 
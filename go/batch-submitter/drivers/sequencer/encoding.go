@@ -22,6 +22,11 @@ const (
 var (
 	byteOrder         = binary.BigEndian
 	ErrMalformedBatch = errors.New("malformed batch")
+
+	// ErrUnknownBatchType signals that a batch is either being encoded with,
+	// or was decoded with a marker context signaling, an unsupported
+	// BatchType.
+	ErrUnknownBatchType = errors.New("unknown batch type")
 )
 
 // BatchContext denotes a range of transactions that belong the same batch. It
@@ -88,17 +93,70 @@ const (
 	// BatchTypeBrotli represents a batch type where the transaction data is
 	// compressed using brotli.
 	BatchTypeBrotli BatchType = 0
+
+	// BatchTypeZstd represents a batch type where the transaction data is
+	// compressed using zstd, optionally with a pre-trained dictionary.
+	BatchTypeZstd BatchType = 1
+
+	// BatchTypeZstdColumnar represents a batch type where the transaction
+	// data is split into one column per transaction field before being
+	// compressed using zstd, optionally with a pre-trained dictionary.
+	BatchTypeZstdColumnar BatchType = 2
 )
 
+// batchTypeNames maps each supported BatchType to its human-readable name.
+var batchTypeNames = map[BatchType]string{
+	BatchTypeLegacy:       "legacy",
+	BatchTypeBrotli:       "brotli",
+	BatchTypeZstd:         "zstd",
+	BatchTypeZstdColumnar: "zstd-columnar",
+}
+
+// String returns the human-readable name of the BatchType.
+func (t BatchType) String() string {
+	if name, ok := batchTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int8(t))
+}
+
+// ParseBatchType returns the BatchType with the given human-readable name.
+func ParseBatchType(name string) (BatchType, error) {
+	for batchType, batchTypeName := range batchTypeNames {
+		if batchTypeName == name {
+			return batchType, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownBatchType, name)
+}
+
 // MarkerContext returns the marker context, if any, for the given batch type.
-func MarkerContext() *BatchContext {
-	// Brotli marker context sets block number equal to zero.
-	return &BatchContext{
-		Timestamp:   0,
-		BlockNumber: 0,
+func MarkerContext(batchType BatchType) *BatchContext {
+	switch batchType {
+
+	// No marker context for legacy encoding.
+	case BatchTypeLegacy:
+		return nil
+
+	// Typed batches set the block number equal to the batch type, e.g. the
+	// brotli marker context sets block number equal to zero.
+	default:
+		return &BatchContext{
+			Timestamp:   0,
+			BlockNumber: uint64(batchType),
+		}
 	}
 }
 
+// batchTypeFromMarker returns the BatchType signaled by the given marker
+// context. An error is returned if the type is not supported.
+func batchTypeFromMarker(c *BatchContext) (BatchType, error) {
+	if c.BlockNumber > uint64(BatchTypeZstdColumnar) {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownBatchType, c.BlockNumber)
+	}
+	return BatchType(c.BlockNumber), nil
+}
+
 // AppendSequencerBatchParams holds the raw data required to submit a batch of
 // L2 txs to L1 CTC contract. Rather than encoding the objects using the
 // standard ABI encoding, a custom encoding is and provided in the call data to
@@ -123,6 +181,11 @@ type AppendSequencerBatchParams struct {
 	// Txs contains all sequencer txs that will be recorded in the L1 CTC
 	// contract.
 	Txs []*CachedTx
+
+	// Typ is the encoding used for the transaction data. The zero value
+	// selects BatchTypeBrotli. When decoding, it is set to the type signaled
+	// by the marker context, or BatchTypeLegacy if none is present.
+	Typ BatchType
 }
 
 // Write encodes the AppendSequencerBatchParams using the following format:
@@ -133,6 +196,10 @@ type AppendSequencerBatchParams struct {
 //  - [num txs ommitted]
 //    - tx_len:                       3 bytes
 //    - tx_bytes:                     tx_len bytes
+//
+// Unless the batch type is BatchTypeLegacy, the contexts are preceded by a
+// marker context signaling the batch type, and the transaction data is
+// compressed according to that type.
 func (p *AppendSequencerBatchParams) Write(w *bytes.Buffer) error {
 
 	_ = writeUint64(w, p.ShouldStartAtElement, 5)
@@ -148,12 +215,17 @@ func (p *AppendSequencerBatchParams) Write(w *bytes.Buffer) error {
 		return ErrMalformedBatch
 	}
 
+	if _, ok := batchTypeNames[p.Typ]; !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownBatchType, p.Typ)
+	}
+
 	// copy the contexts as to not malleate the struct
 	// when it is a typed batch
 	contexts := make([]BatchContext, 0, len(p.Contexts)+1)
 	// Add the marker context, if any, for non-legacy encodings.
-	markerContext := MarkerContext()
-	contexts = append(contexts, *markerContext)
+	if markerContext := MarkerContext(p.Typ); markerContext != nil {
+		contexts = append(contexts, *markerContext)
+	}
 	contexts = append(contexts, p.Contexts...)
 
 	// Write number of contexts followed by each fixed-size BatchContext.
@@ -162,21 +234,33 @@ func (p *AppendSequencerBatchParams) Write(w *bytes.Buffer) error {
 		context.Write(w)
 	}
 
+	switch p.Typ {
+	case BatchTypeLegacy:
+		return writeTxs(w, p.Txs)
+
 	// Compress data for each transaction.
-	bw := brotli.NewWriterLevel(w, 11)
-	for _, tx := range p.Txs {
-		if err := writeUint64(bw, uint64(tx.Size()), TxLenSize); err != nil {
+	case BatchTypeBrotli:
+		bw := brotli.NewWriterLevel(w, 11)
+		if err := writeTxs(bw, p.Txs); err != nil {
 			return err
 		}
-		if _, err := bw.Write(tx.RawTx()); err != nil {
+		return bw.Close()
+
+	default:
+		zw, err := newZstdWriter(w)
+		if err != nil {
 			return err
 		}
+		if p.Typ == BatchTypeZstdColumnar {
+			err = writeColumnarTxs(zw, p.Txs)
+		} else {
+			err = writeTxs(zw, p.Txs)
+		}
+		if err != nil {
+			return err
+		}
+		return zw.Close()
 	}
-	if err := bw.Close(); err != nil {
-		return err
-	}
-
-	return nil
 }
 
 // Serialize performs the same encoding as Write, but returns the resulting
@@ -200,6 +284,9 @@ func (p *AppendSequencerBatchParams) Serialize() ([]byte, error) {
 //  - [num txs ommitted]
 //    - tx_len:                       3 bytes
 //    - tx_bytes:                     tx_len bytes
+//
+// If the first context is a marker context, the transaction data is
+// decompressed according to the signaled batch type, which is recorded in Typ.
 func (p *AppendSequencerBatchParams) Read(r io.Reader) error {
 	if err := readUint64(r, &p.ShouldStartAtElement, 5); err != nil {
 		return err
@@ -226,19 +313,46 @@ func (p *AppendSequencerBatchParams) Read(r io.Reader) error {
 		}
 
 		if i == 0 && batchContext.Timestamp == 0 {
-			batchType = BatchTypeBrotli
+			typ, err := batchTypeFromMarker(&batchContext)
+			if err != nil {
+				return err
+			}
+			batchType = typ
 			continue
 		}
 
 		p.Contexts = append(p.Contexts, batchContext)
 	}
+	p.Typ = batchType
 
 	// Read the compressed transactions.
-	if batchType == BatchTypeBrotli {
+	switch batchType {
+	case BatchTypeBrotli:
 		br := brotli.NewReader(r)
 		var decodedOutput bytes.Buffer
 		_, _ = io.Copy(&decodedOutput, br)
 		r = bytes.NewReader(decodedOutput.Bytes())
+
+	case BatchTypeZstd:
+		zr, err := zstdDecompress(r)
+		if err != nil {
+			return err
+		}
+		r = zr
+
+	// Columnar batches encode the number of txs, so they are deserialized
+	// separately.
+	case BatchTypeZstdColumnar:
+		zr, err := zstdDecompress(r)
+		if err != nil {
+			return err
+		}
+		txs, err := readColumnarTxs(zr)
+		if err != nil {
+			return err
+		}
+		p.Txs = append(p.Txs, txs...)
+		return p.checkWellFormed()
 	}
 
 	// Deserialize any transactions. Since the number of txs is ommitted
@@ -250,13 +364,7 @@ func (p *AppendSequencerBatchParams) Read(r io.Reader) error {
 		// encoded object. Silence the error and return success if
 		// the batch is well formed.
 		if err == io.EOF {
-			if len(p.Contexts) == 0 && len(p.Txs) != 0 {
-				return ErrMalformedBatch
-			}
-			if len(p.Txs) == 0 && len(p.Contexts) != 0 {
-				return ErrMalformedBatch
-			}
-			return nil
+			return p.checkWellFormed()
 		} else if err != nil {
 			return err
 		}
@@ -268,6 +376,18 @@ func (p *AppendSequencerBatchParams) Read(r io.Reader) error {
 	}
 }
 
+// checkWellFormed returns ErrMalformedBatch if the batch has transactions but
+// no contexts, or contexts but no transactions.
+func (p *AppendSequencerBatchParams) checkWellFormed() error {
+	if len(p.Contexts) == 0 && len(p.Txs) != 0 {
+		return ErrMalformedBatch
+	}
+	if len(p.Txs) == 0 && len(p.Contexts) != 0 {
+		return ErrMalformedBatch
+	}
+	return nil
+}
+
 // writeUint64 writes a the bottom `n` bytes of `val` to `w`.
 func writeUint64(w io.Writer, val uint64, n uint) error {
 	if n < 1 || n > 8 {
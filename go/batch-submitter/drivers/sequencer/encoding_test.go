@@ -5,11 +5,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/go/batch-submitter/drivers/sequencer"
 	l2types "github.com/ethereum-optimism/optimism/l2geth/core/types"
 	l2rlp "github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,6 +72,14 @@ type AppendSequencerBatchParamsTest struct {
 
 var appendSequencerBatchParamTests = AppendSequencerBatchParamsTestCases{}
 
+// compressedBatchTypes lists every batch type that is signaled using a marker
+// context.
+var compressedBatchTypes = []sequencer.BatchType{
+	sequencer.BatchTypeBrotli,
+	sequencer.BatchTypeZstd,
+	sequencer.BatchTypeZstdColumnar,
+}
+
 func init() {
 	data, err := os.ReadFile("./testdata/valid_append_sequencer_batch_params.json")
 	if err != nil {
@@ -119,6 +129,7 @@ func testAppendSequencerBatchParamsEncodeDecode(
 		TotalElementsToAppend: test.TotalElementsToAppend,
 		Contexts:              test.Contexts,
 		Txs:                   nil,
+		Typ:                   sequencer.BatchTypeLegacy,
 	}
 
 	// Decode the batch from the test string.
@@ -146,25 +157,36 @@ func testAppendSequencerBatchParamsEncodeDecode(
 	compareTxs(t, expTxs, decodedTxs)
 	params.Txs = decodedTxs
 
-	// Serialize the batches in compressed form
-	compressedParamsBytes, err := params.Serialize()
+	// Serialize the batch using the legacy encoding, which should reproduce
+	// the original test vector.
+	paramsBytes, err := params.Serialize()
 	if test.Error {
 		require.ErrorIs(t, err, sequencer.ErrMalformedBatch)
 		return
 	}
 	require.Nil(t, err)
+	require.Equal(t, test.HexEncoding, hex.EncodeToString(paramsBytes))
 
-	// Deserialize the compressed batch
-	var paramsCompressed sequencer.AppendSequencerBatchParams
-	err = paramsCompressed.Read(bytes.NewReader(compressedParamsBytes))
-	require.Nil(t, err)
+	// Serialize the batches in each compressed form and assert that they
+	// decode to the original params.
+	for _, batchType := range compressedBatchTypes {
+		params.Typ = batchType
 
-	decompressedTxs := paramsCompressed.Txs
-	paramsCompressed.Txs = nil
+		compressedParamsBytes, err := params.Serialize()
+		require.Nil(t, err)
 
-	require.Equal(t, expParams, paramsCompressed)
-	compareTxs(t, expTxs, decompressedTxs)
-	paramsCompressed.Txs = decompressedTxs
+		// Deserialize the compressed batch
+		var paramsCompressed sequencer.AppendSequencerBatchParams
+		err = paramsCompressed.Read(bytes.NewReader(compressedParamsBytes))
+		require.Nil(t, err)
+
+		decompressedTxs := paramsCompressed.Txs
+		paramsCompressed.Txs = nil
+
+		expParams.Typ = batchType
+		require.Equal(t, expParams, paramsCompressed)
+		compareTxs(t, expTxs, decompressedTxs)
+	}
 }
 
 // compareTxs compares a list of two transactions, testing each pair by tx hash.
@@ -176,3 +198,203 @@ func compareTxs(t *testing.T, a []*l2types.Transaction, b []*sequencer.CachedTx)
 		require.Equal(t, txA.Hash(), b[i].Tx().Hash())
 	}
 }
+
+// TestAppendSequencerBatchParamsZstdDictionary asserts that zstd batches
+// round trip when compressed with a pre-trained dictionary, and that they
+// cannot be decoded without it.
+//
+// NOTE: This test is not run in parallel, since the dictionary is installed
+// globally.
+func TestAppendSequencerBatchParamsZstdDictionary(t *testing.T) {
+	dict, err := os.ReadFile("./testdata/zstd_batch.dict")
+	require.Nil(t, err)
+
+	err = sequencer.SetZstdDictionary([]byte("not a dictionary"))
+	require.ErrorIs(t, err, sequencer.ErrInvalidZstdDictionary)
+
+	test := appendSequencerBatchParamTests.Tests[len(appendSequencerBatchParamTests.Tests)-1]
+	rawBytes, err := hex.DecodeString(test.HexEncoding)
+	require.Nil(t, err)
+
+	for _, batchType := range []sequencer.BatchType{
+		sequencer.BatchTypeZstd,
+		sequencer.BatchTypeZstdColumnar,
+	} {
+		var params sequencer.AppendSequencerBatchParams
+		require.Nil(t, params.Read(bytes.NewReader(rawBytes)))
+		params.Typ = batchType
+
+		require.Nil(t, sequencer.SetZstdDictionary(dict))
+		compressedParamsBytes, err := params.Serialize()
+		require.Nil(t, err)
+
+		var decoded sequencer.AppendSequencerBatchParams
+		err = decoded.Read(bytes.NewReader(compressedParamsBytes))
+		require.Nil(t, err)
+		require.Equal(t, params.Contexts, decoded.Contexts)
+		require.Equal(t, len(params.Txs), len(decoded.Txs))
+
+		require.Nil(t, sequencer.SetZstdDictionary(nil))
+		err = decoded.Read(bytes.NewReader(compressedParamsBytes))
+		require.NotNil(t, err)
+	}
+}
+
+// TestAppendSequencerBatchParamsUnknownBatchType asserts that batches cannot
+// be encoded with, or decoded from a marker context signaling, an unknown
+// batch type.
+func TestAppendSequencerBatchParamsUnknownBatchType(t *testing.T) {
+	t.Parallel()
+
+	params := sequencer.AppendSequencerBatchParams{
+		Typ: sequencer.BatchType(42),
+	}
+	_, err := params.Serialize()
+	require.ErrorIs(t, err, sequencer.ErrUnknownBatchType)
+
+	// Marker context with a block number of 42.
+	rawBytes, err := hex.DecodeString("0000000001000000000001" +
+		"0000000000000000000000000000002a")
+	require.Nil(t, err)
+	err = params.Read(bytes.NewReader(rawBytes))
+	require.ErrorIs(t, err, sequencer.ErrUnknownBatchType)
+}
+
+// TestParseBatchType asserts that each batch type can be parsed from its
+// string representation.
+func TestParseBatchType(t *testing.T) {
+	t.Parallel()
+
+	for _, batchType := range append(compressedBatchTypes, sequencer.BatchTypeLegacy) {
+		parsed, err := sequencer.ParseBatchType(batchType.String())
+		require.Nil(t, err)
+		require.Equal(t, batchType, parsed)
+	}
+
+	_, err := sequencer.ParseBatchType("gzip")
+	require.ErrorIs(t, err, sequencer.ErrUnknownBatchType)
+}
+
+// BenchmarkAppendSequencerBatchParams compares the encoded size and the
+// encoding and decoding CPU time of each batch type over the recorded mainnet
+// batches in the test vectors. The zstd types are additionally measured using
+// testdata/zstd_batch.dict, which was trained over the same batches with
+// `zstd --train --maxdict=16384`.
+func BenchmarkAppendSequencerBatchParams(b *testing.B) {
+	var mainnetBatches []sequencer.AppendSequencerBatchParams
+	for _, test := range appendSequencerBatchParamTests.Tests {
+		if !strings.HasPrefix(test.Name, "0x") {
+			continue
+		}
+		rawBytes, err := hex.DecodeString(test.HexEncoding)
+		require.Nil(b, err)
+
+		var params sequencer.AppendSequencerBatchParams
+		require.Nil(b, params.Read(bytes.NewReader(rawBytes)))
+		mainnetBatches = append(mainnetBatches, params)
+	}
+
+	dict, err := os.ReadFile("./testdata/zstd_batch.dict")
+	require.Nil(b, err)
+
+	benchmarks := []struct {
+		name      string
+		batchType sequencer.BatchType
+		dict      []byte
+	}{
+		{"legacy", sequencer.BatchTypeLegacy, nil},
+		{"brotli", sequencer.BatchTypeBrotli, nil},
+		{"zstd", sequencer.BatchTypeZstd, nil},
+		{"zstd-dict", sequencer.BatchTypeZstd, dict},
+		{"zstd-columnar", sequencer.BatchTypeZstdColumnar, nil},
+		{"zstd-columnar-dict", sequencer.BatchTypeZstdColumnar, dict},
+	}
+
+	for _, bm := range benchmarks {
+		require.Nil(b, sequencer.SetZstdDictionary(bm.dict))
+
+		encoded := make([][]byte, len(mainnetBatches))
+		var totalSize int
+		for i := range mainnetBatches {
+			mainnetBatches[i].Typ = bm.batchType
+			encoded[i], err = mainnetBatches[i].Serialize()
+			require.Nil(b, err)
+			totalSize += len(encoded[i])
+		}
+		avgSize := float64(totalSize) / float64(len(mainnetBatches))
+
+		b.Run(bm.name+"/encode", func(b *testing.B) {
+			b.ReportMetric(avgSize, "bytes/batch")
+			for n := 0; n < b.N; n++ {
+				for i := range mainnetBatches {
+					if _, err := mainnetBatches[i].Serialize(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(bm.name+"/decode", func(b *testing.B) {
+			b.ReportMetric(avgSize, "bytes/batch")
+			for n := 0; n < b.N; n++ {
+				for i := range encoded {
+					var params sequencer.AppendSequencerBatchParams
+					err := params.Read(bytes.NewReader(encoded[i]))
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+
+	require.Nil(b, sequencer.SetZstdDictionary(nil))
+}
+
+// TestAppendSequencerBatchParamsMalformedColumnar asserts that columnar
+// batches whose tx count is inconsistent with the payload are rejected.
+func TestAppendSequencerBatchParamsMalformedColumnar(t *testing.T) {
+	t.Parallel()
+
+	// Batch header followed by a marker context for the zstd-columnar batch
+	// type and a single context with one sequenced tx.
+	header, err := hex.DecodeString("0000000001000000000002" +
+		"0000000000000000000000000000000002" +
+		"0000010000000000000001000000000001")
+	require.Nil(t, err)
+
+	zw, err := zstd.NewWriter(nil)
+	require.Nil(t, err)
+	defer zw.Close()
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{"empty", ""},
+		{"oversized tx count", "ffffff"},
+		{"truncated columns", "000002" + "01"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := hex.DecodeString(test.payload)
+			require.Nil(t, err)
+
+			rawBytes := append(append([]byte{}, header...), zw.EncodeAll(payload, nil)...)
+			var params sequencer.AppendSequencerBatchParams
+			err = params.Read(bytes.NewReader(rawBytes))
+			require.ErrorIs(t, err, sequencer.ErrMalformedBatch)
+		})
+	}
+
+	// A payload that decompresses beyond the limit is rejected.
+	t.Run("oversized payload", func(t *testing.T) {
+		payload := make([]byte, sequencer.MaxDecompressedBatchSize+1)
+		payload[2] = 1
+
+		rawBytes := append(append([]byte{}, header...), zw.EncodeAll(payload, nil)...)
+		var params sequencer.AppendSequencerBatchParams
+		err := params.Read(bytes.NewReader(rawBytes))
+		require.ErrorIs(t, err, sequencer.ErrMalformedBatch)
+	})
+}
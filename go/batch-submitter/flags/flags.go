@@ -160,6 +160,40 @@ var (
 		Value:  0,
 		EnvVar: prefixEnvVar("MAX_L1_GAS_PRICE"),
 	}
+	MaxL2BlockLagFlag = cli.Uint64Flag{
+		Name: "max-l2-block-lag",
+		Usage: "Number of unsubmitted L2 blocks at which a batch is " +
+			"submitted regardless of L1 gas prices. Disabled if zero",
+		Value:  1000,
+		EnvVar: prefixEnvVar("MAX_L2_BLOCK_LAG"),
+	}
+	MaxSubmissionDelayFlag = cli.DurationFlag{
+		Name: "max-submission-delay",
+		Usage: "Time since the last submission after which a batch is " +
+			"submitted regardless of L1 gas prices. Disabled if zero",
+		Value:  time.Hour,
+		EnvVar: prefixEnvVar("MAX_SUBMISSION_DELAY"),
+	}
+	BaseFeePercentileFlag = cli.Float64Flag{
+		Name: "base-fee-percentile",
+		Usage: "Percentile of the recent L1 base fee history at or below " +
+			"which batches that are not full are submitted. Disabled if zero",
+		Value:  0,
+		EnvVar: prefixEnvVar("BASE_FEE_PERCENTILE"),
+	}
+	BaseFeeHistorySizeFlag = cli.Uint64Flag{
+		Name:   "base-fee-history-size",
+		Usage:  "Number of recent L1 blocks used to compute the base fee percentile",
+		Value:  300,
+		EnvVar: prefixEnvVar("BASE_FEE_HISTORY_SIZE"),
+	}
+	FullBatchRatioFlag = cli.Float64Flag{
+		Name: "full-batch-ratio",
+		Usage: "Fraction of max-l1-tx-size at which a batch no longer " +
+			"waits for a base fee below base-fee-percentile",
+		Value:  0.9,
+		EnvVar: prefixEnvVar("FULL_BATCH_RATIO"),
+	}
 
 	LogLevelFlag = cli.StringFlag{
 		Name:   "log-level",
@@ -218,6 +252,61 @@ var (
 		Usage:  "Whether or not to disable HTTP/2 support.",
 		EnvVar: prefixEnvVar("HTTP2_DISABLE"),
 	}
+	SequencerBatchTypeFlag = cli.StringFlag{
+		Name: "sequencer-batch-type",
+		Usage: "The encoding used for sequencer batches: legacy, brotli, " +
+			"zstd or zstd-columnar. Batches are only decodable by data " +
+			"transport layers that support the chosen type",
+		Value:  "brotli",
+		EnvVar: prefixEnvVar("SEQUENCER_BATCH_TYPE"),
+	}
+	SequencerZstdDictionaryFlag = cli.StringFlag{
+		Name: "sequencer-zstd-dictionary",
+		Usage: "Path to a pre-trained zstd dictionary used to compress " +
+			"zstd and zstd-columnar sequencer batches",
+		EnvVar: prefixEnvVar("SEQUENCER_ZSTD_DICTIONARY"),
+	}
+	ProposerVerifierRpcsFlag = cli.StringSliceFlag{
+		Name: "proposer-verifier-rpcs",
+		Usage: "HTTP provider URLs of independent L2 verifiers, all of which " +
+			"must agree with l2-eth-rpc on each state root before it is proposed",
+		EnvVar: prefixEnvVar("PROPOSER_VERIFIER_RPCS"),
+	}
+	SequencerFetchConcurrencyFlag = cli.IntFlag{
+		Name:   "sequencer-fetch-concurrency",
+		Usage:  "Maximum number of L2 blocks fetched concurrently when crafting sequencer batches",
+		Value:  8,
+		EnvVar: prefixEnvVar("SEQUENCER_FETCH_CONCURRENCY"),
+	}
+	SequencerBlockCacheSizeFlag = cli.IntFlag{
+		Name:   "sequencer-block-cache-size",
+		Usage:  "Maximum number of unsubmitted L2 blocks cached across polls",
+		Value:  10000,
+		EnvVar: prefixEnvVar("SEQUENCER_BLOCK_CACHE_SIZE"),
+	}
+	ControlServerEnableFlag = cli.BoolFlag{
+		Name: "control-server-enable",
+		Usage: "Whether or not to run the control server used to pause, " +
+			"resume, force and inspect submissions",
+		EnvVar: prefixEnvVar("CONTROL_SERVER_ENABLE"),
+	}
+	ControlServerHostnameFlag = cli.StringFlag{
+		Name:   "control-server-hostname",
+		Usage:  "The hostname of the control server",
+		Value:  "127.0.0.1",
+		EnvVar: prefixEnvVar("CONTROL_SERVER_HOSTNAME"),
+	}
+	ControlServerPortFlag = cli.Uint64Flag{
+		Name:   "control-server-port",
+		Usage:  "The port of the control server",
+		Value:  7301,
+		EnvVar: prefixEnvVar("CONTROL_SERVER_PORT"),
+	}
+	ControlServerTokenFlag = cli.StringFlag{
+		Name:   "control-server-token",
+		Usage:  "The bearer token required to authenticate control server requests",
+		EnvVar: prefixEnvVar("CONTROL_SERVER_TOKEN"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -246,6 +335,11 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	MaxL1GasPriceFlag,
+	MaxL2BlockLagFlag,
+	MaxSubmissionDelayFlag,
+	BaseFeePercentileFlag,
+	BaseFeeHistorySizeFlag,
+	FullBatchRatioFlag,
 	LogLevelFlag,
 	LogTerminalFlag,
 	SentryEnableFlag,
@@ -256,6 +350,15 @@ var optionalFlags = []cli.Flag{
 	MetricsHostnameFlag,
 	MetricsPortFlag,
 	HTTP2DisableFlag,
+	SequencerBatchTypeFlag,
+	SequencerZstdDictionaryFlag,
+	SequencerFetchConcurrencyFlag,
+	SequencerBlockCacheSizeFlag,
+	ProposerVerifierRpcsFlag,
+	ControlServerEnableFlag,
+	ControlServerHostnameFlag,
+	ControlServerPortFlag,
+	ControlServerTokenFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -4,16 +4,19 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/go/bss-core/drivers"
+	"github.com/ethereum-optimism/optimism/go/bss-core/metrics"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 var (
-	errGasPriceTooHigh   = errors.New("Gas price is higher than gas price")
-	errBatchSizeTooSmall = errors.New("Batch size too small or max submission timeout not reached")
+	errGasPriceTooHigh     = errors.New("Gas price is higher than gas price")
+	errBatchSizeTooSmall   = errors.New("Batch size too small or max submission timeout not reached")
+	errAwaitingCheapWindow = errors.New("L1 base fee is above the cheap window threshold")
 )
 
 // Config houses parameters for altering the behavior of a BobaService.
@@ -25,82 +28,170 @@ type Config struct {
 	MinTxSize              uint64
 	MaxBatchSubmissionTime time.Duration
 	MaxL1GasPrice          uint64
+
+	// MaxTxSize is the maximum size in bytes of a batch transaction's call
+	// data, against which the fullness of a batch is measured.
+	MaxTxSize uint64
+
+	// MaxL2BlockLag is the number of unsubmitted L2 blocks at which a batch is
+	// submitted regardless of L1 gas prices. Disabled if zero.
+	MaxL2BlockLag uint64
+
+	// MaxSubmissionDelay is the time since the last submission after which a
+	// batch is submitted regardless of L1 gas prices. Disabled if zero.
+	MaxSubmissionDelay time.Duration
+
+	// BaseFeePercentile is the percentile of the recent L1 base fee history
+	// below which the L1 is considered cheap. Batches that are not full are
+	// only submitted during a cheap window. Disabled if zero.
+	BaseFeePercentile float64
+
+	// BaseFeeHistorySize is the number of recent L1 blocks whose base fee is
+	// used to compute the cheap window threshold.
+	BaseFeeHistorySize uint64
+
+	// FullBatchRatio is the fraction of MaxTxSize at which a batch is
+	// considered full, and no longer waits for a cheap window.
+	FullBatchRatio float64
+
+	// Metrics is the optional telemetry object to which the decisions of the
+	// service are reported.
+	Metrics *metrics.Metrics
+}
+
+// BatchInfo describes a candidate batch evaluated by VerifyCondition.
+type BatchInfo struct {
+	// Size is the size in bytes of the batch's elements, which is compared
+	// against MinTxSize.
+	Size uint64
+
+	// CallDataSize is the size in bytes of the batch transaction's call
+	// data, which is compared against MaxTxSize.
+	CallDataSize uint64
+
+	// NumPendingBlocks is the number of L2 blocks that have not yet been
+	// submitted.
+	NumPendingBlocks uint64
 }
 
 // BobaServiceManager is an interface that allows callers to save gas if
 // the batch doesn't meet multiple conditions
 type BobaServiceManager interface {
-	// VerifyCondition verifies the batch size and l1 gas price.
+	// VerifyCondition decides whether the batch should be submitted now.
+	// The batch is always submitted once MaxL2BlockLag or MaxSubmissionDelay
+	// is reached. Otherwise:
 	// If l1 gas price is larger than MaxL1GasPrice, it returns the errGasPriceTooHigh
 	// as the error message
 	// If batch size is under MinTxSize and waiting time is below MaxBatchSubmissionTime,
 	// it return errBatchSizeTooSmall as the error message
-	VerifyCondition(batchSize uint64) error
+	// If the batch is not full and the L1 base fee is above the cheap window
+	// threshold, it returns errAwaitingCheapWindow as the error message
+	VerifyCondition(batch BatchInfo) error
 	// Set the last bacth submission time outside boba service
 	SetLastBatchSubmissionTime()
 	// Get the last batch submission time
 	GetLastBatchSubmissionTime() time.Time
+	// SetMaxL1GasPrice overrides the MaxL1GasPrice, in gwei, at runtime
+	SetMaxL1GasPrice(maxL1GasPrice uint64)
+	// GetMaxL1GasPrice returns the current MaxL1GasPrice in gwei
+	GetMaxL1GasPrice() uint64
 }
 
 type BobaService struct {
+	// mu guards cfg, which may be modified at runtime through
+	// SetMaxL1GasPrice.
+	mu                      sync.RWMutex
 	cfg                     Config
 	lastBatchSubmissionTime time.Time
+	baseFees                *baseFeeHistory
 }
 
 func NewBobaService(
 	name string, context context.Context, MinTxSize uint64,
 	MaxL1GasPrice uint64, MaxBatchSubmissionTime time.Duration,
 	L1Client drivers.L1Client) *BobaService {
+	return NewBobaServiceWithConfig(Config{
+		Name:                   name,
+		Context:                context,
+		MinTxSize:              MinTxSize,
+		MaxL1GasPrice:          MaxL1GasPrice,
+		MaxBatchSubmissionTime: MaxBatchSubmissionTime,
+		L1Client:               L1Client,
+	})
+}
+
+// NewBobaServiceWithConfig creates a BobaService from the full Config,
+// enabling the gas-aware scheduling parameters.
+func NewBobaServiceWithConfig(cfg Config) *BobaService {
 	return &BobaService{
-		cfg: Config{
-			Name:                   name,
-			Context:                context,
-			MinTxSize:              MinTxSize,
-			MaxL1GasPrice:          MaxL1GasPrice,
-			MaxBatchSubmissionTime: MaxBatchSubmissionTime,
-			L1Client:               L1Client,
-		},
+		cfg:                     cfg,
 		lastBatchSubmissionTime: time.Now(),
+		baseFees:                newBaseFeeHistory(cfg.BaseFeeHistorySize),
 	}
 }
 
-func (s *BobaService) VerifyCondition(batchSize uint64) error {
+func (s *BobaService) VerifyCondition(batch BatchInfo) error {
+	s.mu.RLock()
+	cfg := s.cfg
+	s.mu.RUnlock()
+
+	state := SchedulerState{
+		Batch:                   batch,
+		TimeSinceLastSubmission: time.Since(s.lastBatchSubmissionTime),
+	}
+
 	L1GasPrice, err := s.cfg.L1Client.SuggestGasPrice(s.cfg.Context)
 	if err == nil {
-		// Check L1 Gas Price
-		MaxL1GasPriceWei := new(big.Int).SetUint64(s.cfg.MaxL1GasPrice)
-		MaxL1GasPrice := new(big.Int).Mul(MaxL1GasPriceWei, big.NewInt(params.GWei))
-		if MaxL1GasPrice.Cmp(big.NewInt(0)) > 0 && MaxL1GasPrice.Cmp(L1GasPrice) < 0 {
-			log.Info(
-				s.cfg.Name+" gas price is higher than gas price threshold; aborting batch submission",
-				"MaxL1GasPrice", MaxL1GasPrice,
-				"L1GasPrice", L1GasPrice,
-			)
-			return errGasPriceTooHigh
-		}
+		state.L1GasPrice = L1GasPrice
 	} else {
 		log.Info(s.cfg.Name + " can't get L1 gas Price; skipping the gas price check")
 	}
-	// Check the batch size
-	// Submit the tx batch if batchSize > MinTxSize or timeDuration > MaxBatchSubmissionTime
-	timeSinceLastSubmission := time.Since(s.lastBatchSubmissionTime)
-	if batchSize < s.cfg.MinTxSize && timeSinceLastSubmission < s.cfg.MaxBatchSubmissionTime {
-		log.Info(s.cfg.Name+" skip bacth submission. Batch too small or max submission timeout not reached.",
-			"batchSize", batchSize,
-			"MinTxSize", s.cfg.MinTxSize,
-			"timeSinceLastSubmission", timeSinceLastSubmission,
-			"MaxBatchSubmissionTime", s.cfg.MaxBatchSubmissionTime,
-		)
-		return errBatchSizeTooSmall
-	} else {
-		log.Info(s.cfg.Name+" proceeding with bacth submission.",
-			"batchSize", batchSize,
+
+	// Sample the L1 base fee history to determine the cheap window threshold.
+	if s.cfg.BaseFeePercentile > 0 {
+		baseFee, err := s.baseFees.update(s.cfg.Context, s.cfg.L1Client)
+		if err == nil {
+			state.L1BaseFee = baseFee
+			state.L1BaseFeeThreshold = s.baseFees.percentile(s.cfg.BaseFeePercentile)
+		} else {
+			log.Info(s.cfg.Name+" can't get L1 base fee; skipping the cheap window check",
+				"err", err)
+		}
+	}
+
+	decision := Decide(&cfg, &state)
+	s.recordDecision(&state, decision)
+
+	if !decision.Submit {
+		log.Info(s.cfg.Name+" skip bacth submission.",
+			"reason", decision.Reason,
+			"batchSize", batch.Size,
+			"callDataSize", batch.CallDataSize,
+			"numPendingBlocks", batch.NumPendingBlocks,
 			"MinTxSize", s.cfg.MinTxSize,
-			"timeSinceLastSubmission", timeSinceLastSubmission,
+			"timeSinceLastSubmission", state.TimeSinceLastSubmission,
 			"MaxBatchSubmissionTime", s.cfg.MaxBatchSubmissionTime,
+			"MaxL1GasPrice", cfg.MaxL1GasPrice,
+			"L1GasPrice", state.L1GasPrice,
+			"L1BaseFee", state.L1BaseFee,
+			"L1BaseFeeThreshold", state.L1BaseFeeThreshold,
 		)
+		return decision.err()
 	}
 
+	log.Info(s.cfg.Name+" proceeding with bacth submission.",
+		"reason", decision.Reason,
+		"batchSize", batch.Size,
+		"callDataSize", batch.CallDataSize,
+		"numPendingBlocks", batch.NumPendingBlocks,
+		"MinTxSize", s.cfg.MinTxSize,
+		"timeSinceLastSubmission", state.TimeSinceLastSubmission,
+		"MaxBatchSubmissionTime", s.cfg.MaxBatchSubmissionTime,
+		"L1GasPrice", state.L1GasPrice,
+		"L1BaseFee", state.L1BaseFee,
+		"L1BaseFeeThreshold", state.L1BaseFeeThreshold,
+	)
+
 	return nil
 }
 
@@ -111,3 +202,51 @@ func (s *BobaService) SetLastBatchSubmissionTime() {
 func (s *BobaService) GetLastBatchSubmissionTime() time.Time {
 	return s.lastBatchSubmissionTime
 }
+
+// SetMaxL1GasPrice overrides the MaxL1GasPrice, in gwei, used by subsequent
+// calls to VerifyCondition. A value of zero disables the gas price check.
+func (s *BobaService) SetMaxL1GasPrice(maxL1GasPrice uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg.MaxL1GasPrice = maxL1GasPrice
+}
+
+// GetMaxL1GasPrice returns the MaxL1GasPrice, in gwei, currently in effect.
+func (s *BobaService) GetMaxL1GasPrice() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg.MaxL1GasPrice
+}
+
+// recordDecision reports the decision, its reason and the L1 fees on which it
+// was based to the configured metrics, if any.
+func (s *BobaService) recordDecision(state *SchedulerState, decision Decision) {
+	m := s.cfg.Metrics
+	if m == nil {
+		return
+	}
+
+	if decision.Submit {
+		m.SchedulerDecision.Set(1)
+	} else {
+		m.SchedulerDecision.Set(0)
+	}
+	m.SchedulerReason.Reset()
+	m.SchedulerReason.WithLabelValues(decision.Reason).Set(1)
+	m.SchedulerDecisions.WithLabelValues(decision.Reason).Inc()
+
+	if state.L1BaseFee != nil {
+		m.L1BaseFee.Set(weiToGwei64(state.L1BaseFee))
+	}
+	if state.L1BaseFeeThreshold != nil {
+		m.L1BaseFeeThreshold.Set(weiToGwei64(state.L1BaseFeeThreshold))
+	}
+}
+
+// weiToGwei64 converts an amount in wei to a float64 amount in gwei.
+func weiToGwei64(wei *big.Int) float64 {
+	gwei := new(big.Float).SetInt(wei)
+	gwei.Quo(gwei, new(big.Float).SetInt64(params.GWei))
+	gwei64, _ := gwei.Float64()
+	return gwei64
+}
@@ -41,20 +41,38 @@ func TestVerifyCondition(t *testing.T) {
 		context.Background(), 20, 2, time.Duration(5)*time.Millisecond, L1Client)
 
 	s.bobaService.SetLastBatchSubmissionTime()
-	err := s.bobaService.VerifyCondition(10)
+	err := s.bobaService.VerifyCondition(boba.BatchInfo{Size: 10})
 	require.Equal(t, err, errBatchSizeTooSmall)
 
 	// Wait for a while
 	time.Sleep(time.Duration(5) * time.Millisecond)
-	err = s.bobaService.VerifyCondition(10)
+	err = s.bobaService.VerifyCondition(boba.BatchInfo{Size: 10})
 	require.Equal(t, err, nil)
 
 	// Increase L1 gas price to 10 GWei
 	testGasPrice = big.NewInt(10000000000)
-	err = s.bobaService.VerifyCondition(10)
+	err = s.bobaService.VerifyCondition(boba.BatchInfo{Size: 10})
 	require.Equal(t, err, errGasPriceTooHigh)
 }
 
+func TestSetMaxL1GasPrice(t *testing.T) {
+	L1Client := MockL1Client()
+	// MinTxSize = 20, MaxL1GasPrice = 2 GWEI
+	s := NewService(
+		context.Background(), 20, 2, time.Duration(0), L1Client)
+	require.Equal(t, uint64(2), s.bobaService.GetMaxL1GasPrice())
+
+	testGasPrice = big.NewInt(10000000000)
+	err := s.bobaService.VerifyCondition(boba.BatchInfo{Size: 10})
+	require.Equal(t, err, errGasPriceTooHigh)
+
+	// Raise the maximum L1 gas price above the current L1 gas price.
+	s.bobaService.SetMaxL1GasPrice(20)
+	require.Equal(t, uint64(20), s.bobaService.GetMaxL1GasPrice())
+	err = s.bobaService.VerifyCondition(boba.BatchInfo{Size: 10})
+	require.Equal(t, err, nil)
+}
+
 func TestSetLastBatchSubmissionTime(t *testing.T) {
 	L1Client := MockL1Client()
 	s := NewService(
@@ -0,0 +1,293 @@
+package boba
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/bss-core/drivers"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The reasons reported with each scheduling Decision.
+const (
+	ReasonMaxL2BlockLag       = "max_l2_block_lag"
+	ReasonMaxSubmissionDelay  = "max_submission_delay"
+	ReasonGasPriceTooHigh     = "gas_price_too_high"
+	ReasonBatchTooSmall       = "batch_too_small"
+	ReasonAwaitingCheapWindow = "awaiting_cheap_window"
+	ReasonBatchFull           = "batch_full"
+	ReasonCheapWindow         = "cheap_window"
+	ReasonBatchReady          = "batch_ready"
+)
+
+// SchedulerState is the information on which a scheduling Decision is based.
+type SchedulerState struct {
+	// Batch describes the candidate batch.
+	Batch BatchInfo
+
+	// TimeSinceLastSubmission is the time elapsed since the last batch was
+	// submitted.
+	TimeSinceLastSubmission time.Duration
+
+	// L1GasPrice is the suggested L1 gas price, or nil if unavailable.
+	L1GasPrice *big.Int
+
+	// L1BaseFee is the base fee of the latest L1 block, or nil if
+	// unavailable.
+	L1BaseFee *big.Int
+
+	// L1BaseFeeThreshold is the base fee at or below which the L1 is
+	// considered cheap, or nil if unavailable.
+	L1BaseFeeThreshold *big.Int
+}
+
+// Decision is the outcome of evaluating whether a batch should be submitted.
+type Decision struct {
+	// Submit is true if the batch should be submitted now.
+	Submit bool
+
+	// Reason is a short, metric-friendly identifier explaining the decision.
+	Reason string
+}
+
+// err returns the error reported by VerifyCondition for the decision.
+func (d Decision) err() error {
+	switch {
+	case d.Submit:
+		return nil
+	case d.Reason == ReasonGasPriceTooHigh:
+		return errGasPriceTooHigh
+	case d.Reason == ReasonAwaitingCheapWindow:
+		return errAwaitingCheapWindow
+	default:
+		return errBatchSizeTooSmall
+	}
+}
+
+// Decide determines whether a batch should be submitted given the current
+// state. The rules are evaluated in the following order:
+//  1. Submit if the number of unsubmitted L2 blocks reached MaxL2BlockLag, or
+//     the time since the last submission reached MaxSubmissionDelay, so that a
+//     sustained gas spike can not stall submissions indefinitely.
+//  2. Wait if the L1 gas price is above MaxL1GasPrice.
+//  3. Wait if the batch is smaller than MinTxSize and MaxBatchSubmissionTime
+//     has not elapsed since the last submission.
+//  4. If a cheap window threshold is known and the L1 base fee is above it,
+//     submit only if the batch is full, otherwise wait for the window.
+//  5. Submit.
+func Decide(cfg *Config, state *SchedulerState) Decision {
+	batch := state.Batch
+	if cfg.MaxL2BlockLag > 0 && batch.NumPendingBlocks >= cfg.MaxL2BlockLag {
+		return Decision{Submit: true, Reason: ReasonMaxL2BlockLag}
+	}
+	if cfg.MaxSubmissionDelay > 0 &&
+		state.TimeSinceLastSubmission >= cfg.MaxSubmissionDelay {
+
+		return Decision{Submit: true, Reason: ReasonMaxSubmissionDelay}
+	}
+
+	if state.L1GasPrice != nil && cfg.MaxL1GasPrice > 0 {
+		maxL1GasPrice := new(big.Int).Mul(
+			new(big.Int).SetUint64(cfg.MaxL1GasPrice), big.NewInt(params.GWei),
+		)
+		if maxL1GasPrice.Cmp(state.L1GasPrice) < 0 {
+			return Decision{Submit: false, Reason: ReasonGasPriceTooHigh}
+		}
+	}
+
+	// Submit the tx batch if batchSize > MinTxSize or timeDuration > MaxBatchSubmissionTime
+	if batch.Size < cfg.MinTxSize &&
+		state.TimeSinceLastSubmission < cfg.MaxBatchSubmissionTime {
+
+		return Decision{Submit: false, Reason: ReasonBatchTooSmall}
+	}
+
+	if state.L1BaseFee == nil || state.L1BaseFeeThreshold == nil {
+		return Decision{Submit: true, Reason: ReasonBatchReady}
+	}
+	if state.L1BaseFee.Cmp(state.L1BaseFeeThreshold) <= 0 {
+		return Decision{Submit: true, Reason: ReasonCheapWindow}
+	}
+	if cfg.MaxTxSize > 0 && cfg.FullBatchRatio > 0 {
+		fullness := float64(batch.CallDataSize) / float64(cfg.MaxTxSize)
+		if fullness >= cfg.FullBatchRatio {
+			return Decision{Submit: true, Reason: ReasonBatchFull}
+		}
+	}
+	return Decision{Submit: false, Reason: ReasonAwaitingCheapWindow}
+}
+
+// maxFeeHistoryBlocks is the maximum number of blocks requested in a single
+// eth_feeHistory call, matching the limit enforced by geth.
+const maxFeeHistoryBlocks = 1024
+
+// errNoBaseFee signals that the fee history contains blocks without a base
+// fee, i.e. blocks that predate London.
+var errNoBaseFee = errors.New("fee history contains blocks without a base fee")
+
+// baseFeeHistory records the base fees of the most recent L1 blocks.
+type baseFeeHistory struct {
+	size        uint64
+	baseFees    map[uint64]*big.Int
+	latestBlock uint64
+}
+
+// newBaseFeeHistory creates a baseFeeHistory retaining the base fees of the
+// given number of blocks.
+func newBaseFeeHistory(size uint64) *baseFeeHistory {
+	if size == 0 {
+		size = 1
+	}
+	return &baseFeeHistory{
+		size:     size,
+		baseFees: make(map[uint64]*big.Int),
+	}
+}
+
+// update records the base fees of any blocks produced since the last update,
+// and returns the base fee of the latest block. Blocks that predate London are
+// recorded using their suggested gas price.
+func (h *baseFeeHistory) update(
+	ctx context.Context,
+	l1Client drivers.L1Client,
+) (*big.Int, error) {
+
+	latest, err := l1Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	latestBaseFee, err := headerBaseFee(ctx, l1Client, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	latestNumber := latest.Number.Uint64()
+	first := h.latestBlock + 1
+	if latestNumber+1 > h.size && first < latestNumber+1-h.size {
+		first = latestNumber + 1 - h.size
+	}
+	if err := h.fetch(ctx, l1Client, first, latestNumber); err != nil {
+		return nil, err
+	}
+	h.baseFees[latestNumber] = latestBaseFee
+	if latestNumber > h.latestBlock {
+		h.latestBlock = latestNumber
+	}
+
+	// Evict blocks that have fallen out of the history.
+	for number := range h.baseFees {
+		if number+h.size <= h.latestBlock {
+			delete(h.baseFees, number)
+		}
+	}
+
+	return latestBaseFee, nil
+}
+
+// fetch records the base fees of the blocks between first and end, where end is
+// *exclusive*. If the client supports it, the base fees are requested in
+// batches using eth_feeHistory, falling back to requesting each header.
+func (h *baseFeeHistory) fetch(
+	ctx context.Context,
+	l1Client drivers.L1Client,
+	first, end uint64,
+) error {
+
+	if client, ok := l1Client.(drivers.BaseFeeHistoryClient); ok {
+		err := h.fetchHistory(ctx, client, first, end)
+		if err == nil {
+			return nil
+		}
+		log.Debug("Unable to fetch L1 fee history, requesting headers",
+			"err", err)
+	}
+
+	for number := first; number < end; number++ {
+		header, err := l1Client.HeaderByNumber(
+			ctx, new(big.Int).SetUint64(number),
+		)
+		if err != nil {
+			return err
+		}
+		baseFee, err := headerBaseFee(ctx, l1Client, header)
+		if err != nil {
+			return err
+		}
+		h.baseFees[number] = baseFee
+	}
+	return nil
+}
+
+// fetchHistory records the base fees of the blocks between first and end,
+// where end is *exclusive*, using eth_feeHistory. Blocks that predate London
+// have no base fee, in which case an error is returned.
+func (h *baseFeeHistory) fetchHistory(
+	ctx context.Context,
+	client drivers.BaseFeeHistoryClient,
+	first, end uint64,
+) error {
+
+	for start := first; start < end; start += maxFeeHistoryBlocks {
+		count := end - start
+		if count > maxFeeHistoryBlocks {
+			count = maxFeeHistoryBlocks
+		}
+		baseFees, err := client.BaseFeeHistory(
+			ctx, count, new(big.Int).SetUint64(start+count-1),
+		)
+		if err != nil {
+			return err
+		}
+		for i, baseFee := range baseFees {
+			if baseFee.Sign() == 0 {
+				return errNoBaseFee
+			}
+			h.baseFees[start+uint64(i)] = baseFee
+		}
+	}
+	return nil
+}
+
+// percentile returns the base fee at the given percentile of the history.
+func (h *baseFeeHistory) percentile(p float64) *big.Int {
+	if len(h.baseFees) == 0 {
+		return nil
+	}
+
+	baseFees := make([]*big.Int, 0, len(h.baseFees))
+	for _, baseFee := range h.baseFees {
+		baseFees = append(baseFees, baseFee)
+	}
+	sort.Slice(baseFees, func(i, j int) bool {
+		return baseFees[i].Cmp(baseFees[j]) < 0
+	})
+
+	idx := int(math.Ceil(p/100*float64(len(baseFees)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(baseFees) {
+		idx = len(baseFees) - 1
+	}
+	return baseFees[idx]
+}
+
+// headerBaseFee returns the base fee of the header, falling back to the
+// suggested gas price for blocks that predate London.
+func headerBaseFee(
+	ctx context.Context,
+	l1Client drivers.L1Client,
+	header *types.Header,
+) (*big.Int, error) {
+
+	if header.BaseFee != nil {
+		return header.BaseFee, nil
+	}
+	return l1Client.SuggestGasPrice(ctx)
+}
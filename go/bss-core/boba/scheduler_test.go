@@ -0,0 +1,263 @@
+package boba_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/bss-core/boba"
+	"github.com/ethereum-optimism/optimism/go/bss-core/mock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var schedulerConfig = boba.Config{
+	MinTxSize:              100,
+	MaxBatchSubmissionTime: time.Minute,
+	MaxL1GasPrice:          100, // 100 GWEI
+	MaxTxSize:              1000,
+	MaxL2BlockLag:          500,
+	MaxSubmissionDelay:     time.Hour,
+	BaseFeePercentile:      25,
+	BaseFeeHistorySize:     100,
+	FullBatchRatio:         0.9,
+}
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1000000000))
+}
+
+var decideTests = []struct {
+	name      string
+	state     boba.SchedulerState
+	expSubmit bool
+	expReason string
+}{
+	{
+		name: "max l2 block lag overrides gas price",
+		state: boba.SchedulerState{
+			Batch:      boba.BatchInfo{Size: 10, NumPendingBlocks: 500},
+			L1GasPrice: gwei(1000),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonMaxL2BlockLag,
+	},
+	{
+		name: "max submission delay overrides gas price",
+		state: boba.SchedulerState{
+			Batch:                   boba.BatchInfo{Size: 10, NumPendingBlocks: 1},
+			TimeSinceLastSubmission: time.Hour,
+			L1GasPrice:              gwei(1000),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonMaxSubmissionDelay,
+	},
+	{
+		name: "gas price too high",
+		state: boba.SchedulerState{
+			Batch:      boba.BatchInfo{Size: 200, NumPendingBlocks: 1},
+			L1GasPrice: gwei(101),
+		},
+		expSubmit: false,
+		expReason: boba.ReasonGasPriceTooHigh,
+	},
+	{
+		name: "batch too small",
+		state: boba.SchedulerState{
+			Batch:      boba.BatchInfo{Size: 10, NumPendingBlocks: 1},
+			L1GasPrice: gwei(10),
+		},
+		expSubmit: false,
+		expReason: boba.ReasonBatchTooSmall,
+	},
+	{
+		name: "batch ready without base fee history",
+		state: boba.SchedulerState{
+			Batch:      boba.BatchInfo{Size: 200, NumPendingBlocks: 1},
+			L1GasPrice: gwei(10),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonBatchReady,
+	},
+	{
+		name: "small batch submitted after max batch submission time",
+		state: boba.SchedulerState{
+			Batch:                   boba.BatchInfo{Size: 10, NumPendingBlocks: 1},
+			TimeSinceLastSubmission: time.Minute,
+			L1GasPrice:              gwei(10),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonBatchReady,
+	},
+	{
+		name: "cheap window",
+		state: boba.SchedulerState{
+			Batch:              boba.BatchInfo{Size: 200, NumPendingBlocks: 1},
+			L1GasPrice:         gwei(10),
+			L1BaseFee:          gwei(9),
+			L1BaseFeeThreshold: gwei(9),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonCheapWindow,
+	},
+	{
+		name: "awaiting cheap window",
+		state: boba.SchedulerState{
+			Batch: boba.BatchInfo{
+				Size: 200, CallDataSize: 500, NumPendingBlocks: 1,
+			},
+			L1GasPrice:         gwei(10),
+			L1BaseFee:          gwei(10),
+			L1BaseFeeThreshold: gwei(9),
+		},
+		expSubmit: false,
+		expReason: boba.ReasonAwaitingCheapWindow,
+	},
+	{
+		name: "full batch does not await cheap window",
+		state: boba.SchedulerState{
+			Batch: boba.BatchInfo{
+				Size: 2000, CallDataSize: 900, NumPendingBlocks: 1,
+			},
+			L1GasPrice:         gwei(10),
+			L1BaseFee:          gwei(10),
+			L1BaseFeeThreshold: gwei(9),
+		},
+		expSubmit: true,
+		expReason: boba.ReasonBatchFull,
+	},
+}
+
+// TestDecide asserts the outcome of each scheduling rule.
+func TestDecide(t *testing.T) {
+	for _, test := range decideTests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := boba.Decide(&schedulerConfig, &test.state)
+			require.Equal(t, test.expSubmit, decision.Submit)
+			require.Equal(t, test.expReason, decision.Reason)
+		})
+	}
+}
+
+// TestVerifyConditionCheapWindow asserts that the base fee history is sampled
+// from the L1 client, and that batches wait for a base fee at or below the
+// configured percentile of that history.
+func TestVerifyConditionCheapWindow(t *testing.T) {
+	// Base fees of blocks 1-100 are 1-100 GWEI.
+	latestBlock := int64(100)
+	baseFeeOffset := int64(0)
+	var numHeaderRequests int
+	l1Client := mock.NewL1Client(mock.L1ClientConfig{
+		SuggestGasPrice: func(ctx context.Context) (*big.Int, error) {
+			return gwei(1), nil
+		},
+		HeaderByNumber: func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			numHeaderRequests++
+			if number == nil {
+				number = big.NewInt(latestBlock)
+			}
+			return &types.Header{
+				Number:  number,
+				BaseFee: gwei(number.Int64() + baseFeeOffset),
+			}, nil
+		},
+	})
+
+	cfg := schedulerConfig
+	cfg.Context = context.Background()
+	cfg.L1Client = l1Client
+	s := boba.NewBobaServiceWithConfig(cfg)
+
+	batch := boba.BatchInfo{Size: 200, CallDataSize: 500, NumPendingBlocks: 1}
+
+	// The latest base fee of 100 GWEI is above the 25th percentile.
+	err := s.VerifyCondition(batch)
+	require.EqualError(t, err, "L1 base fee is above the cheap window threshold")
+	require.Equal(t, 100, numHeaderRequests)
+
+	// A subsequent block with a cheap base fee only requires a single header
+	// request, and allows the batch to be submitted.
+	latestBlock = 101
+	baseFeeOffset = -90
+	numHeaderRequests = 0
+	err = s.VerifyCondition(batch)
+	require.Nil(t, err)
+	require.Equal(t, 1, numHeaderRequests)
+}
+
+// feeHistoryL1Client extends the mock L1 client with batched base fee history
+// requests.
+type feeHistoryL1Client struct {
+	*mock.L1Client
+
+	baseFeeHistory func(uint64, *big.Int) ([]*big.Int, error)
+}
+
+func (c *feeHistoryL1Client) BaseFeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+) ([]*big.Int, error) {
+
+	return c.baseFeeHistory(blockCount, lastBlock)
+}
+
+// TestVerifyConditionCheapWindowFeeHistory asserts that the base fee history
+// is requested in a single batch if the L1 client supports it, and that
+// headers are requested if the history is unavailable.
+func TestVerifyConditionCheapWindowFeeHistory(t *testing.T) {
+	// Base fees of blocks 1-100 are 1-100 GWEI.
+	var numHeaderRequests, numHistoryRequests int
+	historyErr := errors.New("fee history unavailable")
+	l1Client := &feeHistoryL1Client{
+		L1Client: mock.NewL1Client(mock.L1ClientConfig{
+			SuggestGasPrice: func(ctx context.Context) (*big.Int, error) {
+				return gwei(1), nil
+			},
+			HeaderByNumber: func(ctx context.Context, number *big.Int) (*types.Header, error) {
+				numHeaderRequests++
+				if number == nil {
+					number = big.NewInt(100)
+				}
+				return &types.Header{
+					Number:  number,
+					BaseFee: gwei(number.Int64()),
+				}, nil
+			},
+		}),
+		baseFeeHistory: func(blockCount uint64, lastBlock *big.Int) ([]*big.Int, error) {
+			numHistoryRequests++
+			require.Equal(t, uint64(99), blockCount)
+			require.Equal(t, int64(99), lastBlock.Int64())
+			baseFees := make([]*big.Int, blockCount)
+			for i := range baseFees {
+				baseFees[i] = gwei(int64(i) + 1)
+			}
+			return baseFees, nil
+		},
+	}
+
+	cfg := schedulerConfig
+	cfg.Context = context.Background()
+	cfg.L1Client = l1Client
+	s := boba.NewBobaServiceWithConfig(cfg)
+
+	batch := boba.BatchInfo{Size: 200, CallDataSize: 500, NumPendingBlocks: 1}
+
+	err := s.VerifyCondition(batch)
+	require.EqualError(t, err, "L1 base fee is above the cheap window threshold")
+	require.Equal(t, 1, numHeaderRequests)
+	require.Equal(t, 1, numHistoryRequests)
+
+	// Without a fee history, each header is requested instead.
+	l1Client.baseFeeHistory = func(uint64, *big.Int) ([]*big.Int, error) {
+		return nil, historyErr
+	}
+	numHeaderRequests = 0
+	s = boba.NewBobaServiceWithConfig(cfg)
+	err = s.VerifyCondition(batch)
+	require.EqualError(t, err, "L1 base fee is above the cheap window threshold")
+	require.Equal(t, 100, numHeaderRequests)
+}
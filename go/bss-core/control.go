@@ -0,0 +1,325 @@
+package bsscore
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// ErrServicePaused signals that a submission was forced while the
+	// service is paused.
+	ErrServicePaused = errors.New("service is paused")
+
+	// ErrSubmissionPending signals that a submission was forced while a
+	// previously forced submission has not yet started.
+	ErrSubmissionPending = errors.New("forced submission already pending")
+
+	// ErrUnknownService signals that a control request referenced a service
+	// that is not running.
+	ErrUnknownService = errors.New("unknown service")
+
+	// ErrUnknownAction signals that a control request referenced an action
+	// that is not supported.
+	ErrUnknownAction = errors.New("unknown action")
+)
+
+// controlReadTimeout bounds the time taken to read a control request.
+const controlReadTimeout = 10 * time.Second
+
+// ServiceStatus is a snapshot of the state of a Service, as reported by the
+// control server.
+type ServiceStatus struct {
+	// Name is the name of the service's driver.
+	Name string `json:"name"`
+
+	// Paused is true if submissions have been paused through the control
+	// server.
+	Paused bool `json:"paused"`
+
+	// LastStart and LastEnd are the bounds of the last L2 block range
+	// processed by the service, where LastEnd is *exclusive*.
+	LastStart *big.Int `json:"lastStart"`
+	LastEnd   *big.Int `json:"lastEnd"`
+
+	// PendingTxHash is the hash of the most recently published batch tx that
+	// has not yet confirmed, if any.
+	PendingTxHash *common.Hash `json:"pendingTxHash"`
+
+	// LastSubmittedTxHash is the hash of the most recently confirmed batch
+	// tx, if any.
+	LastSubmittedTxHash *common.Hash `json:"lastSubmittedTxHash"`
+
+	// Nonce is the submitter's nonce as of the last poll.
+	Nonce uint64 `json:"nonce"`
+
+	// Balance is the submitter's balance in wei as of the last poll.
+	Balance *big.Int `json:"balance"`
+
+	// SafeMinimumBalance is the balance in wei below which the submitter's
+	// balance is considered unsafe.
+	SafeMinimumBalance *big.Int `json:"safeMinimumBalance"`
+
+	// BelowSafeMinimumBalance is true if Balance is below
+	// SafeMinimumBalance.
+	BelowSafeMinimumBalance bool `json:"belowSafeMinimumBalance"`
+
+	// MaxL1GasPrice is the maximum L1 gas price in gwei currently in effect.
+	MaxL1GasPrice uint64 `json:"maxL1GasPrice"`
+}
+
+// Pause stops the service from crafting and submitting batches until Resume is
+// called. A batch tx that is already being published is not affected.
+func (s *Service) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.paused = true
+	s.metrics.SubmissionsPaused.Set(1)
+	log.Warn(s.cfg.Driver.Name() + " pausing submissions")
+}
+
+// Resume resumes submissions after a call to Pause.
+func (s *Service) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.paused = false
+	s.metrics.SubmissionsPaused.Set(0)
+	log.Info(s.cfg.Driver.Name() + " resuming submissions")
+}
+
+// Paused returns true if submissions are paused.
+func (s *Service) Paused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.paused
+}
+
+// ForceSubmit triggers an immediate poll, submitting any pending batch
+// regardless of the conditions of the boba service. ErrServicePaused is
+// returned if the service is paused, and ErrSubmissionPending if a forced
+// submission has not yet been picked up.
+func (s *Service) ForceSubmit() error {
+	if s.Paused() {
+		return ErrServicePaused
+	}
+
+	select {
+	case s.forceSubmit <- struct{}{}:
+		return nil
+	default:
+		return ErrSubmissionPending
+	}
+}
+
+// SetMaxL1GasPrice overrides the maximum L1 gas price, in gwei, above which
+// batches are not submitted. A value of zero disables the check.
+func (s *Service) SetMaxL1GasPrice(maxL1GasPrice uint64) {
+	log.Warn(s.cfg.Driver.Name()+" overriding max L1 gas price",
+		"old", s.bobaService.GetMaxL1GasPrice(), "new", maxL1GasPrice)
+	s.bobaService.SetMaxL1GasPrice(maxL1GasPrice)
+}
+
+// Status returns a snapshot of the state of the service.
+func (s *Service) Status() ServiceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.status
+	status.Paused = s.paused
+	status.MaxL1GasPrice = s.bobaService.GetMaxL1GasPrice()
+	return status
+}
+
+// updateStatus applies update to the status of the service.
+func (s *Service) updateStatus(update func(*ServiceStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.status)
+}
+
+// ControlServer is an HTTP handler exposing the following endpoints to control
+// the provided services, where <service> is the case-insensitive driver name:
+//
+//	GET  /status                             status of all services
+//	GET  /services/<service>/status          status of a service
+//	POST /services/<service>/pause           pause submissions
+//	POST /services/<service>/resume          resume submissions
+//	POST /services/<service>/submit          force an immediate submission
+//	POST /services/<service>/max-l1-gas-price?gwei=<n>
+//	                                         override the max L1 gas price
+//
+// All requests must carry the configured token as a bearer token in the
+// Authorization header. Responses are JSON encoded.
+type ControlServer struct {
+	token    string
+	services map[string]*Service
+}
+
+// NewControlServer creates a ControlServer for the given services, requiring
+// requests to be authenticated with token.
+func NewControlServer(token string, services []*Service) *ControlServer {
+	byName := make(map[string]*Service, len(services))
+	for _, service := range services {
+		byName[strings.ToLower(service.cfg.Driver.Name())] = service
+	}
+
+	return &ControlServer{
+		token:    token,
+		services: byName,
+	}
+}
+
+// ServeHTTP handles a control request.
+func (c *ControlServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !c.authorized(r) {
+		writeControlError(w, http.StatusUnauthorized,
+			errors.New("unauthorized"))
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "status" {
+		if r.Method != http.MethodGet {
+			writeControlError(w, http.StatusMethodNotAllowed,
+				fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+
+		statuses := make([]ServiceStatus, 0, len(c.services))
+		for _, service := range c.services {
+			statuses = append(statuses, service.Status())
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Name < statuses[j].Name
+		})
+		writeControlResponse(w, statuses)
+		return
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[0] != "services" {
+		writeControlError(w, http.StatusNotFound, ErrUnknownAction)
+		return
+	}
+	service, ok := c.services[strings.ToLower(parts[1])]
+	if !ok {
+		writeControlError(w, http.StatusNotFound, ErrUnknownService)
+		return
+	}
+
+	action := parts[2]
+	if action == "status" {
+		if r.Method != http.MethodGet {
+			writeControlError(w, http.StatusMethodNotAllowed,
+				fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		writeControlResponse(w, service.Status())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeControlError(w, http.StatusMethodNotAllowed,
+			fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	switch action {
+	case "pause":
+		service.Pause()
+
+	case "resume":
+		service.Resume()
+
+	case "submit":
+		if err := service.ForceSubmit(); err != nil {
+			writeControlError(w, http.StatusConflict, err)
+			return
+		}
+
+	case "max-l1-gas-price":
+		gwei, err := strconv.ParseUint(r.URL.Query().Get("gwei"), 10, 64)
+		if err != nil {
+			writeControlError(w, http.StatusBadRequest,
+				fmt.Errorf("invalid gwei: %w", err))
+			return
+		}
+		service.SetMaxL1GasPrice(gwei)
+
+	default:
+		writeControlError(w, http.StatusNotFound, ErrUnknownAction)
+		return
+	}
+
+	writeControlResponse(w, service.Status())
+}
+
+// authorized returns true if the request carries the configured bearer token.
+func (c *ControlServer) authorized(r *http.Request) bool {
+	const prefix = "Bearer "
+
+	auth := r.Header.Get("Authorization")
+	if c.token == "" || !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	token := strings.TrimPrefix(auth, prefix)
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(c.token)) == 1
+}
+
+// writeControlResponse writes v as a JSON encoded response.
+func writeControlResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Unable to encode control response", "err", err)
+	}
+}
+
+// writeControlError writes err as a JSON encoded response with the given
+// status code.
+func writeControlError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+// RunControlServer spins up a control server for the given services at the
+// provided hostname and port.
+//
+// NOTE: This method MUST be run as a goroutine.
+func RunControlServer(
+	hostname string,
+	port uint64,
+	token string,
+	services []*Service,
+) {
+
+	controlAddr := fmt.Sprintf("%s:%d", hostname, port)
+	server := &http.Server{
+		Addr:              controlAddr,
+		Handler:           NewControlServer(token, services),
+		ReadHeaderTimeout: controlReadTimeout,
+		ReadTimeout:       controlReadTimeout,
+	}
+
+	log.Info("Starting control server", "addr", controlAddr)
+	if err := server.ListenAndServe(); err != nil {
+		log.Error("Control server stopped", "err", err)
+	}
+}
@@ -0,0 +1,149 @@
+package bsscore_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bsscore "github.com/ethereum-optimism/optimism/go/bss-core"
+	"github.com/ethereum-optimism/optimism/go/bss-core/metrics"
+	"github.com/ethereum-optimism/optimism/go/bss-core/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+const testControlToken = "secret"
+
+var testDriverMetrics = metrics.NewMetrics("control_test")
+
+// testDriver is a bsscore.Driver that only supports the methods used to
+// construct a Service.
+type testDriver struct {
+	bsscore.Driver
+}
+
+func (d *testDriver) Name() string {
+	return "Sequencer"
+}
+
+func (d *testDriver) Metrics() *metrics.Metrics {
+	return testDriverMetrics
+}
+
+func (d *testDriver) WalletAddr() common.Address {
+	return common.Address{}
+}
+
+func (d *testDriver) ClearPendingTx(
+	context.Context, txmgr.TxManager, *ethclient.Client) error {
+
+	return nil
+}
+
+func (d *testDriver) SubmitBatchTx(
+	context.Context, *types.Transaction) (*types.Transaction, error) {
+
+	return nil, nil
+}
+
+// controlRequest issues a control request to the server, decoding the JSON
+// response into v if non-nil, and returns the status code.
+func controlRequest(
+	t *testing.T,
+	server http.Handler,
+	method, target, token string,
+	v interface{},
+) int {
+
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if v != nil && rec.Code == http.StatusOK {
+		require.Nil(t, json.NewDecoder(rec.Body).Decode(v))
+	}
+	return rec.Code
+}
+
+// TestControlServer asserts that the control server authenticates requests and
+// pauses, resumes and reconfigures the requested service.
+func TestControlServer(t *testing.T) {
+	service := bsscore.NewService(bsscore.ServiceConfig{
+		Context:                 context.Background(),
+		Driver:                  &testDriver{},
+		TxManagerConfig:         txmgr.Config{NumConfirmations: 1},
+		MaxL1GasPrice:           2,
+		SafeMinimumEtherBalance: 1,
+	})
+	server := bsscore.NewControlServer(
+		testControlToken, []*bsscore.Service{service},
+	)
+
+	// Requests without the correct token are rejected.
+	code := controlRequest(t, server, http.MethodGet, "/status", "", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+	code = controlRequest(t, server, http.MethodGet, "/status", "wrong", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	var statuses []bsscore.ServiceStatus
+	code = controlRequest(
+		t, server, http.MethodGet, "/status", testControlToken, &statuses,
+	)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, statuses, 1)
+	require.Equal(t, "Sequencer", statuses[0].Name)
+	require.False(t, statuses[0].Paused)
+	require.Equal(t, uint64(2), statuses[0].MaxL1GasPrice)
+	require.Equal(t, big.NewInt(1e18), statuses[0].SafeMinimumBalance)
+
+	// Service names are case-insensitive, and unknown services are rejected.
+	var status bsscore.ServiceStatus
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/sequencer/pause", testControlToken, &status)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Paused)
+	require.True(t, service.Paused())
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/proposer/pause", testControlToken, nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Submissions can not be forced while paused.
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/Sequencer/submit", testControlToken, nil)
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, bsscore.ErrServicePaused, service.ForceSubmit())
+
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/Sequencer/resume", testControlToken, &status)
+	require.Equal(t, http.StatusOK, code)
+	require.False(t, status.Paused)
+
+	// Only a single forced submission may be pending.
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/Sequencer/submit", testControlToken, nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, bsscore.ErrSubmissionPending, service.ForceSubmit())
+
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/Sequencer/max-l1-gas-price?gwei=50", testControlToken,
+		&status)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(50), status.MaxL1GasPrice)
+	code = controlRequest(t, server, http.MethodPost,
+		"/services/Sequencer/max-l1-gas-price?gwei=abc", testControlToken,
+		nil)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// Actions must be posted.
+	code = controlRequest(t, server, http.MethodGet,
+		"/services/Sequencer/pause", testControlToken, nil)
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
@@ -17,6 +17,21 @@ import (
 func L1EthClientWithTimeout(ctx context.Context, url string, disableHTTP2 bool) (
 	*ethclient.Client, error) {
 
+	rpcClient, err := L1RPCClientWithTimeout(ctx, url, disableHTTP2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethclient.NewClient(rpcClient), nil
+}
+
+// L1RPCClientWithTimeout attempts to dial the L1 provider using the provided
+// URL, returning the raw RPC client. This allows requests that are not exposed
+// by the ethclient, such as eth_feeHistory. If the dial doesn't complete within
+// defaultDialTimeout seconds, this method will return an error.
+func L1RPCClientWithTimeout(ctx context.Context, url string, disableHTTP2 bool) (
+	*rpc.Client, error) {
+
 	ctxt, cancel := context.WithTimeout(ctx, defaultDialTimeout)
 	defer cancel()
 
@@ -29,13 +44,8 @@ func L1EthClientWithTimeout(ctx context.Context, url string, disableHTTP2 bool)
 			}
 		}
 
-		rpcClient, err := rpc.DialHTTPWithClient(url, httpClient)
-		if err != nil {
-			return nil, err
-		}
-
-		return ethclient.NewClient(rpcClient), nil
+		return rpc.DialHTTPWithClient(url, httpClient)
 	}
 
-	return ethclient.DialContext(ctxt, url)
+	return rpc.DialContext(ctxt, url)
 }
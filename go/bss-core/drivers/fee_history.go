@@ -0,0 +1,88 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrIncompleteFeeHistory signals that eth_feeHistory did not return the base
+// fees of the whole requested range.
+var ErrIncompleteFeeHistory = errors.New("incomplete fee history")
+
+// BaseFeeHistoryClient is implemented by L1 clients that can return the base
+// fees of a range of blocks in a single request.
+type BaseFeeHistoryClient interface {
+	// BaseFeeHistory returns the base fees of the blockCount blocks ending
+	// in lastBlock, in ascending order.
+	BaseFeeHistory(
+		ctx context.Context,
+		blockCount uint64,
+		lastBlock *big.Int,
+	) ([]*big.Int, error)
+}
+
+// FeeHistoryClient extends an L1Client with base fee history requests that
+// are served by eth_feeHistory.
+type FeeHistoryClient struct {
+	L1Client
+
+	rpc *rpc.Client
+}
+
+// NewFeeHistoryClient creates a FeeHistoryClient that serves eth_feeHistory
+// requests using the RPC client underlying l1Client.
+func NewFeeHistoryClient(
+	l1Client L1Client,
+	rpcClient *rpc.Client,
+) *FeeHistoryClient {
+
+	return &FeeHistoryClient{
+		L1Client: l1Client,
+		rpc:      rpcClient,
+	}
+}
+
+// feeHistoryResult is the subset of the eth_feeHistory response used to
+// determine base fees.
+type feeHistoryResult struct {
+	OldestBlock   *hexutil.Big   `json:"oldestBlock"`
+	BaseFeePerGas []*hexutil.Big `json:"baseFeePerGas"`
+}
+
+// BaseFeeHistory returns the base fees of the blockCount blocks ending in
+// lastBlock, in ascending order.
+func (c *FeeHistoryClient) BaseFeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+) ([]*big.Int, error) {
+
+	var result feeHistoryResult
+	err := c.rpc.CallContext(
+		ctx, &result, "eth_feeHistory", hexutil.Uint64(blockCount),
+		(*hexutil.Big)(lastBlock), []float64{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response includes the base fee of the block following lastBlock,
+	// and may be cut short if the node has not retained the whole range.
+	if result.OldestBlock == nil ||
+		uint64(len(result.BaseFeePerGas)) < blockCount ||
+		new(big.Int).Add(result.OldestBlock.ToInt(),
+			new(big.Int).SetUint64(blockCount-1)).Cmp(lastBlock) != 0 {
+
+		return nil, ErrIncompleteFeeHistory
+	}
+
+	baseFees := make([]*big.Int, blockCount)
+	for i := range baseFees {
+		baseFees[i] = result.BaseFeePerGas[i].ToInt()
+	}
+	return baseFees, nil
+}
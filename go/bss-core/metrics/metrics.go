@@ -43,6 +43,41 @@ type Metrics struct {
 	//
 	// NOTE: This is currently only active in the sequencer driver.
 	BatchPruneCount prometheus.Gauge
+
+	// SchedulerDecision tracks whether the last evaluated batch was submitted
+	// (1) or postponed (0).
+	SchedulerDecision prometheus.Gauge
+
+	// SchedulerReason tracks the reason for the last scheduling decision. Only
+	// the label of the current reason is set to 1.
+	SchedulerReason *prometheus.GaugeVec
+
+	// SchedulerDecisions tracks the total number of scheduling decisions made
+	// for each reason.
+	SchedulerDecisions *prometheus.CounterVec
+
+	// L1BaseFee tracks the base fee of the latest L1 block in gwei.
+	L1BaseFee prometheus.Gauge
+
+	// L1BaseFeeThreshold tracks the L1 base fee in gwei at or below which the
+	// scheduler considers the L1 cheap.
+	L1BaseFeeThreshold prometheus.Gauge
+
+	// StateRootMismatches tracks the number of times a verifier reported a
+	// state root that differs from the one about to be proposed.
+	//
+	// NOTE: This is currently only active in the proposer driver.
+	StateRootMismatches prometheus.Counter
+
+	// StateRootVerificationHalted is set to 1 once submissions are halted
+	// due to a state root mismatch.
+	//
+	// NOTE: This is currently only active in the proposer driver.
+	StateRootVerificationHalted prometheus.Gauge
+
+	// SubmissionsPaused is set to 1 while submissions are paused through the
+	// control server.
+	SubmissionsPaused prometheus.Gauge
 }
 
 func NewMetrics(subsystem string) *Metrics {
@@ -100,5 +135,45 @@ func NewMetrics(subsystem string) *Metrics {
 			Help:      "Number of times a batch is pruned",
 			Subsystem: subsystem,
 		}),
+		SchedulerDecision: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "scheduler_decision",
+			Help:      "Whether the last batch was submitted (1) or postponed (0)",
+			Subsystem: subsystem,
+		}),
+		SchedulerReason: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "scheduler_reason",
+			Help:      "Reason for the last scheduling decision",
+			Subsystem: subsystem,
+		}, []string{"reason"}),
+		SchedulerDecisions: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "scheduler_decisions",
+			Help:      "Count of scheduling decisions by reason",
+			Subsystem: subsystem,
+		}, []string{"reason"}),
+		L1BaseFee: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "l1_base_fee_gwei",
+			Help:      "Base fee of the latest L1 block",
+			Subsystem: subsystem,
+		}),
+		L1BaseFeeThreshold: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "l1_base_fee_threshold_gwei",
+			Help:      "L1 base fee at or below which batches are submitted",
+			Subsystem: subsystem,
+		}),
+		StateRootMismatches: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "state_root_mismatches",
+			Help:      "Count of state roots that differ from a verifier",
+			Subsystem: subsystem,
+		}),
+		StateRootVerificationHalted: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "state_root_verification_halted",
+			Help:      "Whether submissions are halted due to a state root mismatch",
+			Subsystem: subsystem,
+		}),
+		SubmissionsPaused: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "submissions_paused",
+			Help:      "Whether submissions are paused through the control server",
+			Subsystem: subsystem,
+		}),
 	}
 }
@@ -8,12 +8,15 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/go/bss-core/boba"
+	"github.com/ethereum-optimism/optimism/go/bss-core/drivers"
 	"github.com/ethereum-optimism/optimism/go/bss-core/metrics"
 	"github.com/ethereum-optimism/optimism/go/bss-core/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
@@ -74,6 +77,20 @@ type ServiceConfig struct {
 	MinTxSize              uint64
 	MaxBatchSubmissionTime time.Duration
 	MaxL1GasPrice          uint64
+	MaxTxSize              uint64
+	MaxL2BlockLag          uint64
+	MaxSubmissionDelay     time.Duration
+	BaseFeePercentile      float64
+	BaseFeeHistorySize     uint64
+	FullBatchRatio         float64
+
+	// L1RPCClient is the optional RPC client underlying L1Client. If set,
+	// the L1 base fee history is requested in batches using eth_feeHistory.
+	L1RPCClient *rpc.Client
+
+	// SafeMinimumEtherBalance is the amount of ether below which the
+	// submitter's balance is logged as an error and reported as unsafe.
+	SafeMinimumEtherBalance uint64
 }
 
 type Service struct {
@@ -85,6 +102,13 @@ type Service struct {
 	txMgr       txmgr.TxManager
 	metrics     *metrics.Metrics
 
+	// mu guards the control state below, which is accessed by the control
+	// server concurrently with the event loop.
+	mu          sync.Mutex
+	paused      bool
+	status      ServiceStatus
+	forceSubmit chan struct{}
+
 	wg sync.WaitGroup
 }
 
@@ -95,10 +119,26 @@ func NewService(cfg ServiceConfig) *Service {
 		cfg.Driver.Name(), cfg.TxManagerConfig, cfg.L1Client,
 	)
 
-	bobaService := boba.NewBobaService(
-		cfg.Driver.Name(), cfg.Context, cfg.MinTxSize, cfg.MaxL1GasPrice,
-		cfg.MaxBatchSubmissionTime, cfg.L1Client,
-	)
+	var l1Client drivers.L1Client = cfg.L1Client
+	if cfg.L1RPCClient != nil {
+		l1Client = drivers.NewFeeHistoryClient(cfg.L1Client, cfg.L1RPCClient)
+	}
+
+	bobaService := boba.NewBobaServiceWithConfig(boba.Config{
+		Name:                   cfg.Driver.Name(),
+		Context:                cfg.Context,
+		L1Client:               l1Client,
+		MinTxSize:              cfg.MinTxSize,
+		MaxBatchSubmissionTime: cfg.MaxBatchSubmissionTime,
+		MaxL1GasPrice:          cfg.MaxL1GasPrice,
+		MaxTxSize:              cfg.MaxTxSize,
+		MaxL2BlockLag:          cfg.MaxL2BlockLag,
+		MaxSubmissionDelay:     cfg.MaxSubmissionDelay,
+		BaseFeePercentile:      cfg.BaseFeePercentile,
+		BaseFeeHistorySize:     cfg.BaseFeeHistorySize,
+		FullBatchRatio:         cfg.FullBatchRatio,
+		Metrics:                cfg.Driver.Metrics(),
+	})
 
 	return &Service{
 		cfg:         cfg,
@@ -107,6 +147,11 @@ func NewService(cfg ServiceConfig) *Service {
 		txMgr:       txMgr,
 		metrics:     cfg.Driver.Metrics(),
 		bobaService: bobaService,
+		status: ServiceStatus{
+			Name:               cfg.Driver.Name(),
+			SafeMinimumBalance: ethToWei(cfg.SafeMinimumEtherBalance),
+		},
+		forceSubmit: make(chan struct{}, 1),
 	}
 }
 
@@ -141,126 +186,184 @@ func (s *Service) eventLoop() {
 	}
 
 	for {
+		var force bool
 		select {
 		case <-time.After(s.cfg.PollInterval):
-			// Record the submitter's current ETH balance. This is done first in
-			// case any of the remaining steps fail, we can at least have an
-			// accurate view of the submitter's balance.
-			balance, err := s.cfg.L1Client.BalanceAt(
-				s.ctx, s.cfg.Driver.WalletAddr(), nil,
-			)
-			if err != nil {
-				log.Error(name+" unable to get current balance", "err", err)
-				continue
-			}
-			s.metrics.ETHBalance.Set(weiToEth64(balance))
+		case <-s.forceSubmit:
+			log.Info(name + " forcing batch submission")
+			force = true
+		case err := <-s.ctx.Done():
+			log.Error(name+" service shutting down", "err", err)
+			return
+		}
 
-			// Determine the range of L2 blocks that the batch submitter has not
-			// processed, and needs to take action on.
-			log.Info(name + " fetching current block range")
-			start, end, err := s.cfg.Driver.GetBatchBlockRange(s.ctx)
-			if err != nil {
-				log.Error(name+" unable to get block range", "err", err)
-				continue
-			}
+		// Record the submitter's current ETH balance. This is done first in
+		// case any of the remaining steps fail, we can at least have an
+		// accurate view of the submitter's balance.
+		balance, err := s.cfg.L1Client.BalanceAt(
+			s.ctx, s.cfg.Driver.WalletAddr(), nil,
+		)
+		if err != nil {
+			log.Error(name+" unable to get current balance", "err", err)
+			continue
+		}
+		s.metrics.ETHBalance.Set(weiToEth64(balance))
+		s.recordBalance(balance)
 
-			// No new updates.
-			if start.Cmp(end) == 0 {
-				log.Info(name+" no updates", "start", start, "end", end)
-				continue
-			}
-			log.Info(name+" block range", "start", start, "end", end)
+		// Skip submissions while paused through the control server.
+		if s.Paused() {
+			log.Info(name + " submissions paused")
+			continue
+		}
 
-			// Query for the submitter's current nonce.
-			nonce64, err := s.cfg.L1Client.NonceAt(
-				s.ctx, s.cfg.Driver.WalletAddr(), nil,
-			)
-			if err != nil {
-				log.Error(name+" unable to get current nonce",
-					"err", err)
-				continue
-			}
-			nonce := new(big.Int).SetUint64(nonce64)
+		// Determine the range of L2 blocks that the batch submitter has not
+		// processed, and needs to take action on.
+		log.Info(name + " fetching current block range")
+		start, end, err := s.cfg.Driver.GetBatchBlockRange(s.ctx)
+		if err != nil {
+			log.Error(name+" unable to get block range", "err", err)
+			continue
+		}
 
-			batchTxBuildStart := time.Now()
-			tx, batchSize, err := s.cfg.Driver.CraftBatchTx(
-				s.ctx, start, end, nonce,
-			)
+		// No new updates.
+		if start.Cmp(end) == 0 {
+			log.Info(name+" no updates", "start", start, "end", end)
+			continue
+		}
+		log.Info(name+" block range", "start", start, "end", end)
+		s.updateStatus(func(status *ServiceStatus) {
+			status.LastStart = start
+			status.LastEnd = end
+		})
+
+		// Query for the submitter's current nonce.
+		nonce64, err := s.cfg.L1Client.NonceAt(
+			s.ctx, s.cfg.Driver.WalletAddr(), nil,
+		)
+		if err != nil {
+			log.Error(name+" unable to get current nonce",
+				"err", err)
+			continue
+		}
+		nonce := new(big.Int).SetUint64(nonce64)
+		s.updateStatus(func(status *ServiceStatus) {
+			status.Nonce = nonce64
+		})
+
+		batchTxBuildStart := time.Now()
+		tx, batchSize, err := s.cfg.Driver.CraftBatchTx(
+			s.ctx, start, end, nonce,
+		)
+		if err != nil {
+			log.Error(name+" unable to craft batch tx",
+				"err", err)
+			continue
+		}
+		log.Info(name+" batch tx size", "size", batchSize)
+		batchTxBuildTime := time.Since(batchTxBuildStart) / time.Millisecond
+		s.metrics.BatchTxBuildTime.Set(float64(batchTxBuildTime))
+
+		// Forced submissions bypass the conditions of the boba service.
+		if !force {
+			err = s.bobaService.VerifyCondition(boba.BatchInfo{
+				Size:             batchSize,
+				CallDataSize:     uint64(len(tx.Data())),
+				NumPendingBlocks: new(big.Int).Sub(end, start).Uint64(),
+			})
 			if err != nil {
-				log.Error(name+" unable to craft batch tx",
-					"err", err)
 				continue
 			}
-			log.Info(name+" batch tx size", "size", batchSize)
-			batchTxBuildTime := time.Since(batchTxBuildStart) / time.Millisecond
-			s.metrics.BatchTxBuildTime.Set(float64(batchTxBuildTime))
+		}
 
-			if err := s.bobaService.VerifyCondition(batchSize); err != nil {
-				continue
-			}
+		// Record the size of the batch transaction.
+		var txBuf bytes.Buffer
+		if err := tx.EncodeRLP(&txBuf); err != nil {
+			log.Error(name+" unable to encode batch tx", "err", err)
+			continue
+		}
+		s.metrics.BatchSizeInBytes.Observe(float64(len(txBuf.Bytes())))
 
-			// Record the size of the batch transaction.
-			var txBuf bytes.Buffer
-			if err := tx.EncodeRLP(&txBuf); err != nil {
-				log.Error(name+" unable to encode batch tx", "err", err)
-				continue
-			}
-			s.metrics.BatchSizeInBytes.Observe(float64(len(txBuf.Bytes())))
-
-			// Construct the transaction submission clousure that will attempt
-			// to send the next transaction at the given nonce and gas price.
-			sendTx := func(ctx context.Context) (*types.Transaction, error) {
-				log.Info(name+" attempting batch tx", "start", start,
-					"end", end, "nonce", nonce)
-
-				tx, err := s.cfg.Driver.SubmitBatchTx(ctx, tx)
-				if err != nil {
-					return nil, err
-				}
-
-				log.Info(
-					name+" submitted batch tx",
-					"start", start,
-					"end", end,
-					"nonce", nonce,
-					"tx_hash", tx.Hash(),
-				)
-
-				return tx, nil
-			}
+		// Construct the transaction submission clousure that will attempt
+		// to send the next transaction at the given nonce and gas price.
+		sendTx := func(ctx context.Context) (*types.Transaction, error) {
+			log.Info(name+" attempting batch tx", "start", start,
+				"end", end, "nonce", nonce)
 
-			// Wait until one of our submitted transactions confirms. If no
-			// receipt is received it's likely our gas price was too low.
-			batchConfirmationStart := time.Now()
-			receipt, err := s.txMgr.Send(s.ctx, sendTx)
+			tx, err := s.cfg.Driver.SubmitBatchTx(ctx, tx)
 			if err != nil {
-				log.Error(name+" unable to publish batch tx",
-					"err", err)
-				s.metrics.FailedSubmissions.Inc()
-				continue
+				return nil, err
 			}
 
-			// The transaction was successfully submitted.
-			log.Info(name+" batch tx successfully published",
-				"tx_hash", receipt.TxHash)
-			s.bobaService.SetLastBatchSubmissionTime()
-			batchConfirmationTime := time.Since(batchConfirmationStart) /
-				time.Millisecond
-			s.metrics.BatchConfirmationTime.Set(float64(batchConfirmationTime))
-			s.metrics.BatchesSubmitted.Inc()
-			s.metrics.SubmissionGasUsed.Set(float64(receipt.GasUsed))
-			s.metrics.SubmissionTimestamp.Set(float64(time.Now().UnixNano() / 1e6))
+			log.Info(
+				name+" submitted batch tx",
+				"start", start,
+				"end", end,
+				"nonce", nonce,
+				"tx_hash", tx.Hash(),
+			)
+			txHash := tx.Hash()
+			s.updateStatus(func(status *ServiceStatus) {
+				status.PendingTxHash = &txHash
+			})
+
+			return tx, nil
+		}
 
-		case err := <-s.ctx.Done():
-			log.Error(name+" service shutting down", "err", err)
-			return
+		// Wait until one of our submitted transactions confirms. If no
+		// receipt is received it's likely our gas price was too low.
+		batchConfirmationStart := time.Now()
+		receipt, err := s.txMgr.Send(s.ctx, sendTx)
+		s.updateStatus(func(status *ServiceStatus) {
+			status.PendingTxHash = nil
+		})
+		if err != nil {
+			log.Error(name+" unable to publish batch tx",
+				"err", err)
+			s.metrics.FailedSubmissions.Inc()
+			continue
 		}
+
+		// The transaction was successfully submitted.
+		log.Info(name+" batch tx successfully published",
+			"tx_hash", receipt.TxHash)
+		s.bobaService.SetLastBatchSubmissionTime()
+		s.updateStatus(func(status *ServiceStatus) {
+			status.LastSubmittedTxHash = &receipt.TxHash
+		})
+		batchConfirmationTime := time.Since(batchConfirmationStart) /
+			time.Millisecond
+		s.metrics.BatchConfirmationTime.Set(float64(batchConfirmationTime))
+		s.metrics.BatchesSubmitted.Inc()
+		s.metrics.SubmissionGasUsed.Set(float64(receipt.GasUsed))
+		s.metrics.SubmissionTimestamp.Set(float64(time.Now().UnixNano() / 1e6))
 	}
 }
 
+// recordBalance records the submitter's current balance in the status, logging
+// an error if it is below SafeMinimumEtherBalance.
+func (s *Service) recordBalance(balance *big.Int) {
+	s.updateStatus(func(status *ServiceStatus) {
+		status.Balance = balance
+		status.BelowSafeMinimumBalance =
+			balance.Cmp(status.SafeMinimumBalance) < 0
+		if status.BelowSafeMinimumBalance {
+			log.Error(status.Name+" balance below safe minimum",
+				"balance", balance,
+				"safe_minimum_balance", status.SafeMinimumBalance)
+		}
+	})
+}
+
 func weiToEth64(wei *big.Int) float64 {
 	eth := new(big.Float).SetInt(wei)
 	eth.Mul(eth, weiToEth)
 	eth64, _ := eth.Float64()
 	return eth64
 }
+
+// ethToWei converts an amount in ether to an amount in wei.
+func ethToWei(eth uint64) *big.Int {
+	return new(big.Int).Mul(
+		new(big.Int).SetUint64(eth), big.NewInt(params.Ether),
+	)
+}
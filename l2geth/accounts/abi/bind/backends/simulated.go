@@ -125,6 +125,7 @@ func (b *SimulatedBackend) rollback() {
 
 	b.pendingBlock = blocks[0]
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), statedb.Database())
+	b.pendingState.SetOVMConfig(b.config.OVMConfig())
 }
 
 // CodeAt returns the code associated with a certain account in the blockchain.
@@ -431,7 +432,7 @@ func (b *SimulatedBackend) callContract(ctx context.Context, call ethereum.CallM
 	// Execute the call.
 	msg := callmsg{call}
 
-	evmContext := core.NewEVMContext(msg, block.Header(), b.blockchain, nil)
+	evmContext := core.NewEVMContext(msg, block.Header(), b.blockchain, b.config, nil)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
 	vmenv := vm.NewEVM(evmContext, statedb, b.config, vm.Config{})
@@ -465,6 +466,7 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 
 	b.pendingBlock = blocks[0]
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), statedb.Database())
+	b.pendingState.SetOVMConfig(b.config.OVMConfig())
 	return nil
 }
 
@@ -578,6 +580,7 @@ func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 
 	b.pendingBlock = blocks[0]
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), statedb.Database())
+	b.pendingState.SetOVMConfig(b.config.OVMConfig())
 
 	return nil
 }
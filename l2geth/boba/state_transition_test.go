@@ -68,10 +68,15 @@ func TestNewStateTransition(t *testing.T) {
 	statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), alloc)
 
 	// Enable OVM
-	rcfg.UsingOVM = true
-	rcfg.OvmBobaGasPricOracle = common.HexToAddress("0x4200000000000000000000000000000000000024")
+	ovm := &rcfg.Config{
+		UsingOVM:                  true,
+		BobaGasPriceOracleAddress: common.HexToAddress("0x4200000000000000000000000000000000000024"),
+	}
+	chainConfig := *params.MainnetChainConfig
+	chainConfig.OVM = ovm
+	statedb.SetOVMConfig(ovm)
 
-	evm := vm.NewEVM(context, statedb, params.MainnetChainConfig, vm.Config{})
+	evm := vm.NewEVM(context, statedb, &chainConfig, vm.Config{})
 	msg, err := tx.AsMessage(signer)
 	if err != nil {
 		t.Fatalf("failed to prepare transaction for tracing: %v", err)
@@ -98,7 +103,7 @@ func TestNewStateTransition(t *testing.T) {
 
 	// Check the Boba balance
 	userBobaBalance := statedb.GetBobaBalance(msg.From())
-	vaultBalance := statedb.GetBobaBalance(rcfg.OvmBobaGasPricOracle)
+	vaultBalance := statedb.GetBobaBalance(ovm.BobaGasPriceOracleAddress)
 
 	if new(big.Int).Mul(big.NewInt(int64(gasUsed)), msg.GasPrice()).Cmp(vaultBalance) != 0 {
 		t.Fatal("failed to calculate boba fee")
@@ -110,7 +115,7 @@ func TestNewStateTransition(t *testing.T) {
 
 	// Add l1 security fee
 	preUserBobaBalance := statedb.GetBobaBalance(msg.From())
-	preVaultBalance := statedb.GetBobaBalance(rcfg.OvmBobaGasPricOracle)
+	preVaultBalance := statedb.GetBobaBalance(ovm.BobaGasPriceOracleAddress)
 
 	statedb.SetState(rcfg.L2GasPriceOracleAddress, rcfg.L1GasPriceSlot, common.BigToHash(common.Big1))
 	statedb.SetState(rcfg.L2GasPriceOracleAddress, rcfg.OverheadSlot, common.BigToHash(big.NewInt(2750)))
@@ -134,7 +139,7 @@ func TestNewStateTransition(t *testing.T) {
 		t.Fatalf("failed to execute transaction: %v", err)
 	}
 	afterUserBobaBalance := statedb.GetBobaBalance(msg.From())
-	afterVaultBalance := statedb.GetBobaBalance(rcfg.OvmBobaGasPricOracle)
+	afterVaultBalance := statedb.GetBobaBalance(ovm.BobaGasPriceOracleAddress)
 
 	userPaidBobaFee := new(big.Int).Sub(preUserBobaBalance, afterUserBobaBalance)
 	vaultReceivedFee := new(big.Int).Sub(afterVaultBalance, preVaultBalance)
@@ -161,7 +166,7 @@ func TestNewStateTransition(t *testing.T) {
 
 	// Set boba as the fee token
 	preUserBobaBalance = statedb.GetBobaBalance(msg.From())
-	preVaultBalance = statedb.GetBobaBalance(rcfg.OvmBobaGasPricOracle)
+	preVaultBalance = statedb.GetBobaBalance(ovm.BobaGasPriceOracleAddress)
 
 	st = core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.Gas()))
 	_, _, _, err = st.TransitionDb()
@@ -170,7 +175,7 @@ func TestNewStateTransition(t *testing.T) {
 	}
 
 	afterUserBobaBalance = statedb.GetBobaBalance(msg.From())
-	afterVaultBalance = statedb.GetBobaBalance(rcfg.OvmBobaGasPricOracle)
+	afterVaultBalance = statedb.GetBobaBalance(ovm.BobaGasPriceOracleAddress)
 
 	if preUserBobaBalance.Cmp(afterUserBobaBalance) != 0 {
 		t.Fatal("should not charge fee")
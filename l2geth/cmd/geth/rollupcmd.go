@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/cmd/utils"
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	rollupdump "github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	rollupDumpCommand = cli.Command{
+		Name:     "rollup-dump",
+		Usage:    "Export and import regenesis state dumps",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The rollup-dump commands move the state of a chain into a new genesis. The
+dump is written in the OvmDump JSON format, one account at a time, so that
+it can be produced from a mainnet sized state.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export the state at a block into a regenesis dump",
+				ArgsUsage: "<blockHash | blockNum> <dumpFile>",
+				Action:    utils.MigrateFlags(rollupDumpExport),
+				Category:  "BLOCKCHAIN COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					utils.SyncModeFlag,
+					utils.RollupDumpExcludeFlag,
+					utils.RollupDumpOverridesFlag,
+				},
+				Description: `
+    geth rollup-dump export <blockHash | blockNum> <dumpFile>
+
+Walks the state at the given block and writes the code, nonce, balance and
+storage of every account to the dump file. Accounts listed with --exclude are left out.
+Accounts in the --overrides dump replace the code, nonce and storage slots of
+the matching predeploys and are added if they do not exist yet. The export
+fails if the preimage of an account or storage key is missing.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Initialize a new genesis block from a regenesis dump",
+				ArgsUsage: "<genesisPath> <dumpFile>",
+				Action:    utils.MigrateFlags(rollupDumpImport),
+				Category:  "BLOCKCHAIN COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+    geth rollup-dump import <genesisPath> <dumpFile>
+
+Loads the genesis file and writes a genesis block whose state holds its
+allocation along with every account of the dump. The accounts are written
+into the state as they are read and the state is committed to the database
+as it grows. An account that is both in the genesis allocation and in the
+dump is an error, as is a database that already holds a genesis block.`,
+			},
+		},
+	}
+)
+
+func rollupDumpExport(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		utils.Fatalf("This command requires a block and an output file.")
+	}
+	transform, err := makeDumpTransform(ctx)
+	if err != nil {
+		utils.Fatalf("Invalid dump transform: %v", err)
+	}
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	var block *types.Block
+	if arg := ctx.Args().First(); hashish(arg) {
+		block = chain.GetBlockByHash(common.HexToHash(arg))
+	} else {
+		num, _ := strconv.ParseUint(arg, 10, 64)
+		block = chain.GetBlockByNumber(num)
+	}
+	if block == nil {
+		utils.Fatalf("Block not found")
+	}
+	statedb, err := state.New(block.Root(), state.NewDatabase(chainDb))
+	if err != nil {
+		utils.Fatalf("Could not create new state: %v", err)
+	}
+	file, err := os.Create(ctx.Args().Get(1))
+	if err != nil {
+		utils.Fatalf("Failed to create dump file: %v", err)
+	}
+	defer file.Close()
+
+	var (
+		start = time.Now()
+		enc   = rollupdump.NewEncoder(file)
+	)
+	log.Info("Exporting regenesis dump", "number", block.NumberU64(), "hash", block.Hash(), "root", block.Root())
+	progress := &exportProgress{Encoder: enc, start: start, logged: start}
+	if err := statedb.OvmDump(transform.Writer(progress)); err != nil {
+		utils.Fatalf("Failed to export state: %v", err)
+	}
+	remaining := transform.Remaining()
+	for _, account := range remaining {
+		if err := enc.Encode(account); err != nil {
+			utils.Fatalf("Failed to export state: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		utils.Fatalf("Failed to write dump file: %v", err)
+	}
+	log.Info("Exported regenesis dump", "accounts", enc.Count(), "added", len(remaining), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// exportProgress logs the progress of an export every few seconds.
+type exportProgress struct {
+	*rollupdump.Encoder
+	start  time.Time
+	logged time.Time
+}
+
+func (p *exportProgress) EndAccount(account *rollupdump.OvmDumpAccount) error {
+	if time.Since(p.logged) > 8*time.Second {
+		log.Info("Exporting regenesis dump", "accounts", p.Count(), "elapsed", common.PrettyDuration(time.Since(p.start)))
+		p.logged = time.Now()
+	}
+	return p.Encoder.EndAccount(account)
+}
+
+// makeDumpTransform creates the account transform of an export from the
+// exclude and overrides flags.
+func makeDumpTransform(ctx *cli.Context) (*rollupdump.Transform, error) {
+	var exclude []common.Address
+	if list := ctx.String(utils.RollupDumpExcludeFlag.Name); list != "" {
+		for _, addr := range strings.Split(list, ",") {
+			addr = strings.TrimSpace(addr)
+			if !common.IsHexAddress(addr) {
+				return nil, fmt.Errorf("invalid address %q", addr)
+			}
+			exclude = append(exclude, common.HexToAddress(addr))
+		}
+	}
+	var overrides []*rollupdump.OvmDumpAccount
+	if path := ctx.String(utils.RollupDumpOverridesFlag.Name); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		if overrides, err = rollupdump.ReadOverrides(file); err != nil {
+			return nil, err
+		}
+	}
+	return rollupdump.NewTransform(exclude, overrides)
+}
+
+func rollupDumpImport(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		utils.Fatalf("This command requires a genesis file and a dump file.")
+	}
+	genesisFile, err := os.Open(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to read genesis file: %v", err)
+	}
+	defer genesisFile.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(genesisFile).Decode(genesis); err != nil {
+		utils.Fatalf("Invalid genesis file: %v", err)
+	}
+	dumpFile, err := os.Open(ctx.Args().Get(1))
+	if err != nil {
+		utils.Fatalf("Failed to read dump file: %v", err)
+	}
+	defer dumpFile.Close()
+
+	stack := makeFullNode(ctx)
+	defer stack.Close()
+
+	chaindb, err := stack.OpenDatabase("chaindata", 0, 0, "")
+	if err != nil {
+		utils.Fatalf("Failed to open database: %v", err)
+	}
+	defer chaindb.Close()
+
+	block, accounts, err := genesis.CommitOvmDump(chaindb, dumpFile)
+	if err != nil {
+		utils.Fatalf("Failed to write regenesis state: %v", err)
+	}
+	log.Info("Successfully wrote regenesis state", "accounts", accounts, "hash", block.Hash())
+	return nil
+}
@@ -77,7 +77,42 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.RollupEnforceFeesFlag,
 			utils.RollupFeeThresholdDownFlag,
 			utils.RollupFeeThresholdUpFlag,
+			utils.RollupMaxReorgDepthFlag,
+			utils.RollupReorgDryRunFlag,
+			utils.RollupClientFlag,
+			utils.RollupL1RpcHttpFlag,
+			utils.RollupL1CanonicalTransactionChainAddressFlag,
+			utils.RollupL1ConfirmationsFlag,
+			utils.RollupTuringV1HeightFlag,
+			utils.RollupStateDivergenceCheckFlag,
+			utils.RollupHaltOnStateDivergenceFlag,
+			utils.RollupSyncConcurrencyFlag,
+			utils.RollupHealthAddrFlag,
+			utils.RollupHealthMaxIndexLagFlag,
+			utils.RollupHealthMaxQueueLagFlag,
+			utils.RollupHealthMaxL1ContextAgeFlag,
+			utils.RollupHealthMaxTxAgeFlag,
+			utils.RollupSenderTxRateFlag,
+			utils.RollupSenderTxBurstFlag,
+			utils.RollupIPTxRateFlag,
+			utils.RollupIPTxBurstFlag,
+			utils.RollupIngressQueueSizeFlag,
+			utils.RollupMaxNonceGapFlag,
+			utils.RollupUsingOVMFlag,
+			utils.RollupTuringCreditAddressFlag,
+			utils.RollupBobaGasPriceOracleAddressFlag,
+			utils.RollupL2BobaTokenAddressFlag,
+			utils.TuringAllowedHostsFlag,
+			utils.TuringDeniedHostsFlag,
+			utils.TuringTimeoutFlag,
+			utils.TuringMaxRawResponseLengthFlag,
+			utils.TuringMaxResponseLengthFlag,
+			utils.TuringMaxConcurrentCallsFlag,
+			utils.TuringRandomnessFlag,
+			utils.TuringRandomSeedFlag,
+			utils.TuringRandomKeyFlag,
 			utils.SequencerClientHttpFlag,
+			utils.SequencerClientWsFlag,
 		},
 	},
 	{
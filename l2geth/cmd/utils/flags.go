@@ -36,12 +36,14 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/accounts/keystore"
 	"github.com/ethereum-optimism/optimism/l2geth/common"
 	"github.com/ethereum-optimism/optimism/l2geth/common/fdlimit"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
 	"github.com/ethereum-optimism/optimism/l2geth/consensus"
 	"github.com/ethereum-optimism/optimism/l2geth/consensus/clique"
 	"github.com/ethereum-optimism/optimism/l2geth/consensus/ethash"
 	"github.com/ethereum-optimism/optimism/l2geth/core"
 	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
 	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto/ecvrf"
 	"github.com/ethereum-optimism/optimism/l2geth/eth"
 	"github.com/ethereum-optimism/optimism/l2geth/eth/downloader"
 	"github.com/ethereum-optimism/optimism/l2geth/eth/gasprice"
@@ -227,6 +229,14 @@ var (
 		Name:  "nocode",
 		Usage: "Exclude contract code (save db lookups)",
 	}
+	RollupDumpExcludeFlag = cli.StringFlag{
+		Name:  "exclude",
+		Usage: "Comma separated list of accounts to leave out of the regenesis dump",
+	}
+	RollupDumpOverridesFlag = cli.StringFlag{
+		Name:  "overrides",
+		Usage: "Regenesis dump whose accounts replace or add predeploys in the export",
+	}
 	defaultSyncMode = eth.DefaultConfig.SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:  "syncmode",
@@ -865,11 +875,193 @@ var (
 		Usage:  "Allow txs with fees above the current fee up to this amount, must be > 1",
 		EnvVar: "ROLLUP_FEE_THRESHOLD_UP",
 	}
+	RollupMaxReorgDepthFlag = cli.Uint64Flag{
+		Name:   "rollup.maxreorgdepth",
+		Usage:  "Maximum number of blocks rolled back when a batched transaction mismatches, reorgs are disabled if zero",
+		EnvVar: "ROLLUP_MAX_REORG_DEPTH",
+	}
+	RollupReorgDryRunFlag = cli.BoolFlag{
+		Name:   "rollup.reorgdryrun",
+		Usage:  "Log reorgs caused by mismatched batched transactions without performing them",
+		EnvVar: "ROLLUP_REORG_DRY_RUN",
+	}
+	RollupClientFlag = cli.StringFlag{
+		Name:   "rollup.client",
+		Usage:  "Source of the rollup client (\"dtl\" or \"l1\"), defaults to dtl",
+		Value:  "dtl",
+		EnvVar: "ROLLUP_CLIENT",
+	}
+	RollupL1RpcHttpFlag = cli.StringFlag{
+		Name:   "rollup.l1rpchttp",
+		Usage:  "HTTP endpoint of layer one for the l1 rollup client",
+		EnvVar: "ROLLUP_L1_RPC_HTTP",
+	}
+	RollupL1CanonicalTransactionChainAddressFlag = cli.StringFlag{
+		Name:   "rollup.l1ctcaddress",
+		Usage:  "Address of the canonical transaction chain for the l1 rollup client",
+		EnvVar: "ROLLUP_L1_CTC_ADDRESS",
+	}
+	RollupL1ConfirmationsFlag = cli.Uint64Flag{
+		Name:   "rollup.l1confirmations",
+		Usage:  "Number of layer one confirmations required by the l1 rollup client",
+		Value:  eth.DefaultConfig.Rollup.L1Confirmations,
+		EnvVar: "ROLLUP_L1_CONFIRMATIONS",
+	}
+	RollupTuringV1HeightFlag = cli.Uint64Flag{
+		Name:   "rollup.turingv1height",
+		Usage:  "Block height from which batched transactions carry the Turing v1 header",
+		EnvVar: "ROLLUP_TURING_V1_HEIGHT",
+	}
+	RollupStateDivergenceCheckFlag = cli.BoolFlag{
+		Name:   "rollup.statedivergencecheck",
+		Usage:  "Compare the state roots posted to the State Commitment Chain with the local state roots",
+		EnvVar: "ROLLUP_STATE_DIVERGENCE_CHECK",
+	}
+	RollupHaltOnStateDivergenceFlag = cli.BoolFlag{
+		Name:   "rollup.haltonstatedivergence",
+		Usage:  "Halt syncing at the first state root divergence",
+		EnvVar: "ROLLUP_HALT_ON_STATE_DIVERGENCE",
+	}
+	RollupSyncConcurrencyFlag = cli.IntFlag{
+		Name:   "rollup.syncconcurrency",
+		Usage:  "Maximum number of elements fetched ahead of being applied while syncing",
+		Value:  eth.DefaultConfig.Rollup.SyncConcurrency,
+		EnvVar: "ROLLUP_SYNC_CONCURRENCY",
+	}
+	RollupHealthAddrFlag = cli.StringFlag{
+		Name:   "rollup.healthaddr",
+		Usage:  "Address of the HTTP health endpoint, disabled if empty",
+		EnvVar: "ROLLUP_HEALTH_ADDR",
+	}
+	RollupHealthMaxIndexLagFlag = cli.Uint64Flag{
+		Name:   "rollup.healthmaxindexlag",
+		Usage:  "Maximum number of transactions behind the remote tip before reporting unhealthy, disabled if zero",
+		EnvVar: "ROLLUP_HEALTH_MAX_INDEX_LAG",
+	}
+	RollupHealthMaxQueueLagFlag = cli.Uint64Flag{
+		Name:   "rollup.healthmaxqueuelag",
+		Usage:  "Maximum number of enqueue transactions behind the remote tip before reporting unhealthy, disabled if zero",
+		EnvVar: "ROLLUP_HEALTH_MAX_QUEUE_LAG",
+	}
+	RollupHealthMaxL1ContextAgeFlag = cli.DurationFlag{
+		Name:   "rollup.healthmaxl1contextage",
+		Usage:  "Maximum age of the L1 context before reporting unhealthy, disabled if zero",
+		EnvVar: "ROLLUP_HEALTH_MAX_L1_CONTEXT_AGE",
+	}
+	RollupHealthMaxTxAgeFlag = cli.DurationFlag{
+		Name:   "rollup.healthmaxtxage",
+		Usage:  "Maximum time since a transaction was applied before reporting unhealthy, disabled if zero",
+		EnvVar: "ROLLUP_HEALTH_MAX_TX_AGE",
+	}
+	RollupSenderTxRateFlag = cli.Float64Flag{
+		Name:   "rollup.sendertxrate",
+		Usage:  "Transactions per second that a sender may submit to the sequencer, disabled if zero",
+		EnvVar: "ROLLUP_SENDER_TX_RATE",
+	}
+	RollupSenderTxBurstFlag = cli.IntFlag{
+		Name:   "rollup.sendertxburst",
+		Usage:  "Number of transactions that a sender may submit to the sequencer at once",
+		EnvVar: "ROLLUP_SENDER_TX_BURST",
+	}
+	RollupIPTxRateFlag = cli.Float64Flag{
+		Name:   "rollup.iptxrate",
+		Usage:  "Transactions per second that a remote address may submit to the sequencer over HTTP, disabled if zero",
+		EnvVar: "ROLLUP_IP_TX_RATE",
+	}
+	RollupIPTxBurstFlag = cli.IntFlag{
+		Name:   "rollup.iptxburst",
+		Usage:  "Number of transactions that a remote address may submit to the sequencer at once",
+		EnvVar: "ROLLUP_IP_TX_BURST",
+	}
+	RollupIngressQueueSizeFlag = cli.IntFlag{
+		Name:   "rollup.ingressqueuesize",
+		Usage:  "Maximum number of transactions waiting to be applied by the sequencer, by highest gas price, disabled if zero",
+		EnvVar: "ROLLUP_INGRESS_QUEUE_SIZE",
+	}
+	RollupMaxNonceGapFlag = cli.Uint64Flag{
+		Name:   "rollup.maxnoncegap",
+		Usage:  "Maximum number of nonces that a transaction may be ahead of its sender, disabled if zero",
+		EnvVar: "ROLLUP_MAX_NONCE_GAP",
+	}
+	RollupUsingOVMFlag = cli.BoolFlag{
+		Name:   "rollup.usingovm",
+		Usage:  "Enable the functionality necessary for the OVM",
+		EnvVar: "USING_OVM",
+	}
+	RollupTuringCreditAddressFlag = cli.StringFlag{
+		Name:   "rollup.turingcreditaddress",
+		Usage:  "Address of the Turing credit contract",
+		EnvVar: "TURING_CREDIT_ADDRESS",
+	}
+	RollupBobaGasPriceOracleAddressFlag = cli.StringFlag{
+		Name:   "rollup.bobagaspriceoracleaddress",
+		Usage:  "Address of the Boba gas price oracle",
+		EnvVar: "BOBA_GAS_PRICE_ORACLE_ADDRESS",
+	}
+	RollupL2BobaTokenAddressFlag = cli.StringFlag{
+		Name:   "rollup.l2bobatokenaddress",
+		Usage:  "Address of the L2 Boba token",
+		EnvVar: "L2_BOBA_TOKEN_ADDRESS",
+	}
+	TuringAllowedHostsFlag = cli.StringFlag{
+		Name:   "turing.allowedhosts",
+		Usage:  "Comma separated hosts that Turing requests may be sent to, a leading dot matches subdomains, all hosts if empty",
+		EnvVar: "TURING_ALLOWED_HOSTS",
+	}
+	TuringDeniedHostsFlag = cli.StringFlag{
+		Name:   "turing.deniedhosts",
+		Usage:  "Comma separated hosts that Turing requests may not be sent to, a leading dot matches subdomains",
+		EnvVar: "TURING_DENIED_HOSTS",
+	}
+	TuringTimeoutFlag = cli.DurationFlag{
+		Name:   "turing.timeout",
+		Usage:  "Timeout of a Turing request",
+		Value:  vm.DefaultTuringConfig.Timeout,
+		EnvVar: "TURING_TIMEOUT",
+	}
+	TuringMaxRawResponseLengthFlag = cli.IntFlag{
+		Name:   "turing.maxrawresponselength",
+		Usage:  "Maximum length of the hex encoded response to a Turing request",
+		Value:  vm.DefaultTuringConfig.MaxRawResponseLength,
+		EnvVar: "TURING_MAX_RAW_RESPONSE_LENGTH",
+	}
+	TuringMaxResponseLengthFlag = cli.IntFlag{
+		Name:   "turing.maxresponselength",
+		Usage:  "Maximum length in bytes of the decoded response to a Turing request",
+		Value:  vm.DefaultTuringConfig.MaxResponseLength,
+		EnvVar: "TURING_MAX_RESPONSE_LENGTH",
+	}
+	TuringMaxConcurrentCallsFlag = cli.IntFlag{
+		Name:   "turing.maxconcurrentcalls",
+		Usage:  "Maximum number of Turing requests in flight, unlimited if zero",
+		EnvVar: "TURING_MAX_CONCURRENT_CALLS",
+	}
+	TuringRandomnessFlag = cli.StringFlag{
+		Name:   "turing.randomness",
+		Usage:  `Provider of Turing random numbers ("crypto", "seeded" for deterministic tests or "verifiable")`,
+		Value:  "crypto",
+		EnvVar: "TURING_RANDOMNESS",
+	}
+	TuringRandomSeedFlag = cli.StringFlag{
+		Name:   "turing.randomseed",
+		Usage:  "Hex encoded seed of the seeded randomness provider",
+		EnvVar: "TURING_RANDOM_SEED",
+	}
+	TuringRandomKeyFlag = cli.StringFlag{
+		Name:   "turing.randomkey",
+		Usage:  "Hex encoded P-256 private key file of the verifiable randomness provider",
+		EnvVar: "TURING_RANDOM_KEY",
+	}
 	SequencerClientHttpFlag = cli.StringFlag{
 		Name:   "sequencer.clienthttp",
 		Usage:  "HTTP endpoint for the sequencer client",
 		EnvVar: "SEQUENCER_CLIENT_HTTP",
 	}
+	SequencerClientWsFlag = cli.StringFlag{
+		Name:   "sequencer.clientws",
+		Usage:  "WebSocket endpoint of the sequencer, replicas subscribe to the sequencer transactions when set",
+		EnvVar: "SEQUENCER_CLIENT_WS",
+	}
 )
 
 // MakeDataDir retrieves the currently requested data directory, terminating
@@ -1146,9 +1338,142 @@ func setRollup(ctx *cli.Context, cfg *rollup.Config) {
 		val := ctx.GlobalFloat64(RollupFeeThresholdUpFlag.Name)
 		cfg.FeeThresholdUp = new(big.Float).SetFloat64(val)
 	}
+	if ctx.GlobalIsSet(RollupMaxReorgDepthFlag.Name) {
+		cfg.MaxReorgDepth = ctx.GlobalUint64(RollupMaxReorgDepthFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupReorgDryRunFlag.Name) {
+		cfg.ReorgDryRun = ctx.GlobalBool(RollupReorgDryRunFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupClientFlag.Name) {
+		val := ctx.GlobalString(RollupClientFlag.Name)
+		clientType, err := rollup.NewClientType(val)
+		if err != nil {
+			log.Error("Configured with unknown rollup client, defaulting to dtl", "client", val)
+			clientType, _ = rollup.NewClientType("dtl")
+		}
+		cfg.ClientType = clientType
+	}
+	if ctx.GlobalIsSet(RollupL1RpcHttpFlag.Name) {
+		cfg.L1RpcHttp = ctx.GlobalString(RollupL1RpcHttpFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupL1CanonicalTransactionChainAddressFlag.Name) {
+		addr := ctx.GlobalString(RollupL1CanonicalTransactionChainAddressFlag.Name)
+		cfg.L1CanonicalTransactionChainAddress = common.HexToAddress(addr)
+	}
+	if ctx.GlobalIsSet(RollupL1ConfirmationsFlag.Name) {
+		cfg.L1Confirmations = ctx.GlobalUint64(RollupL1ConfirmationsFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupTuringV1HeightFlag.Name) {
+		cfg.TuringV1Height = ctx.GlobalUint64(RollupTuringV1HeightFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupStateDivergenceCheckFlag.Name) {
+		cfg.StateDivergenceCheck = ctx.GlobalBool(RollupStateDivergenceCheckFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHaltOnStateDivergenceFlag.Name) {
+		cfg.HaltOnStateDivergence = ctx.GlobalBool(RollupHaltOnStateDivergenceFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupSyncConcurrencyFlag.Name) {
+		cfg.SyncConcurrency = ctx.GlobalInt(RollupSyncConcurrencyFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHealthAddrFlag.Name) {
+		cfg.HealthAddr = ctx.GlobalString(RollupHealthAddrFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHealthMaxIndexLagFlag.Name) {
+		cfg.HealthMaxIndexLag = ctx.GlobalUint64(RollupHealthMaxIndexLagFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHealthMaxQueueLagFlag.Name) {
+		cfg.HealthMaxQueueLag = ctx.GlobalUint64(RollupHealthMaxQueueLagFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHealthMaxL1ContextAgeFlag.Name) {
+		cfg.HealthMaxL1ContextAge = ctx.GlobalDuration(RollupHealthMaxL1ContextAgeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupHealthMaxTxAgeFlag.Name) {
+		cfg.HealthMaxTxAge = ctx.GlobalDuration(RollupHealthMaxTxAgeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupSenderTxRateFlag.Name) {
+		cfg.SenderTxRate = ctx.GlobalFloat64(RollupSenderTxRateFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupSenderTxBurstFlag.Name) {
+		cfg.SenderTxBurst = ctx.GlobalInt(RollupSenderTxBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupIPTxRateFlag.Name) {
+		cfg.IPTxRate = ctx.GlobalFloat64(RollupIPTxRateFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupIPTxBurstFlag.Name) {
+		cfg.IPTxBurst = ctx.GlobalInt(RollupIPTxBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupIngressQueueSizeFlag.Name) {
+		cfg.IngressQueueSize = ctx.GlobalInt(RollupIngressQueueSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupMaxNonceGapFlag.Name) {
+		cfg.MaxNonceGap = ctx.GlobalUint64(RollupMaxNonceGapFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupUsingOVMFlag.Name) {
+		cfg.UsingOVM = ctx.GlobalBool(RollupUsingOVMFlag.Name)
+	}
+	if ctx.GlobalIsSet(RollupTuringCreditAddressFlag.Name) {
+		addr := ctx.GlobalString(RollupTuringCreditAddressFlag.Name)
+		cfg.TuringCreditAddress = common.HexToAddress(addr)
+	}
+	if ctx.GlobalIsSet(RollupBobaGasPriceOracleAddressFlag.Name) {
+		addr := ctx.GlobalString(RollupBobaGasPriceOracleAddressFlag.Name)
+		cfg.BobaGasPriceOracleAddress = common.HexToAddress(addr)
+	}
+	if ctx.GlobalIsSet(RollupL2BobaTokenAddressFlag.Name) {
+		addr := ctx.GlobalString(RollupL2BobaTokenAddressFlag.Name)
+		cfg.L2BobaTokenAddress = common.HexToAddress(addr)
+	}
 	if ctx.GlobalIsSet(SequencerClientHttpFlag.Name) {
 		cfg.SequencerClientHttp = ctx.GlobalString(SequencerClientHttpFlag.Name)
 	}
+	if ctx.GlobalIsSet(SequencerClientWsFlag.Name) {
+		cfg.SequencerClientWs = ctx.GlobalString(SequencerClientWsFlag.Name)
+	}
+}
+
+// setTuring configures the off-chain requests of Turing
+func setTuring(ctx *cli.Context) {
+	cfg := vm.DefaultTuringConfig
+	if ctx.GlobalIsSet(TuringAllowedHostsFlag.Name) {
+		cfg.AllowedHosts = splitAndTrim(ctx.GlobalString(TuringAllowedHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(TuringDeniedHostsFlag.Name) {
+		cfg.DeniedHosts = splitAndTrim(ctx.GlobalString(TuringDeniedHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(TuringTimeoutFlag.Name) {
+		cfg.Timeout = ctx.GlobalDuration(TuringTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(TuringMaxRawResponseLengthFlag.Name) {
+		cfg.MaxRawResponseLength = ctx.GlobalInt(TuringMaxRawResponseLengthFlag.Name)
+	}
+	if ctx.GlobalIsSet(TuringMaxResponseLengthFlag.Name) {
+		cfg.MaxResponseLength = ctx.GlobalInt(TuringMaxResponseLengthFlag.Name)
+	}
+	if ctx.GlobalIsSet(TuringMaxConcurrentCallsFlag.Name) {
+		cfg.MaxConcurrentCalls = ctx.GlobalInt(TuringMaxConcurrentCallsFlag.Name)
+	}
+	switch randomness := ctx.GlobalString(TuringRandomnessFlag.Name); randomness {
+	case "", "crypto":
+		cfg.Randomness = vm.CryptoRandomnessProvider{}
+	case "seeded":
+		seed := common.FromHex(ctx.GlobalString(TuringRandomSeedFlag.Name))
+		if len(seed) == 0 {
+			Fatalf("Option %q: seed required by the seeded randomness provider", TuringRandomSeedFlag.Name)
+		}
+		log.Warn("Using deterministic Turing random numbers")
+		cfg.Randomness = vm.NewSeededRandomnessProvider(seed)
+	case "verifiable":
+		key, err := ecvrf.LoadPrivateKey(ctx.GlobalString(TuringRandomKeyFlag.Name))
+		if err != nil {
+			Fatalf("Option %q: %v", TuringRandomKeyFlag.Name, err)
+		}
+		provider := vm.NewVerifiableRandomnessProvider(key)
+		log.Info("Using verifiable Turing random numbers", "publicKey", hexutil.Encode(provider.PublicKey()))
+		cfg.Randomness = provider
+	default:
+		Fatalf("Option %q: unknown randomness provider %q", TuringRandomnessFlag.Name, randomness)
+	}
+	vm.SetTuringConfig(cfg)
 }
 
 // setLes configures the les server and ultra light client settings from the command line flags.
@@ -1613,6 +1938,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	setLes(ctx, cfg)
 	setEth1(ctx, &cfg.Rollup)
 	setRollup(ctx, &cfg.Rollup)
+	setTuring(ctx)
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
@@ -38,7 +38,6 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rlp"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 	"github.com/ethereum-optimism/optimism/l2geth/rpc"
 	lru "github.com/hashicorp/golang-lru"
 	"golang.org/x/crypto/sha3"
@@ -249,7 +248,7 @@ func (c *Clique) verifyHeader(chain consensus.ChainReader, header *types.Header,
 	}
 	number := header.Number.Uint64()
 
-	if !rcfg.UsingOVM {
+	if !chain.Config().UsingOVM() {
 		// Don't waste time checking blocks from the future
 		if header.Time > uint64(time.Now().Unix()) {
 			return consensus.ErrFutureBlock
@@ -328,7 +327,7 @@ func (c *Clique) verifyCascadingFields(chain consensus.ChainReader, header *type
 	// Do not account for timestamps in consensus when running the OVM
 	// changes. The timestamp must be montonic, meaning that it can be the same
 	// or increase. L1 dictates the timestamp.
-	if !rcfg.UsingOVM {
+	if !chain.Config().UsingOVM() {
 		if parent.Time+c.config.Period > header.Time {
 			return ErrInvalidTimestamp
 		}
@@ -555,7 +554,7 @@ func (c *Clique) Prepare(chain consensus.ChainReader, header *types.Header) erro
 	}
 
 	// Do not manipulate the timestamps when running with the OVM
-	if !rcfg.UsingOVM {
+	if !chain.Config().UsingOVM() {
 		header.Time = parent.Time + c.config.Period
 		if header.Time < uint64(time.Now().Unix()) {
 			header.Time = uint64(time.Now().Unix())
@@ -643,7 +642,7 @@ func (c *Clique) Seal(chain consensus.ChainReader, block *types.Block, results c
 	// Set the delay to 0 when using the OVM so that blocks are always
 	// produced instantly. When running in a non-OVM network, the delay prevents
 	// the creation of invalid blocks.
-	if rcfg.UsingOVM {
+	if chain.Config().UsingOVM() {
 		delay = 0
 	}
 	// Sign all the things!
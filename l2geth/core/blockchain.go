@@ -436,6 +436,16 @@ func (bc *BlockChain) SetHead(head uint64) error {
 
 	// Rewind the header chain, deleting all block bodies until then
 	delFn := func(db ethdb.KeyValueWriter, hash common.Hash, num uint64) {
+		// Remove the rollup records of the block, which are kept in the
+		// active store even for frozen blocks
+		if body := rawdb.ReadBody(bc.db, hash, num); body != nil {
+			for _, tx := range body.Transactions {
+				rawdb.DeleteTuringRecords(db, tx.Hash())
+			}
+		}
+		rawdb.DeleteFeeRecord(db, hash, num)
+		rawdb.DeleteTuringCharges(db, hash, num)
+
 		// Ignore the error here since light client won't hit this path
 		frozen, _ := bc.db.Ancients()
 		if num+1 <= frozen {
@@ -529,7 +539,12 @@ func (bc *BlockChain) State() (*state.StateDB, error) {
 
 // StateAt returns a new mutable state based on a particular point in time.
 func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
-	return state.New(root, bc.stateCache)
+	statedb, err := state.New(root, bc.stateCache)
+	if err != nil {
+		return nil, err
+	}
+	statedb.SetOVMConfig(bc.chainConfig.OVMConfig())
+	return statedb, nil
 }
 
 // StateCache returns the caching database underpinning the blockchain instance.
@@ -1334,6 +1349,30 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 		rawdb.WriteTransactionMeta(blockBatch, block.NumberU64(), tx.GetMeta())
 	}
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	var (
+		fees          = types.NewFeeRecord()
+		turingCharges []*types.TuringCharge
+	)
+	for _, receipt := range receipts {
+		if len(receipt.Turing) > 1 {
+			rawdb.WriteTuringRecords(blockBatch, receipt.TxHash, []*types.TuringRecord{
+				{Request: receipt.TuringRequest, Response: receipt.Turing, Proof: receipt.TuringProof},
+			})
+		}
+		if receipt.Fees != nil {
+			fees.Add(receipt.Fees)
+			if receipt.Fees.TuringFee.Sign() > 0 {
+				turingCharges = append(turingCharges, &types.TuringCharge{
+					Helper: receipt.TuringHelper,
+					Credit: new(big.Int).Set(receipt.Fees.TuringFee),
+				})
+			}
+		}
+	}
+	rawdb.WriteFeeRecord(blockBatch, block.Hash(), block.NumberU64(), fees)
+	if len(turingCharges) > 0 {
+		rawdb.WriteTuringCharges(blockBatch, block.Hash(), block.NumberU64(), turingCharges)
+	}
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
@@ -1659,7 +1698,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		if parent == nil {
 			parent = bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
 		}
-		statedb, err := state.New(parent.Root, bc.stateCache)
+		statedb, err := bc.StateAt(parent.Root)
 		if err != nil {
 			return it.index, err
 		}
@@ -1670,7 +1709,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		if !bc.cacheConfig.TrieCleanNoPrefetch {
 			if followup, err := it.peek(); followup != nil && err == nil {
 				go func(start time.Time) {
-					throwaway, _ := state.New(parent.Root, bc.stateCache)
+					throwaway, _ := bc.StateAt(parent.Root)
 					bc.prefetcher.Prefetch(followup, throwaway, bc.vmConfig, &followupInterrupt)
 
 					blockPrefetchExecuteTimer.Update(time.Since(start))
@@ -2038,9 +2077,13 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 	}
 	// Delete useless indexes right now which includes the non-canonical
 	// transaction indexes, canonical chain indexes which above the head.
+	// The Turing records of dropped transactions are keyed by transaction
+	// hash like the indexes, while the fee records of the old blocks are kept
+	// with their receipts.
 	indexesBatch := bc.db.NewBatch()
 	for _, tx := range types.TxDifference(deletedTxs, addedTxs) {
 		rawdb.DeleteTxLookupEntry(indexesBatch, tx.Hash())
+		rawdb.DeleteTuringRecords(indexesBatch, tx.Hash())
 	}
 	// Delete any canonical number assignments above the new head
 	number := bc.CurrentBlock().NumberU64()
@@ -2361,3 +2361,119 @@ func TestDeleteCreateRevert(t *testing.T) {
 		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
 	}
 }
+
+// Tests that the fees collected in a block are recorded when it is inserted.
+func TestFeeRecords(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000)}}}
+		genesis = gspec.MustCommit(db)
+		signer  = types.NewEIP155Signer(gspec.Config.ChainID)
+	)
+	blockchain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 2, func(i int, gen *BlockGen) {
+		if i == 1 {
+			for j := 0; j < 2; j++ {
+				tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(2), nil), signer, key1)
+				if err != nil {
+					t.Fatalf("failed to create tx: %v", err)
+				}
+				gen.AddTx(tx)
+			}
+		}
+	})
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if record := rawdb.ReadFeeRecord(db, genesis.Hash(), 0); record != nil {
+		t.Fatal("Fee record found for the genesis block")
+	}
+	if record := rawdb.ReadFeeRecord(db, chain[0].Hash(), 1); record == nil || record.L2Fee.Sign() != 0 {
+		t.Fatalf("Unexpected fee record of empty block: %v", record)
+	}
+	record := rawdb.ReadFeeRecord(db, chain[1].Hash(), 2)
+	if record == nil {
+		t.Fatal("Fee record not found")
+	}
+	if want := big.NewInt(2 * 2 * int64(params.TxGas)); record.L2Fee.Cmp(want) != 0 {
+		t.Fatalf("Unexpected L2 fee: have %d, want %d", record.L2Fee, want)
+	}
+	if record.L1Fee.Sign() != 0 || record.BobaFee.Sign() != 0 || record.TuringFee.Sign() != 0 || record.Uncollected.Sign() != 0 {
+		t.Fatalf("Unexpected fees: %+v", record)
+	}
+}
+
+// Tests that the rollup records of blocks are removed when the head is set
+// back, and that the Turing records of transactions dropped by a reorg are
+// removed.
+func TestRollupRecordsRemoved(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000)}}}
+		genesis = gspec.MustCommit(db)
+		signer  = types.NewEIP155Signer(gspec.Config.ChainID)
+		records = []*types.TuringRecord{{Request: []byte{0x01}, Response: []byte{0x02}}}
+		charges = []*types.TuringCharge{{Helper: common.Address{0x02}, Credit: big.NewInt(1)}}
+	)
+	blockchain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	generate := func(n int, withTx bool) []*types.Block {
+		chain, _ := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, n, func(i int, gen *BlockGen) {
+			if withTx {
+				tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(2), nil), signer, key1)
+				if err != nil {
+					t.Fatalf("failed to create tx: %v", err)
+				}
+				gen.AddTx(tx)
+			} else {
+				gen.SetCoinbase(common.Address{0x03})
+			}
+		})
+		return chain
+	}
+	// The Turing records of a transaction are removed with its block
+	chain := generate(2, true)
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, block := range chain {
+		rawdb.WriteTuringRecords(db, block.Transactions()[0].Hash(), records)
+		rawdb.WriteTuringCharges(db, block.Hash(), block.NumberU64(), charges)
+	}
+	blockchain.SetHead(1)
+	if rawdb.ReadFeeRecord(db, chain[1].Hash(), 2) != nil || rawdb.ReadTuringCharges(db, chain[1].Hash(), 2) != nil {
+		t.Fatal("Fee records of a removed block were kept")
+	}
+	if rawdb.ReadTuringRecords(db, chain[1].Transactions()[0].Hash()) != nil {
+		t.Fatal("Turing records of a removed transaction were kept")
+	}
+	if rawdb.ReadFeeRecord(db, chain[0].Hash(), 1) == nil || rawdb.ReadTuringCharges(db, chain[0].Hash(), 1) == nil {
+		t.Fatal("Fee records of a canonical block were removed")
+	}
+	if rawdb.ReadTuringRecords(db, chain[0].Transactions()[0].Hash()) == nil {
+		t.Fatal("Turing records of a canonical transaction were removed")
+	}
+
+	// A longer fork without the transaction drops its Turing records, while
+	// the fee records stay with the old block
+	fork := generate(3, false)
+	if _, err := blockchain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if blockchain.CurrentBlock().Hash() != fork[2].Hash() {
+		t.Fatal("Fork did not become canonical")
+	}
+	if rawdb.ReadTuringRecords(db, chain[0].Transactions()[0].Hash()) != nil {
+		t.Fatal("Turing records of a dropped transaction were kept")
+	}
+	if rawdb.ReadFeeRecord(db, chain[0].Hash(), 1) == nil {
+		t.Fatal("Fee record of a block on the old chain was removed")
+	}
+}
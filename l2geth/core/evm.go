@@ -23,8 +23,8 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/consensus"
 	"github.com/ethereum-optimism/optimism/l2geth/core/types"
 	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 // ChainContext supports retrieving headers and consensus parameters from the
@@ -37,8 +37,9 @@ type ChainContext interface {
 	GetHeader(common.Hash, uint64) *types.Header
 }
 
-// NewEVMContext creates a new context for use in the EVM.
-func NewEVMContext(msg Message, header *types.Header, chain ChainContext, author *common.Address) vm.Context {
+// NewEVMContext creates a new context for use in the EVM. The OVM settings of
+// the chain config determine the context of OVM transactions.
+func NewEVMContext(msg Message, header *types.Header, chain ChainContext, config *params.ChainConfig, author *common.Address) vm.Context {
 	// If we don't have an explicit author (i.e. not mining), extract from the header
 	var beneficiary common.Address
 	if author == nil {
@@ -46,7 +47,7 @@ func NewEVMContext(msg Message, header *types.Header, chain ChainContext, author
 	} else {
 		beneficiary = *author
 	}
-	if rcfg.UsingOVM {
+	if config.UsingOVM() {
 		// When using the OVM, we must:
 		// - Set the Time to be the msg.L1Timestamp
 		// - Set Turing to be msg.L1Turing
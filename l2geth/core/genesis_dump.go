@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+)
+
+// ovmDumpCommitInterval is the number of accounts and storage slots that are
+// written to the state of a regenesis between commits to the database.
+var ovmDumpCommitInterval = 100000
+
+// CommitOvmDump writes the genesis block of a regenesis, whose state holds
+// the genesis allocation along with the accounts of a regenesis dump, and
+// returns it with the number of accounts in the dump. The accounts are
+// written into the state as they are read and the state is committed to the
+// database periodically, so that neither has to be held in memory. The
+// accounts of the dump are written as they were exported, with their native
+// balances, while the balances of the allocation follow the OVM config of
+// the chain.
+func (g *Genesis) CommitOvmDump(db ethdb.Database, r io.Reader) (*types.Block, int, error) {
+	if stored := rawdb.ReadCanonicalHash(db, 0); stored != (common.Hash{}) {
+		return nil, 0, fmt.Errorf("database already contains genesis block %x", stored)
+	}
+	w, err := newOvmDumpWriter(state.NewDatabase(db))
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := dump.NewDecoder(r).Decode(w); err != nil {
+		return nil, 0, err
+	}
+	// The allocation is added last since its balances may be written to the
+	// storage of OVM_ETH from the dump
+	w.statedb.SetOVMConfig(g.Config.OVMConfig())
+	for addr, account := range g.Alloc {
+		if w.statedb.Exist(addr) {
+			return nil, 0, fmt.Errorf("account %s is both in the genesis allocation and the dump", addr.Hex())
+		}
+		w.statedb.AddBalance(addr, account.Balance)
+		w.statedb.SetCode(addr, account.Code)
+		w.statedb.SetNonce(addr, account.Nonce)
+		for key, value := range account.Storage {
+			w.statedb.SetState(addr, key, value)
+		}
+	}
+	root, err := w.commit()
+	if err != nil {
+		return nil, 0, err
+	}
+	block := types.NewBlock(g.header(root), nil, nil, nil)
+	if err := g.write(db, block); err != nil {
+		return nil, 0, err
+	}
+	return block, w.accounts, nil
+}
+
+// ovmDumpWriter writes the accounts streamed from a regenesis dump into a
+// state, which is committed to the database every ovmDumpCommitInterval
+// writes. The OVM is disabled on the state so that balances are written to
+// the accounts themselves.
+type ovmDumpWriter struct {
+	db       state.Database
+	statedb  *state.StateDB
+	addr     common.Address
+	accounts int
+	pending  int
+}
+
+func newOvmDumpWriter(db state.Database) (*ovmDumpWriter, error) {
+	w := &ovmDumpWriter{db: db}
+	if err := w.open(common.Hash{}); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *ovmDumpWriter) BeginAccount(addr common.Address) error {
+	if w.statedb.Exist(addr) {
+		return fmt.Errorf("duplicate account %s", addr.Hex())
+	}
+	w.statedb.CreateAccount(addr)
+	w.addr = addr
+	w.accounts++
+	return w.written()
+}
+
+func (w *ovmDumpWriter) Storage(key common.Hash, value string) error {
+	if slot := common.HexToHash(value); slot != (common.Hash{}) {
+		w.statedb.SetState(w.addr, key, slot)
+	}
+	return w.written()
+}
+
+func (w *ovmDumpWriter) EndAccount(account *dump.OvmDumpAccount) error {
+	var code []byte
+	if account.Code != "" {
+		var err error
+		if code, err = hexutil.Decode(account.Code); err != nil {
+			return fmt.Errorf("invalid code for account %s: %w", account.Address.Hex(), err)
+		}
+	}
+	if account.CodeHash != "" {
+		if hash := crypto.Keccak256Hash(code); hash != common.HexToHash(account.CodeHash) {
+			return fmt.Errorf("code hash mismatch for account %s: have %s, want %s", account.Address.Hex(), hash.Hex(), account.CodeHash)
+		}
+	}
+	w.statedb.SetCode(w.addr, code)
+	w.statedb.SetNonce(w.addr, account.Nonce)
+	if account.Balance != nil {
+		w.statedb.SetBalance(w.addr, account.Balance.ToInt())
+	}
+	return w.written()
+}
+
+// written counts a write to the state and commits the state once enough
+// writes are pending.
+func (w *ovmDumpWriter) written() error {
+	w.pending++
+	if w.pending < ovmDumpCommitInterval {
+		return nil
+	}
+	_, err := w.commit()
+	return err
+}
+
+// commit writes the pending changes to the database and reopens the state
+// on top of them, which releases the memory held by the changes.
+func (w *ovmDumpWriter) commit() (common.Hash, error) {
+	root, err := w.statedb.Commit(false)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := w.db.TrieDB().Commit(root, false); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Committed regenesis state", "accounts", w.accounts, "root", root)
+	return root, w.open(root)
+}
+
+// open opens the state at root to write the following accounts into.
+func (w *ovmDumpWriter) open(root common.Hash) error {
+	statedb, err := state.New(root, w.db)
+	if err != nil {
+		return err
+	}
+	statedb.SetOVMConfig(&rcfg.Config{})
+	w.statedb = statedb
+	w.pending = 0
+	return nil
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
+)
+
+func TestOvmDumpRoundTrip(t *testing.T) {
+	// Commit within accounts to check that the state is carried over
+	defer func(interval int) { ovmDumpCommitInterval = interval }(ovmDumpCommitInterval)
+	ovmDumpCommitInterval = 2
+
+	var (
+		contract  = common.HexToAddress("0x4200000000000000000000000000000000000010")
+		excluded  = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		predeploy = common.HexToAddress("0x4200000000000000000000000000000000000042")
+		zero      = new(big.Int)
+	)
+	genesis := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc: GenesisAlloc{
+			contract: {
+				Code:    common.FromHex("0x6001600055"),
+				Storage: map[common.Hash]common.Hash{{1}: {2}, {3}: {4}},
+				Balance: zero,
+				Nonce:   1,
+			},
+			excluded:            {Code: []byte{0x1}, Balance: zero},
+			dump.OvmEthAddress:  {Storage: map[common.Hash]common.Hash{{5}: {6}}, Balance: zero},
+			common.Address{0xa}: {Balance: zero, Nonce: 7},
+			common.Address{0xb}: {Balance: big.NewInt(1e18)},
+		},
+	}
+	db := rawdb.NewMemoryDatabase()
+	block := genesis.MustCommit(db)
+	statedb, err := state.New(block.Root(), state.NewDatabase(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Export the state unchanged and rebuild the genesis from it
+	buf := new(bytes.Buffer)
+	enc := dump.NewEncoder(buf)
+	if err := statedb.OvmDump(enc); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if enc.Count() != len(genesis.Alloc) {
+		t.Fatalf("Unexpected number of accounts: %d", enc.Count())
+	}
+	regenesis := &Genesis{Config: params.TestChainConfig}
+	reblock, accounts, err := regenesis.CommitOvmDump(rawdb.NewMemoryDatabase(), buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts != len(genesis.Alloc) {
+		t.Fatalf("Unexpected number of imported accounts: %d", accounts)
+	}
+	if reblock.Root() != block.Root() {
+		t.Fatalf("State root mismatch: have %x, want %x", reblock.Root(), block.Root())
+	}
+
+	// Export again while dropping one account and replacing a predeploy
+	code := hexutil.Encode([]byte{0x60, 0x00})
+	transform, err := dump.NewTransform([]common.Address{excluded}, []*dump.OvmDumpAccount{
+		{Address: contract, Code: code, Storage: map[common.Hash]string{{3}: common.Hash{9}.Hex()}},
+		{Address: predeploy, Code: code},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	enc = dump.NewEncoder(buf)
+	if err := statedb.OvmDump(transform.Writer(enc)); err != nil {
+		t.Fatal(err)
+	}
+	remaining := transform.Remaining()
+	for _, account := range remaining {
+		if err := enc.Encode(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	redb := rawdb.NewMemoryDatabase()
+	if reblock, _, err = regenesis.CommitOvmDump(redb, buf); err != nil {
+		t.Fatal(err)
+	}
+	if rawdb.ReadCanonicalHash(redb, 0) != reblock.Hash() {
+		t.Fatal("Genesis block not written")
+	}
+	restate, err := state.New(reblock.Root(), state.NewDatabase(redb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restate.Exist(excluded) {
+		t.Fatal("Excluded account was exported")
+	}
+	if !bytes.Equal(restate.GetCode(contract), []byte{0x60, 0x00}) || restate.GetNonce(contract) != 1 {
+		t.Fatalf("Unexpected overridden account: code %x, nonce %d", restate.GetCode(contract), restate.GetNonce(contract))
+	}
+	if restate.GetState(contract, common.Hash{1}) != (common.Hash{2}) || restate.GetState(contract, common.Hash{3}) != (common.Hash{9}) {
+		t.Fatal("Unexpected overridden storage")
+	}
+	if balance := restate.GetBalance(common.Address{0xb}); balance.Cmp(big.NewInt(1e18)) != 0 {
+		t.Fatalf("Unexpected balance of funded account: %v", balance)
+	}
+	if !bytes.Equal(restate.GetCode(predeploy), []byte{0x60, 0x00}) {
+		t.Fatalf("Missing added predeploy: %x", restate.GetCode(predeploy))
+	}
+}
+
+func TestOvmDumpImportErrors(t *testing.T) {
+	encode := func(accounts ...*dump.OvmDumpAccount) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		enc := dump.NewEncoder(buf)
+		for _, account := range accounts {
+			if err := enc.Encode(account); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+	account := &dump.OvmDumpAccount{
+		Address:  common.Address{0x1},
+		Code:     "0x6000",
+		CodeHash: common.Hash{}.Hex(),
+	}
+	genesis := &Genesis{Config: params.TestChainConfig}
+	if _, _, err := genesis.CommitOvmDump(rawdb.NewMemoryDatabase(), encode(account)); err == nil {
+		t.Fatal("Expected code hash mismatch")
+	}
+	account.CodeHash = ""
+	if _, _, err := genesis.CommitOvmDump(rawdb.NewMemoryDatabase(), encode(account, account)); err == nil {
+		t.Fatal("Expected duplicate account")
+	}
+	genesis.Alloc = GenesisAlloc{account.Address: {Balance: new(big.Int)}}
+	if _, _, err := genesis.CommitOvmDump(rawdb.NewMemoryDatabase(), encode(account)); err == nil {
+		t.Fatal("Expected account in both the allocation and the dump")
+	}
+	genesis.Alloc = nil
+	db := rawdb.NewMemoryDatabase()
+	if _, _, err := genesis.CommitOvmDump(db, encode(account)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := genesis.CommitOvmDump(db, encode(account)); err == nil {
+		t.Fatal("Expected existing genesis block")
+	}
+}
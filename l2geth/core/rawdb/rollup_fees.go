@@ -0,0 +1,41 @@
+package rawdb
+
+import (
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+)
+
+// ReadFeeRecord will read the fees collected in a block
+func ReadFeeRecord(db ethdb.KeyValueReader, hash common.Hash, number uint64) *types.FeeRecord {
+	data, _ := db.Get(feeRecordKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	record := new(types.FeeRecord)
+	if err := rlp.DecodeBytes(data, record); err != nil {
+		log.Error("Invalid fee record RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return record
+}
+
+// WriteFeeRecord will write the fees collected in a block
+func WriteFeeRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64, record *types.FeeRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to RLP encode fee record", "err", err)
+	}
+	if err := db.Put(feeRecordKey(number, hash), data); err != nil {
+		log.Crit("Failed to store fee record", "err", err)
+	}
+}
+
+// DeleteFeeRecord will remove the fees collected in a block
+func DeleteFeeRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(feeRecordKey(number, hash)); err != nil {
+		log.Crit("Failed to delete fee record", "err", err)
+	}
+}
@@ -0,0 +1,48 @@
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+func TestReadWriteFeeRecord(t *testing.T) {
+	db := NewMemoryDatabase()
+	hash := common.Hash{0x01}
+	if record := ReadFeeRecord(db, hash, 1); record != nil {
+		t.Fatal("Fee record found in empty database")
+	}
+
+	record := types.NewFeeRecord()
+	record.L1Fee.SetUint64(1)
+	record.L2Fee.SetUint64(2)
+	record.BobaFee.SetUint64(3)
+	record.TuringFee.SetUint64(4)
+	record.Uncollected.SetUint64(5)
+	WriteFeeRecord(db, hash, 1, record)
+	if ReadFeeRecord(db, hash, 2) != nil || ReadFeeRecord(db, common.Hash{0x02}, 1) != nil {
+		t.Fatal("Fee record found for another block")
+	}
+	got := ReadFeeRecord(db, hash, 1)
+	if got == nil {
+		t.Fatal("Fee record not found")
+	}
+	for i, pair := range [][2]*big.Int{
+		{got.L1Fee, record.L1Fee},
+		{got.L2Fee, record.L2Fee},
+		{got.BobaFee, record.BobaFee},
+		{got.TuringFee, record.TuringFee},
+		{got.Uncollected, record.Uncollected},
+	} {
+		if pair[0].Cmp(pair[1]) != 0 {
+			t.Errorf("Fee %d mismatch: have %d, want %d", i, pair[0], pair[1])
+		}
+	}
+
+	DeleteFeeRecord(db, hash, 1)
+	if record := ReadFeeRecord(db, hash, 1); record != nil {
+		t.Fatal("Fee record not deleted")
+	}
+}
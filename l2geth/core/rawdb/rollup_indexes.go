@@ -28,6 +28,13 @@ func WriteHeadIndex(db ethdb.KeyValueWriter, index uint64) {
 	}
 }
 
+// DeleteHeadIndex will delete the known tip of the CTC
+func DeleteHeadIndex(db ethdb.KeyValueWriter) {
+	if err := db.Delete(headIndexKey); err != nil {
+		log.Crit("Failed to delete CTC tip", "err", err)
+	}
+}
+
 // ReadHeadQueueIndex will read the known tip of the queue
 func ReadHeadQueueIndex(db ethdb.KeyValueReader) *uint64 {
 	data, _ := db.Get(headQueueIndexKey)
@@ -49,6 +56,13 @@ func WriteHeadQueueIndex(db ethdb.KeyValueWriter, index uint64) {
 	}
 }
 
+// DeleteHeadQueueIndex will delete the known tip of the queue
+func DeleteHeadQueueIndex(db ethdb.KeyValueWriter) {
+	if err := db.Delete(headQueueIndexKey); err != nil {
+		log.Crit("Failed to delete queue tip", "err", err)
+	}
+}
+
 // ReadHeadVerifiedIndex will read the known tip of the batched transactions
 func ReadHeadVerifiedIndex(db ethdb.KeyValueReader) *uint64 {
 	data, _ := db.Get(headVerifiedIndexKey)
@@ -70,6 +84,13 @@ func WriteHeadVerifiedIndex(db ethdb.KeyValueWriter, index uint64) {
 	}
 }
 
+// DeleteHeadVerifiedIndex will delete the known tip of the batched transactions
+func DeleteHeadVerifiedIndex(db ethdb.KeyValueWriter) {
+	if err := db.Delete(headVerifiedIndexKey); err != nil {
+		log.Crit("Failed to delete batched transactions tip", "err", err)
+	}
+}
+
 // ReadHeadBatchIndex will read the known tip of the processed batches
 func ReadHeadBatchIndex(db ethdb.KeyValueReader) *uint64 {
 	data, _ := db.Get(headBatchKey)
@@ -90,3 +111,26 @@ func WriteHeadBatchIndex(db ethdb.KeyValueWriter, index uint64) {
 		log.Crit("Failed to store head batch index", "err", err)
 	}
 }
+
+// ReadHeadStateBatchIndex will read the known tip of the state root batches
+// checked for divergence
+func ReadHeadStateBatchIndex(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(headStateBatchKey)
+	if len(data) == 0 {
+		return nil
+	}
+	ret := new(big.Int).SetBytes(data).Uint64()
+	return &ret
+}
+
+// WriteHeadStateBatchIndex will write the known tip of the state root batches
+// checked for divergence
+func WriteHeadStateBatchIndex(db ethdb.KeyValueWriter, index uint64) {
+	value := new(big.Int).SetUint64(index).Bytes()
+	if index == 0 {
+		value = []byte{0}
+	}
+	if err := db.Put(headStateBatchKey, value); err != nil {
+		log.Crit("Failed to store head state batch index", "err", err)
+	}
+}
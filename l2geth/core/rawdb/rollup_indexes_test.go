@@ -39,3 +39,23 @@ func TestReadWriteHeadQueueIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteHeadIndices(t *testing.T) {
+	db := NewMemoryDatabase()
+	WriteHeadIndex(db, 1)
+	WriteHeadQueueIndex(db, 2)
+	WriteHeadVerifiedIndex(db, 3)
+
+	DeleteHeadIndex(db)
+	DeleteHeadQueueIndex(db)
+	DeleteHeadVerifiedIndex(db)
+	if ReadHeadIndex(db) != nil {
+		t.Fatal("Head index not deleted")
+	}
+	if ReadHeadQueueIndex(db) != nil {
+		t.Fatal("Head queue index not deleted")
+	}
+	if ReadHeadVerifiedIndex(db) != nil {
+		t.Fatal("Head verified index not deleted")
+	}
+}
@@ -0,0 +1,104 @@
+package rawdb
+
+import (
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+)
+
+// ReadL1IngestionProgress will read the RLP encoded progress of the L1
+// rollup client
+func ReadL1IngestionProgress(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(l1IngestionProgressKey)
+	return data
+}
+
+// WriteL1IngestionProgress will write the RLP encoded progress of the L1
+// rollup client
+func WriteL1IngestionProgress(db ethdb.KeyValueWriter, data []byte) {
+	if err := db.Put(l1IngestionProgressKey, data); err != nil {
+		log.Crit("Failed to store L1 ingestion progress", "err", err)
+	}
+}
+
+// ReadL1IngestionCheckpoints will read the RLP encoded recent checkpoints of
+// the L1 rollup client
+func ReadL1IngestionCheckpoints(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(l1IngestionCheckpointsKey)
+	return data
+}
+
+// WriteL1IngestionCheckpoints will write the RLP encoded recent checkpoints
+// of the L1 rollup client
+func WriteL1IngestionCheckpoints(db ethdb.KeyValueWriter, data []byte) {
+	if err := db.Put(l1IngestionCheckpointsKey, data); err != nil {
+		log.Crit("Failed to store L1 ingestion checkpoints", "err", err)
+	}
+}
+
+// ReadL1EnqueueRLP will read the RLP encoded enqueue ingested from L1 by
+// queue index
+func ReadL1EnqueueRLP(db ethdb.KeyValueReader, index uint64) []byte {
+	data, _ := db.Get(l1EnqueueKey(index))
+	return data
+}
+
+// WriteL1EnqueueRLP will write the RLP encoded enqueue ingested from L1 by
+// queue index
+func WriteL1EnqueueRLP(db ethdb.KeyValueWriter, index uint64, data []byte) {
+	if err := db.Put(l1EnqueueKey(index), data); err != nil {
+		log.Crit("Failed to store L1 enqueue", "err", err)
+	}
+}
+
+// DeleteL1EnqueueRLP will remove the enqueue ingested from L1 by queue index
+func DeleteL1EnqueueRLP(db ethdb.KeyValueWriter, index uint64) {
+	if err := db.Delete(l1EnqueueKey(index)); err != nil {
+		log.Crit("Failed to delete L1 enqueue", "err", err)
+	}
+}
+
+// ReadL1TransactionRLP will read the RLP encoded batched transaction ingested
+// from L1 by CTC index
+func ReadL1TransactionRLP(db ethdb.KeyValueReader, index uint64) []byte {
+	data, _ := db.Get(l1TransactionKey(index))
+	return data
+}
+
+// WriteL1TransactionRLP will write the RLP encoded batched transaction
+// ingested from L1 by CTC index
+func WriteL1TransactionRLP(db ethdb.KeyValueWriter, index uint64, data []byte) {
+	if err := db.Put(l1TransactionKey(index), data); err != nil {
+		log.Crit("Failed to store L1 transaction", "err", err)
+	}
+}
+
+// DeleteL1TransactionRLP will remove the batched transaction ingested from L1
+// by CTC index
+func DeleteL1TransactionRLP(db ethdb.KeyValueWriter, index uint64) {
+	if err := db.Delete(l1TransactionKey(index)); err != nil {
+		log.Crit("Failed to delete L1 transaction", "err", err)
+	}
+}
+
+// ReadL1BatchRLP will read the RLP encoded transaction batch ingested from L1
+// by batch index
+func ReadL1BatchRLP(db ethdb.KeyValueReader, index uint64) []byte {
+	data, _ := db.Get(l1BatchKey(index))
+	return data
+}
+
+// WriteL1BatchRLP will write the RLP encoded transaction batch ingested from
+// L1 by batch index
+func WriteL1BatchRLP(db ethdb.KeyValueWriter, index uint64, data []byte) {
+	if err := db.Put(l1BatchKey(index), data); err != nil {
+		log.Crit("Failed to store L1 transaction batch", "err", err)
+	}
+}
+
+// DeleteL1BatchRLP will remove the transaction batch ingested from L1 by batch
+// index
+func DeleteL1BatchRLP(db ethdb.KeyValueWriter, index uint64) {
+	if err := db.Delete(l1BatchKey(index)); err != nil {
+		log.Crit("Failed to delete L1 transaction batch", "err", err)
+	}
+}
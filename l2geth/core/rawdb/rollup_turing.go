@@ -0,0 +1,76 @@
+package rawdb
+
+import (
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+)
+
+// ReadTuringRecords will read the Turing requests and responses of a
+// transaction by hash
+func ReadTuringRecords(db ethdb.KeyValueReader, hash common.Hash) []*types.TuringRecord {
+	data, _ := db.Get(turingRecordsKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var records []*types.TuringRecord
+	if err := rlp.DecodeBytes(data, &records); err != nil {
+		log.Error("Invalid turing records RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return records
+}
+
+// WriteTuringRecords will write the Turing requests and responses of a
+// transaction by hash
+func WriteTuringRecords(db ethdb.KeyValueWriter, hash common.Hash, records []*types.TuringRecord) {
+	data, err := rlp.EncodeToBytes(records)
+	if err != nil {
+		log.Crit("Failed to RLP encode turing records", "err", err)
+	}
+	if err := db.Put(turingRecordsKey(hash), data); err != nil {
+		log.Crit("Failed to store turing records", "err", err)
+	}
+}
+
+// DeleteTuringRecords will remove the Turing requests and responses of a
+// transaction by hash
+func DeleteTuringRecords(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(turingRecordsKey(hash)); err != nil {
+		log.Crit("Failed to delete turing records", "err", err)
+	}
+}
+
+// ReadTuringCharges will read the Turing credits charged in a block
+func ReadTuringCharges(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.TuringCharge {
+	data, _ := db.Get(turingChargesKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var charges []*types.TuringCharge
+	if err := rlp.DecodeBytes(data, &charges); err != nil {
+		log.Error("Invalid Turing charges RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return charges
+}
+
+// WriteTuringCharges will write the Turing credits charged in a block
+func WriteTuringCharges(db ethdb.KeyValueWriter, hash common.Hash, number uint64, charges []*types.TuringCharge) {
+	data, err := rlp.EncodeToBytes(charges)
+	if err != nil {
+		log.Crit("Failed to RLP encode Turing charges", "err", err)
+	}
+	if err := db.Put(turingChargesKey(number, hash), data); err != nil {
+		log.Crit("Failed to store Turing charges", "err", err)
+	}
+}
+
+// DeleteTuringCharges will remove the Turing credits charged in a block
+func DeleteTuringCharges(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(turingChargesKey(number, hash)); err != nil {
+		log.Crit("Failed to delete Turing charges", "err", err)
+	}
+}
@@ -0,0 +1,66 @@
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+func TestReadWriteTuringRecords(t *testing.T) {
+	db := NewMemoryDatabase()
+	hash := common.Hash{0x01}
+	if records := ReadTuringRecords(db, hash); records != nil {
+		t.Fatal("Turing records found in empty database")
+	}
+
+	records := []*types.TuringRecord{
+		{Request: []byte{0x01, 0x02}, Response: []byte{0x03, 0x04}, Proof: []byte{0x05}},
+	}
+	WriteTuringRecords(db, hash, records)
+	got := ReadTuringRecords(db, hash)
+	if len(got) != 1 {
+		t.Fatalf("Unexpected number of turing records: %d", len(got))
+	}
+	if !bytes.Equal(got[0].Request, records[0].Request) || !bytes.Equal(got[0].Response, records[0].Response) || !bytes.Equal(got[0].Proof, records[0].Proof) {
+		t.Fatal("Turing record mismatch")
+	}
+
+	DeleteTuringRecords(db, hash)
+	if records := ReadTuringRecords(db, hash); records != nil {
+		t.Fatal("Turing records not deleted")
+	}
+}
+
+func TestReadWriteTuringCharges(t *testing.T) {
+	db := NewMemoryDatabase()
+	hash := common.Hash{0x01}
+	if charges := ReadTuringCharges(db, hash, 1); charges != nil {
+		t.Fatal("Turing charges found in empty database")
+	}
+
+	charges := []*types.TuringCharge{
+		{Helper: common.Address{0x0a}, Credit: big.NewInt(10)},
+		{Helper: common.Address{0x0b}, Credit: big.NewInt(20)},
+	}
+	WriteTuringCharges(db, hash, 1, charges)
+	if ReadTuringCharges(db, hash, 2) != nil || ReadTuringCharges(db, common.Hash{0x02}, 1) != nil {
+		t.Fatal("Turing charges found for another block")
+	}
+	got := ReadTuringCharges(db, hash, 1)
+	if len(got) != len(charges) {
+		t.Fatalf("Unexpected number of Turing charges: %d", len(got))
+	}
+	for i, charge := range charges {
+		if got[i].Helper != charge.Helper || got[i].Credit.Cmp(charge.Credit) != 0 {
+			t.Errorf("Turing charge %d mismatch: have %x %d, want %x %d", i, got[i].Helper, got[i].Credit, charge.Helper, charge.Credit)
+		}
+	}
+
+	DeleteTuringCharges(db, hash, 1)
+	if charges := ReadTuringCharges(db, hash, 1); charges != nil {
+		t.Fatal("Turing charges not deleted")
+	}
+}
@@ -64,6 +64,22 @@ var (
 	headVerifiedIndexKey = []byte("LastVerifiedIndex")
 	// headBatchKey tracks the latest processed batch
 	headBatchKey = []byte("LastBatch")
+	// headStateBatchKey tracks the latest state root batch checked for
+	// divergence
+	headStateBatchKey = []byte("LastStateBatch")
+
+	// l1IngestionProgressKey tracks the progress of the L1 rollup client
+	l1IngestionProgressKey = []byte("L1IngestionProgress")
+	// l1IngestionCheckpointsKey tracks the recent progress of the L1 rollup
+	// client along with the hashes of the ingested blocks
+	l1IngestionCheckpointsKey = []byte("L1IngestionCheckpoints")
+	l1EnqueuePrefix           = []byte("oq") // l1EnqueuePrefix + queue index (uint64 big endian) -> enqueue
+	l1TransactionPrefix       = []byte("ot") // l1TransactionPrefix + ctc index (uint64 big endian) -> batched transaction
+	l1BatchPrefix             = []byte("ob") // l1BatchPrefix + batch index (uint64 big endian) -> transaction batch
+
+	turingRecordsPrefix = []byte("tr") // turingRecordsPrefix + tx hash -> turing requests and responses
+	feeRecordPrefix     = []byte("fr") // feeRecordPrefix + num (uint64 big endian) + hash -> fees collected in the block
+	turingChargesPrefix = []byte("tc") // turingChargesPrefix + num (uint64 big endian) + hash -> turing credits charged in the block
 
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-") // config prefix for the db
@@ -117,6 +133,36 @@ func encodeBlockNumber(number uint64) []byte {
 	return enc
 }
 
+// l1EnqueueKey = l1EnqueuePrefix + queue index (uint64 big endian)
+func l1EnqueueKey(index uint64) []byte {
+	return append(l1EnqueuePrefix, encodeBlockNumber(index)...)
+}
+
+// l1TransactionKey = l1TransactionPrefix + ctc index (uint64 big endian)
+func l1TransactionKey(index uint64) []byte {
+	return append(l1TransactionPrefix, encodeBlockNumber(index)...)
+}
+
+// l1BatchKey = l1BatchPrefix + batch index (uint64 big endian)
+func l1BatchKey(index uint64) []byte {
+	return append(l1BatchPrefix, encodeBlockNumber(index)...)
+}
+
+// turingRecordsKey = turingRecordsPrefix + tx hash
+func turingRecordsKey(hash common.Hash) []byte {
+	return append(turingRecordsPrefix, hash.Bytes()...)
+}
+
+// feeRecordKey = feeRecordPrefix + num (uint64 big endian) + hash
+func feeRecordKey(number uint64, hash common.Hash) []byte {
+	return append(append(feeRecordPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// turingChargesKey = turingChargesPrefix + num (uint64 big endian) + hash
+func turingChargesKey(number uint64, hash common.Hash) []byte {
+	return append(append(turingChargesPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
 // headerKeyPrefix = headerPrefix + num (uint64 big endian)
 func headerKeyPrefix(number uint64) []byte {
 	return append(headerPrefix, encodeBlockNumber(number)...)
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
 	"github.com/ethereum-optimism/optimism/l2geth/trie"
 )
 
@@ -159,3 +160,57 @@ func (s *StateDB) Dump(excludeCode, excludeStorage, excludeMissingPreimages bool
 func (s *StateDB) IterativeDump(excludeCode, excludeStorage, excludeMissingPreimages bool, output *json.Encoder) {
 	s.dump(iterativeDump{output}, excludeCode, excludeStorage, excludeMissingPreimages)
 }
+
+// OvmDump streams every account in the state to w in the regenesis dump
+// format, one storage slot at a time. Native balances are carried along
+// with the balances in the storage of OVM_ETH. Unlike the other dump methods it fails
+// on missing preimages, since an account or slot without its preimage cannot
+// be carried into a new genesis.
+func (s *StateDB) OvmDump(w dump.AccountWriter) error {
+	it := trie.NewIterator(s.trie.NodeIterator(nil))
+	for it.Next() {
+		var data Account
+		if err := rlp.DecodeBytes(it.Value, &data); err != nil {
+			return err
+		}
+		preimage := s.trie.GetKey(it.Key)
+		if preimage == nil {
+			return fmt.Errorf("missing preimage for account key %x", it.Key)
+		}
+		addr := common.BytesToAddress(preimage)
+		if err := w.BeginAccount(addr); err != nil {
+			return err
+		}
+		obj := newObject(nil, addr, data)
+		storageIt := trie.NewIterator(obj.getTrie(s.db).NodeIterator(nil))
+		for storageIt.Next() {
+			_, content, _, err := rlp.Split(storageIt.Value)
+			if err != nil {
+				return err
+			}
+			key := s.trie.GetKey(storageIt.Key)
+			if key == nil {
+				return fmt.Errorf("missing preimage for storage key %x of account %s", storageIt.Key, addr.Hex())
+			}
+			if err := w.Storage(common.BytesToHash(key), common.BytesToHash(content).Hex()); err != nil {
+				return err
+			}
+		}
+		if storageIt.Err != nil {
+			return storageIt.Err
+		}
+		account := &dump.OvmDumpAccount{
+			Address:  addr,
+			Code:     hexutil.Encode(obj.Code(s.db)),
+			CodeHash: common.BytesToHash(data.CodeHash).Hex(),
+			Nonce:    data.Nonce,
+		}
+		if data.Balance.Sign() != 0 {
+			account.Balance = (*hexutil.Big)(data.Balance)
+		}
+		if err := w.EndAccount(account); err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
@@ -264,3 +264,54 @@ func (ch accessListAddSlotChange) revert(s *StateDB) {
 func (ch accessListAddSlotChange) dirtied() *common.Address {
 	return nil
 }
+
+// AccountChange holds the state of an account before it was first modified
+// since the last call to Finalise. Nil fields were not modified.
+type AccountChange struct {
+	Balance *big.Int
+	Nonce   *uint64
+	Code    *[]byte
+	Storage map[common.Hash]common.Hash
+}
+
+// Changes returns the accounts modified since the last call to Finalise with
+// their state before the first modification. Changes that were reverted are
+// not included.
+func (s *StateDB) Changes() map[common.Address]*AccountChange {
+	changes := make(map[common.Address]*AccountChange)
+	change := func(addr common.Address) *AccountChange {
+		if changes[addr] == nil {
+			changes[addr] = &AccountChange{Storage: make(map[common.Hash]common.Hash)}
+		}
+		return changes[addr]
+	}
+	for _, entry := range s.journal.entries {
+		switch ch := entry.(type) {
+		case balanceChange:
+			if c := change(*ch.account); c.Balance == nil {
+				c.Balance = new(big.Int).Set(ch.prev)
+			}
+		case suicideChange:
+			if c := change(*ch.account); c.Balance == nil {
+				c.Balance = new(big.Int).Set(ch.prevbalance)
+			}
+		case nonceChange:
+			if c := change(*ch.account); c.Nonce == nil {
+				nonce := ch.prev
+				c.Nonce = &nonce
+			}
+		case codeChange:
+			if c := change(*ch.account); c.Code == nil {
+				code := common.CopyBytes(ch.prevcode)
+				c.Code = &code
+			}
+		case storageChange:
+			c := change(*ch.account)
+			if _, ok := c.Storage[ch.key]; !ok {
+				c.Storage[ch.key] = ch.prevalue
+			}
+		}
+	}
+	return changes
+}
+
@@ -47,6 +47,12 @@ var (
 
 	// emptyCode is the known hash of the empty EVM bytecode.
 	emptyCode = crypto.Keccak256Hash(nil)
+
+	// turingPriceKey and turingRevenueKey are the storage slots of the Turing
+	// credit contract holding the price per request and the credits collected
+	// by the operator
+	turingPriceKey   = common.BigToHash(big.NewInt(3))
+	turingRevenueKey = common.BigToHash(big.NewInt(4))
 )
 
 type proofList [][]byte
@@ -136,6 +142,9 @@ type StateDB struct {
 	validRevisions []revision
 	nextRevisionId int
 
+	// OVM settings, rcfg.Default if nil
+	ovm *rcfg.Config
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads   time.Duration
 	AccountHashes  time.Duration
@@ -259,6 +268,20 @@ func (s *StateDB) Exist(addr common.Address) bool {
 	return s.getStateObject(addr) != nil
 }
 
+// SetOVMConfig sets the OVM settings that locate balances and the Boba
+// predeploys
+func (s *StateDB) SetOVMConfig(cfg *rcfg.Config) {
+	s.ovm = cfg
+}
+
+// OVMConfig returns the OVM settings of the state
+func (s *StateDB) OVMConfig() *rcfg.Config {
+	if s.ovm == nil {
+		return rcfg.Default
+	}
+	return s.ovm
+}
+
 // Empty returns whether the state object is either non-existent
 // or empty according to the EIP161 specification (balance = nonce = code = 0)
 func (s *StateDB) Empty(addr common.Address) bool {
@@ -268,7 +291,7 @@ func (s *StateDB) Empty(addr common.Address) bool {
 
 // Retrieve the balance from the given address or 0 if object not found
 func (s *StateDB) GetBalance(addr common.Address) *big.Int {
-	if rcfg.UsingOVM {
+	if s.OVMConfig().UsingOVM {
 		// Get balance from the OVM_ETH contract.
 		// NOTE: We may remove this feature in a future release.
 		key := GetOVMBalanceKey(addr)
@@ -287,21 +310,21 @@ func (s *StateDB) GetBalance(addr common.Address) *big.Int {
 func (s *StateDB) GetBobaBalance(addr common.Address) *big.Int {
 	// Get balance from the BOBA contract.
 	key := GetBobaBalanceKey(addr)
-	bal := s.GetState(rcfg.OvmL2BobaToken, key)
+	bal := s.GetState(s.OVMConfig().L2BobaTokenAddress, key)
 	return bal.Big()
 }
 
 // Retrieve the fee token selection
 func (s *StateDB) GetFeeTokenSelection(addr common.Address) *big.Int {
 	key := GetFeeTokenSelectionKey(addr)
-	bal := s.GetState(rcfg.OvmBobaGasPricOracle, key)
+	bal := s.GetState(s.OVMConfig().BobaGasPriceOracleAddress, key)
 	return bal.Big()
 }
 
 // Retrieve the price ratio of BOBA and ETH
 func (s *StateDB) GetBobaPriceRatio() *big.Int {
 	keyPriceRatio := common.BigToHash(big.NewInt(5))
-	value := s.GetState(rcfg.OvmBobaGasPricOracle, keyPriceRatio)
+	value := s.GetState(s.OVMConfig().BobaGasPriceOracleAddress, keyPriceRatio)
 	return value.Big()
 }
 
@@ -423,18 +446,9 @@ func (s *StateDB) HasSuicided(addr common.Address) bool {
 func (s *StateDB) TuringCharge(userID common.Address) error {
 	// Mutate two storage slots inside of OVM_ETH to transfer turing credits.
 	// userID is the address of that user's Turing Helper contract
-
-	keyUser := GetTuringPrepayKey(userID)
-	valueUser := s.GetState(rcfg.OvmTuringCreditAddress, keyUser)
-	balUser := valueUser.Big()
-
-	keyPrice := common.BigToHash(big.NewInt(3))
-	value := s.GetState(rcfg.OvmTuringCreditAddress, keyPrice)
-	price := value.Big()
-
-	keyOwner := common.BigToHash(big.NewInt(4))
-	valueOwner := s.GetState(rcfg.OvmTuringCreditAddress, keyOwner)
-	balOwner := valueOwner.Big()
+	balUser := s.GetTuringCredit(userID)
+	price := s.GetTuringPrice()
+	balOwner := s.GetTuringRevenue()
 
 	log.Debug("TURING-CREDIT:Before", "balUser", balUser, "price", price)
 
@@ -448,25 +462,20 @@ func (s *StateDB) TuringCharge(userID common.Address) error {
 	balOwner = balOwner.Add(balOwner, price)
 
 	//set the states
-	s.SetState(rcfg.OvmTuringCreditAddress, keyUser, common.BigToHash(balUser))
-	s.SetState(rcfg.OvmTuringCreditAddress, keyOwner, common.BigToHash(balOwner))
+	s.SetState(s.OVMConfig().TuringCreditAddress, GetTuringPrepayKey(userID), common.BigToHash(balUser))
+	s.SetState(s.OVMConfig().TuringCreditAddress, turingRevenueKey, common.BigToHash(balOwner))
 
 	log.Debug("TURING-CREDIT:Payment completed", "balUser", balUser, "balOwner", balOwner, "price", price)
 
 	return nil
 }
 
-// TuringCharge moves Turing credits from a credit wallet to the operator
+// TuringCheck checks that a credit wallet can pay for a Turing request
 func (s *StateDB) TuringCheck(userID common.Address) error {
 	// userID is the address of that user's Turing Helper contract
 	// checks for sufficient credit
-	keyUser := GetTuringPrepayKey(userID)
-	valueUser := s.GetState(rcfg.OvmTuringCreditAddress, keyUser)
-	balUser := valueUser.Big()
-
-	keyPrice := common.BigToHash(big.NewInt(3))
-	value := s.GetState(rcfg.OvmTuringCreditAddress, keyPrice)
-	price := value.Big()
+	balUser := s.GetTuringCredit(userID)
+	price := s.GetTuringPrice()
 
 	if balUser.Cmp(price) < 0 {
 		log.Warn("TURING-CREDIT-CHECK:User insufficient credit", "balUser", balUser, "price", price)
@@ -478,9 +487,28 @@ func (s *StateDB) TuringCheck(userID common.Address) error {
 	return nil
 }
 
+// GetTuringCredit returns the prepaid Turing credits of a Turing Helper
+// contract
+func (s *StateDB) GetTuringCredit(userID common.Address) *big.Int {
+	value := s.GetState(s.OVMConfig().TuringCreditAddress, GetTuringPrepayKey(userID))
+	return value.Big()
+}
+
+// GetTuringPrice returns the Turing credits charged per Turing request
+func (s *StateDB) GetTuringPrice() *big.Int {
+	value := s.GetState(s.OVMConfig().TuringCreditAddress, turingPriceKey)
+	return value.Big()
+}
+
+// GetTuringRevenue returns the Turing credits collected by the operator
+func (s *StateDB) GetTuringRevenue() *big.Int {
+	value := s.GetState(s.OVMConfig().TuringCreditAddress, turingRevenueKey)
+	return value.Big()
+}
+
 // AddBalance adds amount to the account associated with addr.
 func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
-	if rcfg.UsingOVM {
+	if s.OVMConfig().UsingOVM {
 		// Mutate the storage slot inside of OVM_ETH to change balances.
 		// Note that we don't need to check for overflows or underflows here because the code that
 		// uses this codepath already checks for them. You can follow the original codepath below
@@ -502,15 +530,15 @@ func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
 func (s *StateDB) AddBobaBalance(addr common.Address, amount *big.Int) {
 	// Get balance from the BOBA contract.
 	key := GetBobaBalanceKey(addr)
-	value := s.GetState(rcfg.OvmL2BobaToken, key)
+	value := s.GetState(s.OVMConfig().L2BobaTokenAddress, key)
 	bal := value.Big()
 	bal = bal.Add(bal, amount)
-	s.SetState(rcfg.OvmL2BobaToken, key, common.BigToHash(bal))
+	s.SetState(s.OVMConfig().L2BobaTokenAddress, key, common.BigToHash(bal))
 }
 
 // SubBalance subtracts amount from the account associated with addr.
 func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
-	if rcfg.UsingOVM {
+	if s.OVMConfig().UsingOVM {
 		// Mutate the storage slot inside of OVM_ETH to change balances.
 		// Note that we don't need to check for overflows or underflows here because the code that
 		// uses this codepath already checks for them. You can follow the original codepath below
@@ -532,24 +560,24 @@ func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
 func (s *StateDB) SubBobaBalance(addr common.Address, amount *big.Int) {
 	// Get balance from the BOBA contract.
 	key := GetBobaBalanceKey(addr)
-	value := s.GetState(rcfg.OvmL2BobaToken, key)
+	value := s.GetState(s.OVMConfig().L2BobaTokenAddress, key)
 	bal := value.Big()
 	bal = bal.Sub(bal, amount)
-	s.SetState(rcfg.OvmL2BobaToken, key, common.BigToHash(bal))
+	s.SetState(s.OVMConfig().L2BobaTokenAddress, key, common.BigToHash(bal))
 }
 
 func (s *StateDB) SetBobaAsFeeToken(addr common.Address) {
 	key := GetFeeTokenSelectionKey(addr)
-	s.SetState(rcfg.OvmBobaGasPricOracle, key, common.BigToHash(common.Big1))
+	s.SetState(s.OVMConfig().BobaGasPriceOracleAddress, key, common.BigToHash(common.Big1))
 }
 
 func (s *StateDB) SetBobaPriceRatio(priceRation *big.Int) {
 	keyPriceRatio := common.BigToHash(big.NewInt(5))
-	s.SetState(rcfg.OvmBobaGasPricOracle, keyPriceRatio, common.BigToHash(priceRation))
+	s.SetState(s.OVMConfig().BobaGasPriceOracleAddress, keyPriceRatio, common.BigToHash(priceRation))
 }
 
 func (s *StateDB) SetBalance(addr common.Address, amount *big.Int) {
-	if rcfg.UsingOVM {
+	if s.OVMConfig().UsingOVM {
 		// Mutate the storage slot inside of OVM_ETH to change balances.
 		key := GetOVMBalanceKey(addr)
 		s.SetState(dump.OvmEthAddress, key, common.BigToHash(amount))
@@ -773,6 +801,7 @@ func (s *StateDB) Copy() *StateDB {
 		logSize:             s.logSize,
 		preimages:           make(map[common.Hash][]byte, len(s.preimages)),
 		journal:             newJournal(),
+		ovm:                 s.ovm,
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range s.journal.dirties {
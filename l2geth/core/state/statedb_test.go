@@ -858,12 +858,13 @@ func TestStateDBAccessList(t *testing.T) {
 
 func TestStateDBBobaAsFeeToken(t *testing.T) {
 	addr := common.BytesToAddress([]byte{33})
-	rcfg.OvmL2BobaToken = common.HexToAddress("0x4200000000000000000000000000000000000023")
-	rcfg.OvmBobaGasPricOracle = common.HexToAddress("0x4200000000000000000000000000000000000024")
-
 	memDb := rawdb.NewMemoryDatabase()
 	db := NewDatabase(memDb)
 	state, _ := New(common.Hash{}, db)
+	state.SetOVMConfig(&rcfg.Config{
+		L2BobaTokenAddress:        common.HexToAddress("0x4200000000000000000000000000000000000023"),
+		BobaGasPriceOracleAddress: common.HexToAddress("0x4200000000000000000000000000000000000024"),
+	})
 
 	bobaBalance := state.GetBobaBalance(addr)
 	if bobaBalance.Cmp(common.Big0) != 0 {
@@ -915,3 +916,82 @@ func TestStateDBBobaAsFeeToken(t *testing.T) {
 		t.Fatal("expect 1 fee token selection")
 	}
 }
+
+func TestStateDBChanges(t *testing.T) {
+	addr, other := common.Address{0x01}, common.Address{0x02}
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+	state.SetOVMConfig(&rcfg.Config{})
+	state.SetBalance(addr, big.NewInt(10))
+	state.SetState(addr, common.Hash{0x01}, common.Hash{0x0a})
+	state.Finalise(true)
+
+	state.SetBalance(addr, big.NewInt(20))
+	state.SetBalance(addr, big.NewInt(30))
+	state.SetState(addr, common.Hash{0x01}, common.Hash{0x0b})
+	state.SetState(addr, common.Hash{0x02}, common.Hash{0x0c})
+	state.SetNonce(addr, 1)
+	snapshot := state.Snapshot()
+	state.SetBalance(other, big.NewInt(1))
+	state.RevertToSnapshot(snapshot)
+
+	changes := state.Changes()
+	if len(changes) != 1 || changes[addr] == nil {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	change := changes[addr]
+	if change.Balance == nil || change.Balance.Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("balance before %v, want 10", change.Balance)
+	}
+	if change.Nonce == nil || *change.Nonce != 0 {
+		t.Errorf("nonce before %v, want 0", change.Nonce)
+	}
+	if change.Code != nil {
+		t.Errorf("code changed")
+	}
+	want := map[common.Hash]common.Hash{{0x01}: {0x0a}, {0x02}: {}}
+	if !reflect.DeepEqual(change.Storage, want) {
+		t.Errorf("storage before %v, want %v", change.Storage, want)
+	}
+	state.Finalise(true)
+	if changes := state.Changes(); len(changes) != 0 {
+		t.Errorf("changes after finalise: %v", changes)
+	}
+}
+
+func TestStateDBTuringCredit(t *testing.T) {
+	helper := common.Address{0x01}
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+	state.SetOVMConfig(&rcfg.Config{
+		TuringCreditAddress: common.HexToAddress("0x4200000000000000000000000000000000000020"),
+	})
+	credit := state.OVMConfig().TuringCreditAddress
+	state.SetState(credit, GetTuringPrepayKey(helper), common.BigToHash(big.NewInt(25)))
+	state.SetState(credit, turingPriceKey, common.BigToHash(big.NewInt(10)))
+
+	if balance := state.GetTuringCredit(helper); balance.Cmp(big.NewInt(25)) != 0 {
+		t.Fatalf("credit %d, want 25", balance)
+	}
+	if price := state.GetTuringPrice(); price.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("price %d, want 10", price)
+	}
+	for i := 0; i < 2; i++ {
+		if err := state.TuringCheck(helper); err != nil {
+			t.Fatalf("check %d failed: %v", i, err)
+		}
+		if err := state.TuringCharge(helper); err != nil {
+			t.Fatalf("charge %d failed: %v", i, err)
+		}
+	}
+	if balance := state.GetTuringCredit(helper); balance.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("credit %d after charges, want 5", balance)
+	}
+	if revenue := state.GetTuringRevenue(); revenue.Cmp(big.NewInt(20)) != 0 {
+		t.Fatalf("revenue %d after charges, want 20", revenue)
+	}
+	if state.TuringCheck(helper) == nil || state.TuringCharge(helper) == nil {
+		t.Fatal("insufficient credit accepted")
+	}
+	if balance := state.GetTuringCredit(helper); balance.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("credit %d after failed charge, want 5", balance)
+	}
+}
@@ -77,7 +77,7 @@ func precacheTransaction(config *params.ChainConfig, bc ChainContext, author *co
 		return err
 	}
 	// Create the EVM and execute the transaction
-	context := NewEVMContext(msg, header, bc, author)
+	context := NewEVMContext(msg, header, bc, config, author)
 	vm := vm.NewEVM(context, statedb, config, cfg)
 
 	_, _, _, err = ApplyMessage(vm, msg, gaspool)
@@ -71,6 +71,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		ReplayTuring(p.bc.db, tx)
 		receipt, err := ApplyTransaction(p.config, p.bc, nil, gp, statedb, header, tx, usedGas, cfg)
 		if err != nil {
 			return nil, nil, 0, err
@@ -94,7 +95,7 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 		return nil, err
 	}
 	// Create a new context to be used in the EVM environment
-	context := NewEVMContext(msg, header, bc, author)
+	context := NewEVMContext(msg, header, bc, config, author)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
 	vmenv := vm.NewEVM(context, statedb, config, cfg)
@@ -128,7 +129,7 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	feeTokenSelection := statedb.GetFeeTokenSelection(msg.From())
 
 	// Apply the transaction to the current state (included in the env)
-	_, gas, failed, err := ApplyMessage(vmenv, msg, gp)
+	_, gas, failed, fees, err := ApplyMessageWithFees(vmenv, msg, gp)
 
 	// TURING Update the tx metadata, if a Turing call took place...
 	if len(vmenv.Context.Turing) > 1 {
@@ -174,7 +175,11 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	receipt.BlockNumber = header.Number
 	receipt.TransactionIndex = uint(statedb.TxIndex())
 	receipt.Turing = vmenv.Context.Turing
+	receipt.TuringRequest = vmenv.Context.TuringRequest
+	receipt.TuringProof = vmenv.Context.TuringProof
+	receipt.TuringHelper = vmenv.Context.TuringHelper
 	receipt.L2BobaFee = L2BobaFee
+	receipt.Fees = fees
 
 	return receipt, err
 }
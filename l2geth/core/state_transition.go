@@ -28,7 +28,6 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup/fees"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 var (
@@ -74,6 +73,10 @@ type StateTransition struct {
 	isBobaFeeTokenSelect bool
 	// Fee ratio between Boba an ETH
 	bobaPriceRatio *big.Int
+	// ETH fee that exceeded the balance of the sender and was not charged
+	uncollected *big.Int
+	// Fees collected from the message
+	fees *types.FeeRecord
 }
 
 // Message represents a message sent to a contract.
@@ -146,7 +149,7 @@ func NewStateTransition(evm *vm.EVM, msg Message, gp *GasPool) *StateTransition
 	isFeeTokenUpdate := evm.ChainConfig().IsFeeTokenUpdate(evm.BlockNumber)
 	// The default fee token is ETH
 	isBobaFeeTokenSelect := false
-	if rcfg.UsingOVM {
+	if evm.ChainConfig().UsingOVM() {
 		// if msg.GasPrice > 0, then...
 		if msg.GasPrice().Cmp(common.Big0) == 1 {
 			// Compute the L1 fee before the state transition
@@ -190,9 +193,19 @@ func NewStateTransition(evm *vm.EVM, msg Message, gp *GasPool) *StateTransition
 		isBobaFeeTokenSelect: isBobaFeeTokenSelect,
 		// BOBA price relative to ETH
 		bobaPriceRatio: bobaPriceRatio,
+		uncollected:    new(big.Int),
+		fees:           types.NewFeeRecord(),
 	}
 }
 
+// ApplyMessageWithFees applies a message like ApplyMessage and also returns
+// the fees that were collected from it
+func ApplyMessageWithFees(evm *vm.EVM, msg Message, gp *GasPool) ([]byte, uint64, bool, *types.FeeRecord, error) {
+	st := NewStateTransition(evm, msg, gp)
+	ret, gas, failed, err := st.TransitionDb()
+	return ret, gas, failed, st.fees, err
+}
+
 // ApplyMessage computes the new state by applying the given message
 // against the old state within the environment.
 //
@@ -224,7 +237,7 @@ func (st *StateTransition) useGas(amount uint64) error {
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
 	bobaval := new(big.Int)
-	if rcfg.UsingOVM {
+	if st.evm.ChainConfig().UsingOVM() {
 		// Only charge the L1 fee for QueueOrigin sequencer transactions
 		if st.msg.QueueOrigin() == types.QueueOriginSequencer {
 			// L2 extra gas has already included in tx.Gas()
@@ -243,11 +256,12 @@ func (st *StateTransition) buyGas() error {
 		}
 	}
 	if st.state.GetBalance(st.msg.From()).Cmp(mgval) < 0 {
-		if rcfg.UsingOVM {
+		if st.evm.ChainConfig().UsingOVM() {
 			// Hack to prevent race conditions with the `gas-oracle`
 			// where policy level balance checks pass and then fail
 			// during consensus. The user gets some free gas
 			// in this case.
+			st.uncollected = new(big.Int).Sub(mgval, st.state.GetBalance(st.msg.From()))
 			mgval = st.state.GetBalance(st.msg.From())
 		} else {
 			return errInsufficientBalanceForGas
@@ -284,7 +298,7 @@ func (st *StateTransition) buyGas() error {
 func (st *StateTransition) preCheck() error {
 	// Make sure this transaction's nonce is correct.
 	if st.msg.CheckNonce() {
-		if rcfg.UsingOVM {
+		if st.evm.ChainConfig().UsingOVM() {
 			if st.msg.QueueOrigin() == types.QueueOriginL1ToL2 {
 				return st.buyGas()
 			}
@@ -306,6 +320,11 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	if err = st.preCheck(); err != nil {
 		return
 	}
+	// Turing charges are measured by the change of the collected credits
+	var turingRevenue *big.Int
+	if st.evm.ChainConfig().UsingOVM() {
+		turingRevenue = st.state.GetTuringRevenue()
+	}
 	msg := st.msg
 	sender := vm.AccountRef(msg.From())
 	homestead := st.evm.ChainConfig().IsHomestead(st.evm.BlockNumber)
@@ -362,7 +381,8 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	if st.isBobaFeeTokenSelect {
 		ethval := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.msg.GasPrice())
 		bobaval := new(big.Int).Mul(ethval, st.bobaPriceRatio)
-		st.state.AddBobaBalance(rcfg.OvmBobaGasPricOracle, bobaval)
+		st.state.AddBobaBalance(st.evm.ChainConfig().OVMConfig().BobaGasPriceOracleAddress, bobaval)
+		st.fees.BobaFee.Set(bobaval)
 	}
 
 	// GasUsed hard fork
@@ -370,7 +390,11 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		// st.gasPrice is 0 if users chose BOBA as the fee token.
 		// this 'automatically' takes care of adding the
 		// right amount of ETH
-		st.state.AddBalance(evm.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice))
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
+		st.state.AddBalance(evm.Coinbase, fee)
+		// The L1 fee is paid with the L2 extra gas
+		st.fees.L1Fee.Mul(st.l2ExtraGas, st.gasPrice)
+		st.fees.L2Fee.Sub(fee, st.fees.L1Fee)
 	} else {
 		// The L2 Fee is the same as the fee that is charged in the normal geth
 		// codepath. Add the L1 fee to the L2 fee for the total fee that is sent
@@ -378,6 +402,14 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		l2Fee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
 		fee := new(big.Int).Add(st.l1Fee, l2Fee)
 		st.state.AddBalance(evm.Coinbase, fee)
+		st.fees.L1Fee.Set(st.l1Fee)
+		st.fees.L2Fee.Set(l2Fee)
+	}
+	st.fees.Uncollected.Set(st.uncollected)
+	if turingRevenue != nil {
+		if charged := new(big.Int).Sub(st.state.GetTuringRevenue(), turingRevenue); charged.Sign() > 0 {
+			st.fees.TuringFee.Set(charged)
+		}
 	}
 
 	return ret, st.gasUsed(), vmerr != nil, err
@@ -417,7 +449,7 @@ func (st *StateTransition) refundGas() {
 
 // gasUsed returns the amount of gas used up by the state transition.
 func (st *StateTransition) gasUsed() uint64 {
-	if rcfg.UsingOVM {
+	if st.evm.ChainConfig().UsingOVM() {
 		// GasUsed hard fork
 		if st.isGasUpdate && !st.isFeeTokenUpdate {
 			return st.initialGas - st.gas + st.l2ExtraGas.Uint64()
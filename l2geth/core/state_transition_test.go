@@ -0,0 +1,77 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+)
+
+// Tests that the fees of messages are recorded, including the fees that the
+// sender could not pay and the fees paid in BOBA.
+func TestApplyMessageFees(t *testing.T) {
+	ovm := &rcfg.Config{
+		UsingOVM:                  true,
+		BobaGasPriceOracleAddress: common.HexToAddress("0x4200000000000000000000000000000000000024"),
+		L2BobaTokenAddress:        common.HexToAddress("0x4200000000000000000000000000000000000023"),
+	}
+	config := *params.TestChainConfig
+	config.OVM = ovm
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetOVMConfig(ovm)
+
+	var (
+		from = common.Address{0x01}
+		to   = common.Address{0x02}
+	)
+	apply := func() *types.FeeRecord {
+		msg := types.NewMessage(from, &to, statedb.GetNonce(from), new(big.Int), params.TxGas, big.NewInt(1), nil, true, nil, 0, nil, types.QueueOriginSequencer)
+		context := vm.Context{
+			CanTransfer: CanTransfer,
+			Transfer:    Transfer,
+			Origin:      from,
+			BlockNumber: big.NewInt(1),
+			Time:        big.NewInt(1),
+			Difficulty:  new(big.Int),
+			GasLimit:    params.TxGas,
+			GasPrice:    big.NewInt(1),
+		}
+		evm := vm.NewEVM(context, statedb, &config, vm.Config{})
+		_, gas, _, fees, err := ApplyMessageWithFees(evm, msg, new(GasPool).AddGas(params.TxGas))
+		if err != nil {
+			t.Fatalf("failed to apply message: %v", err)
+		}
+		if gas != params.TxGas {
+			t.Fatalf("Unexpected gas used: %d", gas)
+		}
+		return fees
+	}
+
+	// The charge is capped at the balance of the sender
+	statedb.AddBalance(from, big.NewInt(1000))
+	fees := apply()
+	if fees.L2Fee.Cmp(new(big.Int).SetUint64(params.TxGas)) != 0 {
+		t.Fatalf("Unexpected L2 fee: %d", fees.L2Fee)
+	}
+	if want := new(big.Int).SetUint64(params.TxGas - 1000); fees.Uncollected.Cmp(want) != 0 {
+		t.Fatalf("Unexpected uncollected fee: have %d, want %d", fees.Uncollected, want)
+	}
+
+	// Fees paid in BOBA are not paid in ETH
+	statedb.SetBobaAsFeeToken(from)
+	statedb.SetBobaPriceRatio(big.NewInt(2))
+	statedb.AddBobaBalance(from, big.NewInt(100000))
+	fees = apply()
+	if want := new(big.Int).SetUint64(2 * params.TxGas); fees.BobaFee.Cmp(want) != 0 {
+		t.Fatalf("Unexpected BOBA fee: have %d, want %d", fees.BobaFee, want)
+	}
+	if fees.L1Fee.Sign() != 0 || fees.L2Fee.Sign() != 0 || fees.Uncollected.Sign() != 0 {
+		t.Fatalf("Unexpected ETH fees: %+v", fees)
+	}
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+)
+
+// ReplayTuring sets the recorded Turing response of a transaction that is
+// missing its L1Turing meta, so that re-executing the transaction replays the
+// response that the sequencer saw instead of calling Turing again
+func ReplayTuring(db ethdb.KeyValueReader, tx *types.Transaction) {
+	if len(tx.L1Turing()) > 1 {
+		return
+	}
+	records := rawdb.ReadTuringRecords(db, tx.Hash())
+	if len(records) == 0 {
+		return
+	}
+	tx.SetL1Turing(records[len(records)-1].Response)
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+func TestReplayTuring(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	tx := types.NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil)
+	response := []byte{0x7d, 0x93, 0x61, 0x6c, 0x02}
+
+	// Transactions without a record are not modified
+	ReplayTuring(db, tx)
+	if len(tx.L1Turing()) > 1 {
+		t.Fatal("Turing response set without record")
+	}
+
+	rawdb.WriteTuringRecords(db, tx.Hash(), []*types.TuringRecord{
+		{Request: []byte{0x7d, 0x93, 0x61, 0x6c, 0x01}, Response: response},
+	})
+	ReplayTuring(db, tx)
+	if !bytes.Equal(tx.L1Turing(), response) {
+		t.Fatalf("Unexpected turing response: %x", tx.L1Turing())
+	}
+
+	// The L1Turing meta takes precedence over the record
+	meta := []byte{0x01, 0x02}
+	tx.SetL1Turing(meta)
+	ReplayTuring(db, tx)
+	if !bytes.Equal(tx.L1Turing(), meta) {
+		t.Fatalf("Unexpected turing response: %x", tx.L1Turing())
+	}
+}
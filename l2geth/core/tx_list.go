@@ -224,9 +224,28 @@ type txList struct {
 	txs    *txSortedMap // Heap indexed sorted hash map of the transactions
 
 	costcap *big.Int // Price of the highest costing transaction (reset only if exceeds balance)
+	feecap  *big.Int // Gas fee of the highest paying transaction (reset only if exceeds BOBA balance)
 	gascap  uint64   // Gas limit of the highest spending transaction (reset only if exceeds block limit)
 }
 
+// bobaFeePayment holds the BOBA price ratio and balance of an account that
+// pays the fees of its transactions in BOBA. Such accounts pay the value of a
+// transaction in ETH and gas * price * ratio in BOBA.
+type bobaFeePayment struct {
+	ratio   *big.Int // Price ratio of BOBA and ETH
+	balance *big.Int // BOBA balance of the account
+}
+
+// covers reports whether the BOBA balance pays for a fee denominated in ETH
+func (p *bobaFeePayment) covers(fee *big.Int) bool {
+	return new(big.Int).Mul(fee, p.ratio).Cmp(p.balance) <= 0
+}
+
+// txFee returns the gas fee of a transaction, gas * price
+func txFee(tx *types.Transaction) *big.Int {
+	return new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas()))
+}
+
 // newTxList create a new transaction list for maintaining nonce-indexable fast,
 // gapped, sortable transaction lists.
 func newTxList(strict bool) *txList {
@@ -234,6 +253,7 @@ func newTxList(strict bool) *txList {
 		strict:  strict,
 		txs:     newTxSortedMap(),
 		costcap: new(big.Int),
+		feecap:  new(big.Int),
 	}
 }
 
@@ -265,6 +285,9 @@ func (l *txList) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Tran
 	if cost := tx.Cost(); l.costcap.Cmp(cost) < 0 {
 		l.costcap = cost
 	}
+	if fee := txFee(tx); l.feecap.Cmp(fee) < 0 {
+		l.feecap = fee
+	}
 	if gas := tx.Gas(); l.gascap < gas {
 		l.gascap = gas
 	}
@@ -283,20 +306,35 @@ func (l *txList) Forward(threshold uint64) types.Transactions {
 // post-removal maintenance. Strict-mode invalidated transactions are also
 // returned.
 //
+// If the account pays its fees in BOBA, costLimit only has to cover the value
+// of the transactions and the fees are checked against the BOBA balance.
+//
 // This method uses the cached costcap and gascap to quickly decide if there's even
 // a point in calculating all the costs or if the balance covers all. If the threshold
 // is lower than the costgas cap, the caps will be reset to a new high after removing
 // the newly invalidated transactions.
-func (l *txList) Filter(costLimit *big.Int, gasLimit uint64) (types.Transactions, types.Transactions) {
+func (l *txList) Filter(costLimit *big.Int, gasLimit uint64, boba *bobaFeePayment) (types.Transactions, types.Transactions) {
 	// If all transactions are below the threshold, short circuit
-	if l.costcap.Cmp(costLimit) <= 0 && l.gascap <= gasLimit {
+	if l.costcap.Cmp(costLimit) <= 0 && l.gascap <= gasLimit && (boba == nil || boba.covers(l.feecap)) {
 		return nil, nil
 	}
-	l.costcap = new(big.Int).Set(costLimit) // Lower the caps to the thresholds
+	// Lower the caps to the thresholds. The cost cap includes the fees, so it
+	// is only lowered if the fees are paid in ETH.
+	if boba == nil {
+		l.costcap = new(big.Int).Set(costLimit)
+	}
 	l.gascap = gasLimit
 
 	// Filter out all the transactions above the account's funds
-	removed := l.txs.Filter(func(tx *types.Transaction) bool { return tx.Cost().Cmp(costLimit) > 0 || tx.Gas() > gasLimit })
+	removed := l.txs.Filter(func(tx *types.Transaction) bool {
+		if tx.Gas() > gasLimit {
+			return true
+		}
+		if boba != nil {
+			return tx.Value().Cmp(costLimit) > 0 || !boba.covers(txFee(tx))
+		}
+		return tx.Cost().Cmp(costLimit) > 0
+	})
 
 	// If the list was strict, filter anything above the lowest nonce
 	var invalids types.Transactions
@@ -33,7 +33,6 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/metrics"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 const (
@@ -236,7 +235,8 @@ type TxPool struct {
 	signer      types.Signer
 	mu          sync.RWMutex
 
-	istanbul bool // Fork indicator whether we are in the istanbul stage.
+	istanbul       bool // Fork indicator whether we are in the istanbul stage.
+	feeTokenUpdate bool // Fork indicator whether fees may be paid in BOBA.
 
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
@@ -558,7 +558,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrUnderpriced
 	}
 	// Ensure the transaction adheres to nonce ordering
-	if rcfg.UsingOVM {
+	if pool.chainconfig.UsingOVM() {
 		if pool.currentState.GetNonce(from) != tx.Nonce() {
 			return ErrNonceTooLow
 		}
@@ -569,9 +569,18 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	}
 	// Transactor should have enough funds to cover the costs
 	// cost == V + GP * GL
-	if !rcfg.UsingOVM {
-		// This check is done in SyncService.verifyFee
-		if pool.currentState.GetBalance(from).Cmp(tx.Cost()) < 0 {
+	// Before the fee token fork the fee includes the L1 fee, which is checked
+	// in SyncService.verifyFee. After it, accounts that pay in BOBA pay V in
+	// ETH and GP * GL * ratio in BOBA.
+	if !pool.chainconfig.UsingOVM() || pool.feeTokenUpdate {
+		if boba := pool.bobaFeePayment(from); boba != nil {
+			if pool.currentState.GetBalance(from).Cmp(tx.Value()) < 0 {
+				return ErrInsufficientFunds
+			}
+			if !boba.covers(txFee(tx)) {
+				return ErrInsufficientBobaFunds
+			}
+		} else if pool.currentState.GetBalance(from).Cmp(tx.Cost()) < 0 {
 			return ErrInsufficientFunds
 		}
 	}
@@ -1134,6 +1143,13 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 				}
 				return
 			}
+			// If the new head is nil, it was removed by a setHead between the
+			// firing of the head event and now, there's nothing to add
+			if add == nil {
+				log.Error("Transaction pool reset with missing newhead",
+					"old", oldHead.Hash(), "oldnum", oldNum, "new", newHead.Hash(), "newnum", newNum)
+				return
+			}
 			for rem.NumberU64() > add.NumberU64() {
 				discarded = append(discarded, rem.Transactions()...)
 				if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
@@ -1184,6 +1200,22 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	// Update all fork indicator by next pending block number.
 	next := new(big.Int).Add(newHead.Number, big.NewInt(1))
 	pool.istanbul = pool.chainconfig.IsIstanbul(next)
+	pool.feeTokenUpdate = pool.chainconfig.IsFeeTokenUpdate(next)
+}
+
+// bobaFeePayment returns the BOBA price ratio and balance of an account that
+// pays the fees of its transactions in BOBA, or nil if it pays in ETH.
+func (pool *TxPool) bobaFeePayment(addr common.Address) *bobaFeePayment {
+	if !pool.chainconfig.UsingOVM() || !pool.feeTokenUpdate {
+		return nil
+	}
+	if pool.currentState.GetFeeTokenSelection(addr).Cmp(common.Big1) != 0 {
+		return nil
+	}
+	return &bobaFeePayment{
+		ratio:   pool.currentState.GetBobaPriceRatio(),
+		balance: pool.currentState.GetBobaBalance(addr),
+	}
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1207,7 +1239,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			log.Trace("Removed old queued transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas)
-		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas)
+		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas, pool.bobaFeePayment(addr))
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
@@ -1399,7 +1431,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
-		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas)
+		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas, pool.bobaFeePayment(addr))
 		for _, tx := range drops {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
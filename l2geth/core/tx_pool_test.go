@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/crypto"
 	"github.com/ethereum-optimism/optimism/l2geth/event"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 // testTxPoolConfig is a transaction pool configuration without stateful disk
@@ -1912,3 +1913,132 @@ func benchmarkPoolBatchInsert(b *testing.B, size int) {
 		pool.AddRemotes(batch)
 	}
 }
+
+// setupBobaTxPool creates a pool for the OVM after the fee token fork where
+// the account of the returned key pays its fees in BOBA at a price ratio of 2
+func setupBobaTxPool() (*TxPool, *ecdsa.PrivateKey) {
+	config := *params.TestChainConfig
+	config.OVM = &rcfg.Config{
+		UsingOVM:                  true,
+		BobaGasPriceOracleAddress: common.HexToAddress("0x4200000000000000000000000000000000000024"),
+		L2BobaTokenAddress:        common.HexToAddress("0x4200000000000000000000000000000000000023"),
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetOVMConfig(config.OVM)
+	blockchain := &testBlockChain{statedb, 10000000, new(event.Feed)}
+
+	key, _ := crypto.GenerateKey()
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.SetState(config.OVM.BobaGasPriceOracleAddress, state.GetFeeTokenSelectionKey(account), common.BigToHash(common.Big1))
+	statedb.SetState(config.OVM.BobaGasPriceOracleAddress, common.BigToHash(big.NewInt(5)), common.BigToHash(common.Big2))
+
+	return NewTxPool(testTxPoolConfig, &config, blockchain), key
+}
+
+// setBobaBalance sets the BOBA balance of an account
+func setBobaBalance(pool *TxPool, addr common.Address, balance int64) {
+	bobaToken := pool.chainconfig.OVMConfig().L2BobaTokenAddress
+	pool.currentState.SetState(bobaToken, state.GetBobaBalanceKey(addr), common.BigToHash(big.NewInt(balance)))
+}
+
+// Tests that accounts paying their fees in BOBA are validated against their
+// ETH balance for the value and their BOBA balance for the fee.
+func TestTransactionBobaFeeToken(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupBobaTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(account, big.NewInt(100))
+
+	// The fee of 100000 costs 200000 BOBA, the value of 100 is paid in ETH
+	tx := transaction(0, 100000, key)
+	setBobaBalance(pool, account, 199999)
+	if err := pool.AddRemote(tx); err != ErrInsufficientBobaFunds {
+		t.Fatalf("Unexpected error: %v, want %v", err, ErrInsufficientBobaFunds)
+	}
+	setBobaBalance(pool, account, 200000)
+	if err := pool.AddRemote(tx); err != nil {
+		t.Fatalf("Failed to add transaction paid in BOBA: %v", err)
+	}
+	// The value is still paid in ETH
+	pool.currentState.SetBalance(account, big.NewInt(99))
+	if err := pool.validateTx(transaction(0, 100000, key), false); err != ErrInsufficientFunds {
+		t.Fatalf("Unexpected error: %v, want %v", err, ErrInsufficientFunds)
+	}
+	// Accounts paying in ETH pay the fee with their ETH balance
+	pool.currentState.SetState(pool.chainconfig.OVMConfig().BobaGasPriceOracleAddress, state.GetFeeTokenSelectionKey(account), common.Hash{})
+	pool.currentState.SetBalance(account, big.NewInt(100))
+	if err := pool.validateTx(transaction(0, 100000, key), false); err != ErrInsufficientFunds {
+		t.Fatalf("Unexpected error: %v, want %v", err, ErrInsufficientFunds)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that pending and queued transactions of accounts paying their fees in
+// BOBA are dropped once the BOBA balance cannot pay for them.
+func TestTransactionBobaFeeTokenDropping(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupBobaTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(account, big.NewInt(1000))
+	setBobaBalance(pool, account, 1000)
+
+	// The BOBA costs of the transactions are twice their gas limits
+	var (
+		tx0  = transaction(0, 100, key)
+		tx1  = transaction(1, 200, key)
+		tx2  = transaction(2, 300, key)
+		tx10 = transaction(10, 100, key)
+		tx11 = transaction(11, 200, key)
+		tx12 = transaction(12, 300, key)
+	)
+	pool.promoteTx(account, tx0.Hash(), tx0)
+	pool.promoteTx(account, tx1.Hash(), tx1)
+	pool.promoteTx(account, tx2.Hash(), tx2)
+	pool.enqueueTx(tx10.Hash(), tx10)
+	pool.enqueueTx(tx11.Hash(), tx11)
+	pool.enqueueTx(tx12.Hash(), tx12)
+
+	// The ETH balance covers the values, so nothing is dropped although it
+	// cannot pay for the fees
+	pool.currentState.SetBalance(account, big.NewInt(100))
+	<-pool.requestReset(nil, nil)
+	if pool.pending[account].Len() != 3 {
+		t.Errorf("pending transaction mismatch: have %d, want %d", pool.pending[account].Len(), 3)
+	}
+	if pool.queue[account].Len() != 3 {
+		t.Errorf("queued transaction mismatch: have %d, want %d", pool.queue[account].Len(), 3)
+	}
+	// Reduce the BOBA balance, and check that invalidated transactions are dropped
+	setBobaBalance(pool, account, 400)
+	<-pool.requestReset(nil, nil)
+
+	if _, ok := pool.pending[account].txs.items[tx0.Nonce()]; !ok {
+		t.Errorf("funded pending transaction missing: %v", tx0)
+	}
+	if _, ok := pool.pending[account].txs.items[tx1.Nonce()]; !ok {
+		t.Errorf("funded pending transaction missing: %v", tx1)
+	}
+	if _, ok := pool.pending[account].txs.items[tx2.Nonce()]; ok {
+		t.Errorf("out-of-fund pending transaction present: %v", tx2)
+	}
+	if _, ok := pool.queue[account].txs.items[tx10.Nonce()]; !ok {
+		t.Errorf("funded queued transaction missing: %v", tx10)
+	}
+	if _, ok := pool.queue[account].txs.items[tx11.Nonce()]; !ok {
+		t.Errorf("funded queued transaction missing: %v", tx11)
+	}
+	if _, ok := pool.queue[account].txs.items[tx12.Nonce()]; ok {
+		t.Errorf("out-of-fund queued transaction present: %v", tx12)
+	}
+	if pool.all.Count() != 4 {
+		t.Errorf("total transaction mismatch: have %d, want %d", pool.all.Count(), 4)
+	}
+}
@@ -0,0 +1,35 @@
+package types
+
+import "math/big"
+
+// FeeRecord holds the fees collected from the transactions of a block. The
+// amounts are in wei, except for BobaFee which is in BOBA.
+type FeeRecord struct {
+	L1Fee     *big.Int // L1 security fee paid in ETH
+	L2Fee     *big.Int // L2 execution fee paid in ETH
+	BobaFee   *big.Int // Fee paid in BOBA to the Boba gas price oracle
+	TuringFee *big.Int // Turing credit charged for off-chain requests
+	// Uncollected is the ETH fee credited to the sequencer that the sender
+	// could not pay, as the charge is capped at the balance of the sender
+	Uncollected *big.Int
+}
+
+// NewFeeRecord creates a FeeRecord without fees
+func NewFeeRecord() *FeeRecord {
+	return &FeeRecord{
+		L1Fee:       new(big.Int),
+		L2Fee:       new(big.Int),
+		BobaFee:     new(big.Int),
+		TuringFee:   new(big.Int),
+		Uncollected: new(big.Int),
+	}
+}
+
+// Add adds the fees of another record
+func (r *FeeRecord) Add(other *FeeRecord) {
+	r.L1Fee.Add(r.L1Fee, other.L1Fee)
+	r.L2Fee.Add(r.L2Fee, other.L2Fee)
+	r.BobaFee.Add(r.BobaFee, other.BobaFee)
+	r.TuringFee.Add(r.TuringFee, other.TuringFee)
+	r.Uncollected.Add(r.Uncollected, other.Uncollected)
+}
@@ -76,6 +76,18 @@ type Receipt struct {
 
 	// Using Turing
 	Turing []byte `json:"turing"`
+	// TuringRequest is the calldata of the Turing request of the transaction,
+	// it is stored with the response rather than in the receipt
+	TuringRequest []byte `json:"-"`
+	// TuringProof is the proof of a verifiable random number returned to the
+	// transaction, it is stored with the response
+	TuringProof []byte `json:"-"`
+	// TuringHelper is the TuringHelper contract that was charged for the
+	// Turing request, the charges are stored per block
+	TuringHelper common.Address `json:"-"`
+
+	// Fees collected from the transaction, which are stored per block
+	Fees *FeeRecord `json:"-"`
 }
 
 type receiptMarshaling struct {
@@ -27,7 +27,6 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
 	"github.com/ethereum-optimism/optimism/l2geth/crypto"
 	"github.com/ethereum-optimism/optimism/l2geth/rlp"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 //go:generate gencodec -type txdata -field-override txdataMarshaling -out gen_tx_json.go
@@ -325,13 +324,11 @@ func (tx *Transaction) AsMessage(s Signer) (Message, error) {
 		queueOrigin:   tx.meta.QueueOrigin,
 	}
 
+	// L1 to L2 transactions are not signed, their sender is the layer one
+	// message sender
 	var err error
-	if rcfg.UsingOVM {
-		if tx.meta.QueueOrigin == QueueOriginL1ToL2 && tx.meta.L1MessageSender != nil {
-			msg.from = *tx.meta.L1MessageSender
-		} else {
-			msg.from, err = Sender(s, tx)
-		}
+	if tx.meta.QueueOrigin == QueueOriginL1ToL2 && tx.meta.L1MessageSender != nil {
+		msg.from = *tx.meta.L1MessageSender
 	} else {
 		msg.from, err = Sender(s, tx)
 	}
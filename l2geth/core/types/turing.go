@@ -0,0 +1,23 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+)
+
+// TuringRecord is an off-chain Turing request made by a transaction together
+// with the modified calldata that was returned to the TuringHelper contract.
+// Proof is the proof of a verifiable random number, if any.
+type TuringRecord struct {
+	Request  []byte
+	Response []byte
+	Proof    []byte
+}
+
+// TuringCharge is the Turing credit charged to a TuringHelper contract for
+// the off-chain request of a transaction
+type TuringCharge struct {
+	Helper common.Address
+	Credit *big.Int
+}
@@ -18,7 +18,8 @@ package vm
 
 import (
 	"bytes"
-	"crypto/rand"
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -31,9 +32,7 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup/util"
-	"github.com/ethereum-optimism/optimism/l2geth/rpc"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -113,6 +112,14 @@ type Context struct {
 	Turing      []byte
 	TuringDepth int
 	Sequencer   bool
+	// TuringRequest is the calldata of the Turing request that was answered
+	// by Turing, which is recorded with the response
+	TuringRequest []byte
+	// TuringProof is the proof of a verifiable random number
+	TuringProof []byte
+	// TuringHelper is the Turing Helper contract that was charged for the
+	// Turing request
+	TuringHelper common.Address
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -231,33 +238,29 @@ func (evm *EVM) bobaTuringRandom(input []byte, caller common.Address) hexutil.By
 	rType := int(rest[31])
 	if rType != 1 {
 		log.Error("TURING bobaTuringRandom:Wrong state (rType != 1)", "rType", rType)
-		retError[35] = 10 // Wrong input state
+		retError[35] = turingErrWrongState
 		return retError
 	}
 
 	rlen := len(rest)
 	if rlen < 2*32 {
 		log.Error("TURING bobaTuringRandom:Calldata too short", "len < 2*32", rlen)
-		retError[35] = 11 // Calldata too short
+		retError[35] = turingErrCalldataTooShort
 		return retError
 	}
 
-	// Generate cryptographically strong pseudo-random int between 0 - 2^256 - 1
-	one := big.NewInt(1)
-	two := big.NewInt(2)
-	max := new(big.Int)
-	// Max random value 2^256 - 1
-	max = max.Exp(two, big.NewInt(int64(256)), nil).Sub(max, one)
-	n, err := rand.Int(rand.Reader, max)
+	// Draw a random int between 0 - 2^256 - 1 from the configured provider
+	n, proof, err := getTuringSettings().cfg.Randomness.Random(evm.turingSeed(caller, input))
 
 	if err != nil {
 		log.Error("TURING bobaTuringRandom:Random Number Generation Failed", "err", err)
-		retError[35] = 16 // RNG Failure
+		retError[35] = turingErrRandom
 		return retError
 	}
 
 	//generate a BigInt random number
 	randomBigInt := n
+	evm.Context.TuringProof = proof
 
 	log.Debug("TURING bobaTuringRandom:Random number",
 		"randomBigInt", randomBigInt)
@@ -339,6 +342,18 @@ func (cache *turingCache) Trim() {
 
 var tCache turingCache
 
+// turingSeed hashes the caller, origin, origin nonce and calldata of a
+// Turing request, which identifies the request within a transaction
+func (evm *EVM) turingSeed(caller common.Address, input []byte) common.Hash {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(caller.Bytes())
+	hasher.Write(evm.Context.Origin.Bytes())
+	nonce := new(big.Int).SetUint64(evm.StateDB.GetNonce(evm.Context.Origin))
+	hasher.Write(nonce.Bytes())
+	hasher.Write(input)
+	return common.BytesToHash(hasher.Sum(nil))
+}
+
 // In response to an off-chain Turing request, obtain the requested data and
 // rewrite the parameters so that the contract can be called without reverting.
 // caller is the address of the TuringHelper contract
@@ -346,8 +361,8 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 
 	log.Debug("TURING bobaTuringCall:Caller", "caller", caller.String(), "origin", evm.Context.Origin)
 
-	var responseStringEnc string
 	var responseString []byte
+	settings := getTuringSettings()
 
 	rest := input[4:]
 
@@ -378,36 +393,35 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 	rType := int(rest[31])
 	if rType != 1 {
 		log.Error("TURING bobaTuringCall:Wrong state (rType != 1)", "rType", rType)
-		retError[35] = 10 // Wrong input state
-		return retError, 10
+		retError[35] = turingErrWrongState
+		return retError, turingErrWrongState
 	}
 
 	rlen := len(rest)
 	if rlen < 7*32 {
 		log.Error("TURING bobaTuringCall:Calldata too short", "len < 7*32", rlen)
-		retError[35] = 11 // Calldata too short
-		return retError, 11
+		retError[35] = turingErrCalldataTooShort
+		return retError, turingErrCalldataTooShort
 	}
 
 	// Now check for a cached result
 
-	hasher := sha3.NewLegacyKeccak256()
-	hasher.Write(caller.Bytes())
-	hasher.Write(evm.Context.Origin.Bytes())
-	nonce := new(big.Int).SetUint64(evm.StateDB.GetNonce(evm.Context.Origin))
-	hasher.Write(nonce.Bytes())
-	hasher.Write(input)
-	key := common.BytesToHash(hasher.Sum(nil))
+	key := evm.turingSeed(caller, input)
 
 	log.Debug("TURING Cache key", "key", key, "mayBlock", mayBlock)
 
 	ret := tCache.Get(key)
 
 	if len(ret) != 0 {
+		turingCacheHitsCounter.Inc(1)
+		if host := turingHost(turingURL(rest)); host != "" && settings.cfg.allowed(host) {
+			endpointMetrics(settings.cfg.metricsLabel(host)).cacheHits.Inc(1)
+		}
 		return ret, 0
 	}
 
 	if len(ret) == 0 {
+		turingCacheMissesCounter.Inc(1)
 		log.Debug("TURING Missing cache entry", "mayBlock", mayBlock)
 		if mayBlock {
 			// Since no Boba credit is consumed in an estimateGas call, we put a
@@ -417,8 +431,8 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 			//copy(errVal, retError)
 			tCache.Put(key, retError)
 		} else {
-			retError[35] = 20 // Missing cache entry
-			return retError, 20
+			retError[35] = turingErrMissingCache
+			return retError, turingErrMissingCache
 		}
 	}
 
@@ -435,10 +449,6 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 	// ??? - ??? = payload length
 	// ??? - end = payload
 
-	startIDXurl := int(rest[63]) + 32
-	// the +32 means that we are going directly for the actual string
-	// bytes 0 to 31 are the string length
-
 	startIDXpayload := int(rest[95]) // the start of the payload
 	lengthURL := int(rest[127])      // the length of the URL string
 
@@ -446,14 +456,12 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 	// Note: we do not handle URLs that are longer than 64 characters
 	if lengthURL > 64 {
 		log.Error("TURING bobaTuringCall:URL > 64", "urlLength", lengthURL)
-		retError[35] = 12 // URL string > 64 bytes
-		return retError, 12
+		retError[35] = turingErrURLTooLong
+		return retError, turingErrURLTooLong
 	}
 
 	// The URL we are going to query
-	endIDX := startIDXurl + lengthURL
-	url := string(rest[startIDXurl:endIDX])
-	// we use a specific end value (startIDXurl+lengthURL) since the URL is right-packed with zeros
+	url := turingURL(rest)
 
 	// At this point, we have the API endpoint and the payload that needs to go there...
 	payload := restHexUtil[startIDXpayload:] //using hex here since that makes it easy to get the string
@@ -462,57 +470,71 @@ func (evm *EVM) bobaTuringCall(input []byte, caller common.Address, mayBlock boo
 		"url", url,
 		"payload", payload)
 
-	client, err := rpc.Dial(url)
-
-	if client != nil {
-		startT := time.Now()
-		log.Debug("TURING bobaTuringCall:Calling off-chain client at", "url", url)
-		err := client.CallTimeout(&responseStringEnc, caller.String(), time.Duration(1200)*time.Millisecond, payload)
-		if err != nil {
-			log.Error("TURING bobaTuringCall:Client error", "err", err)
-			retError[35] = 13 // Client Error
-			return retError, 13
-		}
-		if len(responseStringEnc) > 322 {
-			log.Error("TURING bobaTuringCall:Raw response too long (> 322)", "length", len(responseStringEnc), "responseStringEnc", responseStringEnc)
-			retError[35] = 17 // Raw Response too long
-			return retError, 17
-		}
-		responseString, err = hexutil.Decode(responseStringEnc)
-		if err != nil {
-			log.Error("TURING bobaTuringCall:Error decoding responseString", "err", err)
-			retError[35] = 14 // Client Response Decode Error
-			return retError, 14
-		}
-		// if we get back, for example,
-		// 0x
-		// 0000000000000000000000000000000000000000000000000000000000000040
-		// 0000000000000000000000000000000000000000000000000000000000418b95
-		// 0000000000000000000000000000000000000000000000000000017e60d3b45f
-		// this leads to len(responseString) of 3*32 = 96
-		// let's cap the byte payload at 32 + 4*32 = 160 - this allows encoding of 4 uint256
-		// Security perspective - we locally construct the revised calldata, EXCEPT the last field
-		// the `bytes memory _payload`, which is limited to 160 bytes max
-		// Garbage-in scenario: Assuming the payload is filled with garbage, this will break downstream
-		// abi.decode(encResponse,(uint256))'s for example, but that's a problem at the contract level not at the Geth level
-		// DDOS scenario: Assuming the payload is filled with lots of garbage, this will burn ETH
-		// reflecting the cost of storing junk on L1.
-		// Evil-in scenario: Assume a long / specially crafted payload is returned from the external API
-		// In this attack, the idea would be to break client.Call as it is trying to pack the response into &responseStringEnc
-		// Alternatively, could attack hexutil.Decode
-		if len(responseString) > 160 {
-			log.Error("TURING bobaTuringCall:Response too big (> 160 bytes)", "length", len(responseString), "responseString", responseString)
-			retError[35] = 18 // Response too big
-			return retError, 18
-		}
-		t := time.Now()
-		elapsed := t.Sub(startT)
-		log.Debug("TURING API response time", "elapsed", elapsed)
-	} else {
+	host := turingHost(url)
+	if !settings.cfg.allowed(host) {
+		log.Error("TURING bobaTuringCall:Endpoint not allowed", "url", url)
+		turingNotAllowedCounter.Inc(1)
+		retError[35] = turingErrNotAllowed
+		return retError, turingErrNotAllowed
+	}
+	endpoint := endpointMetrics(settings.cfg.metricsLabel(host))
+	fail := func(code int) (hexutil.Bytes, int) {
+		endpoint.markError(code)
+		retError[35] = byte(code)
+		return retError, code
+	}
+
+	if !settings.acquire() {
+		log.Error("TURING bobaTuringCall:Too many concurrent calls", "limit", settings.cfg.MaxConcurrentCalls)
+		turingTooManyCounter.Inc(1)
+		return fail(turingErrTooManyCalls)
+	}
+	startT := time.Now()
+	log.Debug("TURING bobaTuringCall:Calling off-chain client at", "url", url)
+	turingRequestsCounter.Inc(1)
+	ctx, cancel := context.WithTimeout(context.Background(), settings.cfg.Timeout)
+	responseStringEnc, err := settings.cfg.Transport.Call(ctx, url, caller.String(), payload)
+	cancel()
+	settings.release()
+	endpoint.latency.UpdateSince(startT)
+	if errors.Is(err, errTuringCreateClient) {
 		log.Error("TURING bobaTuringCall:Failed to create client for off-chain request", "err", err)
-		retError[35] = 15 // Could not create client
-		return retError, 15
+		return fail(turingErrCreateClient)
+	}
+	if err != nil {
+		log.Error("TURING bobaTuringCall:Client error", "err", err)
+		return fail(turingErrClient)
+	}
+	if len(responseStringEnc) > settings.cfg.MaxRawResponseLength {
+		log.Error("TURING bobaTuringCall:Raw response too long", "length", len(responseStringEnc), "limit", settings.cfg.MaxRawResponseLength, "responseStringEnc", responseStringEnc)
+		return fail(turingErrRawTooLong)
 	}
+	responseString, err = hexutil.Decode(responseStringEnc)
+	if err != nil {
+		log.Error("TURING bobaTuringCall:Error decoding responseString", "err", err)
+		return fail(turingErrDecode)
+	}
+	// if we get back, for example,
+	// 0x
+	// 0000000000000000000000000000000000000000000000000000000000000040
+	// 0000000000000000000000000000000000000000000000000000000000418b95
+	// 0000000000000000000000000000000000000000000000000000017e60d3b45f
+	// this leads to len(responseString) of 3*32 = 96
+	// by default, the byte payload is capped at 32 + 4*32 = 160 - this allows encoding of 4 uint256
+	// Security perspective - we locally construct the revised calldata, EXCEPT the last field
+	// the `bytes memory _payload`, which is limited to MaxResponseLength bytes
+	// Garbage-in scenario: Assuming the payload is filled with garbage, this will break downstream
+	// abi.decode(encResponse,(uint256))'s for example, but that's a problem at the contract level not at the Geth level
+	// DDOS scenario: Assuming the payload is filled with lots of garbage, this will burn ETH
+	// reflecting the cost of storing junk on L1.
+	// Evil-in scenario: Assume a long / specially crafted payload is returned from the external API
+	// In this attack, the idea would be to break the transport as it is trying to pack the response into responseStringEnc
+	// Alternatively, could attack hexutil.Decode
+	if len(responseString) > settings.cfg.MaxResponseLength {
+		log.Error("TURING bobaTuringCall:Response too big", "length", len(responseString), "limit", settings.cfg.MaxResponseLength, "responseString", responseString)
+		return fail(turingErrResponseTooBig)
+	}
+	log.Debug("TURING API response time", "elapsed", time.Since(startT))
 
 	log.Debug("TURING bobaTuringCall:Have valid response from offchain API",
 		"Target", url,
@@ -661,6 +683,8 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 			log.Error("TURING bobaTuringCall:Insufficient credit")
 			return nil, gas, ErrInsufficientBalance
 		}
+		evm.Context.TuringRequest = common.CopyBytes(input)
+		evm.Context.TuringHelper = caller.Address()
 		log.Debug("TURING REQUEST END", "updated_input", updated_input)
 	} else {
 		ret, err = run(evm, contract, input, false)
@@ -823,7 +847,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if !evm.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, common.Address{}, gas, ErrInsufficientBalance
 	}
-	if rcfg.UsingOVM {
+	if evm.chainConfig.UsingOVM() {
 		// Make sure the creator address should be able to deploy.
 		if !evm.AddressWhitelisted(caller.Address()) {
 			// Try to encode this error as a Solidity error message so it's more clear to end-users
@@ -33,6 +33,7 @@ type StateDB interface {
 
 	TuringCharge(userID common.Address) error
 	TuringCheck(userID common.Address) error
+	GetTuringRevenue() *big.Int
 
 	SubBobaBalance(common.Address, *big.Int)
 	AddBobaBalance(common.Address, *big.Int)
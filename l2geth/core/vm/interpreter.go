@@ -24,7 +24,6 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/common"
 	"github.com/ethereum-optimism/optimism/l2geth/common/math"
 	"github.com/ethereum-optimism/optimism/l2geth/log"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 // Config are the configuration options for the Interpreter
@@ -98,7 +97,7 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 			jt = berlinInstructionSet
 		case evm.chainRules.IsIstanbul:
 			jt = istanbulInstructionSet
-			if rcfg.UsingOVM {
+			if evm.chainConfig.UsingOVM() {
 				enableMinimal2929(&jt)
 			}
 		case evm.chainRules.IsConstantinople:
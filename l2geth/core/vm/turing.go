@@ -0,0 +1,344 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/metrics"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+// Turing error codes are written to the rType of the calldata (byte 35) so
+// that a "require" in the TuringHelper contract fails
+const (
+	turingErrWrongState       = 10 // Wrong input state
+	turingErrCalldataTooShort = 11 // Calldata too short
+	turingErrURLTooLong       = 12 // URL string > 64 bytes
+	turingErrClient           = 13 // Client Error
+	turingErrDecode           = 14 // Client Response Decode Error
+	turingErrCreateClient     = 15 // Could not create client
+	turingErrRandom           = 16 // RNG Failure
+	turingErrRawTooLong       = 17 // Raw Response too long
+	turingErrResponseTooBig   = 18 // Response too big
+	turingErrNotAllowed       = 19 // Endpoint not allowed
+	turingErrMissingCache     = 20 // Missing cache entry
+	turingErrTooManyCalls     = 21 // Too many concurrent calls
+)
+
+// turingErrText describes the Turing error codes
+var turingErrText = map[int]string{
+	turingErrWrongState:       "wrong input state",
+	turingErrCalldataTooShort: "calldata too short",
+	turingErrURLTooLong:       "URL too long",
+	turingErrClient:           "client error",
+	turingErrDecode:           "client response decode error",
+	turingErrCreateClient:     "could not create client",
+	turingErrRandom:           "random number generation failure",
+	turingErrRawTooLong:       "raw response too long",
+	turingErrResponseTooBig:   "response too big",
+	turingErrNotAllowed:       "endpoint not allowed",
+	turingErrMissingCache:     "missing cache entry",
+	turingErrTooManyCalls:     "too many concurrent calls",
+}
+
+// TuringStatus returns the rType of the modified calldata of a Turing
+// request, which is 2 for a valid response and a Turing error code
+// otherwise, together with a description of the error
+func TuringStatus(response []byte) (int, string) {
+	if len(response) < 36 {
+		return 0, "response too short"
+	}
+	rType := int(response[35])
+	if rType == 2 {
+		return rType, ""
+	}
+	if text, ok := turingErrText[rType]; ok {
+		return rType, text
+	}
+	return rType, fmt.Sprintf("unknown error %d", rType)
+}
+
+var (
+	turingRequestsCounter    = metrics.NewRegisteredCounter("turing/requests", nil)
+	turingCacheHitsCounter   = metrics.NewRegisteredCounter("turing/cache/hits", nil)
+	turingCacheMissesCounter = metrics.NewRegisteredCounter("turing/cache/misses", nil)
+	turingNotAllowedCounter  = metrics.NewRegisteredCounter("turing/rejected/notallowed", nil)
+	turingTooManyCounter     = metrics.NewRegisteredCounter("turing/rejected/concurrency", nil)
+	turingInflightGauge      = metrics.NewRegisteredGauge("turing/inflight", nil)
+)
+
+// errTuringCreateClient is returned by a TuringTransport when it cannot
+// create a client for an endpoint
+var errTuringCreateClient = errors.New("cannot create turing client")
+
+// TuringTransport performs off-chain Turing requests. The method is the
+// address of the calling TuringHelper contract and the response is the hex
+// encoded ABI payload returned to the contract.
+type TuringTransport interface {
+	Call(ctx context.Context, endpoint string, method string, payload hexutil.Bytes) (string, error)
+}
+
+// HTTPTuringTransport performs Turing requests as JSON-RPC calls over HTTP or
+// websockets
+type HTTPTuringTransport struct{}
+
+// Call dials the endpoint and performs a JSON-RPC call
+func (HTTPTuringTransport) Call(ctx context.Context, endpoint string, method string, payload hexutil.Bytes) (string, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTuringCreateClient, err)
+	}
+	defer client.Close()
+
+	var response string
+	if err := client.CallContext(ctx, &response, method, payload); err != nil {
+		return "", err
+	}
+	return response, nil
+}
+
+// TuringHandler handles a Turing request in process. The context is done
+// once the request times out, and the handler must return when it is.
+type TuringHandler func(ctx context.Context, method string, payload hexutil.Bytes) (string, error)
+
+// LocalTuringTransport dispatches Turing requests to handlers registered in
+// process, which is useful for tests and devnets
+type LocalTuringTransport struct {
+	lock     sync.RWMutex
+	handlers map[string]TuringHandler
+}
+
+// NewLocalTuringTransport creates a LocalTuringTransport without handlers
+func NewLocalTuringTransport() *LocalTuringTransport {
+	return &LocalTuringTransport{handlers: make(map[string]TuringHandler)}
+}
+
+// Register serves the requests to an endpoint with a handler
+func (t *LocalTuringTransport) Register(endpoint string, handler TuringHandler) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.handlers[endpoint] = handler
+}
+
+// Call dispatches the request to the handler of the endpoint
+func (t *LocalTuringTransport) Call(ctx context.Context, endpoint string, method string, payload hexutil.Bytes) (string, error) {
+	t.lock.RLock()
+	handler, ok := t.handlers[endpoint]
+	t.lock.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errTuringCreateClient, endpoint)
+	}
+	type result struct {
+		response string
+		err      error
+	}
+	// The handler runs in its own goroutine so that the request returns on
+	// timeout, the goroutine exits once the handler notices the context
+	done := make(chan result, 1)
+	go func() {
+		response, err := handler(ctx, method, payload)
+		done <- result{response, err}
+	}()
+	select {
+	case res := <-done:
+		return res.response, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// TuringConfig configures the off-chain requests of the sequencer
+type TuringConfig struct {
+	// Transport used to perform requests, HTTPTuringTransport if nil
+	Transport TuringTransport
+	// Hosts that may be called, all hosts if empty. An entry starting with
+	// a dot also matches the subdomains of the host. Metrics are recorded
+	// per entry, requests to other hosts are recorded together.
+	AllowedHosts []string
+	// Hosts that may not be called, which take precedence over AllowedHosts
+	DeniedHosts []string
+	// Timeout of a single request
+	Timeout time.Duration
+	// Maximum length of the hex encoded response
+	MaxRawResponseLength int
+	// Maximum length of the decoded response
+	MaxResponseLength int
+	// Maximum number of requests in flight, unlimited if zero
+	MaxConcurrentCalls int
+	// Provider of the random numbers of GetRandom requests,
+	// CryptoRandomnessProvider if nil
+	Randomness RandomnessProvider
+}
+
+// DefaultTuringConfig contains the default Turing settings
+var DefaultTuringConfig = TuringConfig{
+	Timeout: 1200 * time.Millisecond,
+	// 0x + 2 * 160
+	MaxRawResponseLength: 322,
+	// 32 + 4*32 allows the encoding of 4 uint256
+	MaxResponseLength: 160,
+}
+
+// turingSettings holds the active TuringConfig with its concurrency limit
+type turingSettings struct {
+	cfg       TuringConfig
+	semaphore chan struct{}
+}
+
+var turingActive atomic.Value
+
+func init() {
+	SetTuringConfig(DefaultTuringConfig)
+}
+
+// SetTuringConfig replaces the configuration of Turing requests. Zero
+// timeouts and lengths are replaced with their defaults.
+func SetTuringConfig(cfg TuringConfig) {
+	if cfg.Transport == nil {
+		cfg.Transport = HTTPTuringTransport{}
+	}
+	if cfg.Randomness == nil {
+		cfg.Randomness = CryptoRandomnessProvider{}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTuringConfig.Timeout
+	}
+	if cfg.MaxRawResponseLength <= 0 {
+		cfg.MaxRawResponseLength = DefaultTuringConfig.MaxRawResponseLength
+	}
+	if cfg.MaxResponseLength <= 0 {
+		cfg.MaxResponseLength = DefaultTuringConfig.MaxResponseLength
+	}
+	settings := &turingSettings{cfg: cfg}
+	if cfg.MaxConcurrentCalls > 0 {
+		settings.semaphore = make(chan struct{}, cfg.MaxConcurrentCalls)
+	}
+	turingActive.Store(settings)
+}
+
+// getTuringSettings returns the active Turing settings
+func getTuringSettings() *turingSettings {
+	return turingActive.Load().(*turingSettings)
+}
+
+// turingURL returns the URL string of the calldata of a Turing request
+// without its methodID, or the empty string if it is malformed. The URL
+// string is right-packed with zeros.
+func turingURL(rest []byte) string {
+	// the +32 means that we are going directly for the actual string
+	// bytes 0 to 31 are the string length
+	startIDXurl := int(rest[63]) + 32
+	lengthURL := int(rest[127])
+	if lengthURL > 64 || startIDXurl+lengthURL > len(rest) {
+		return ""
+	}
+	return string(rest[startIDXurl : startIDXurl+lengthURL])
+}
+
+// turingHost returns the host of an endpoint, or the empty string if the
+// endpoint cannot be parsed
+func turingHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// matchHost reports whether a host matches any of the patterns
+func matchHost(host string, patterns []string) bool {
+	return matchingPattern(host, patterns) != ""
+}
+
+// matchingPattern returns the first of the patterns that matches the host,
+// or the empty string if none does
+func matchingPattern(host string, patterns []string) string {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if host == pattern || (strings.HasPrefix(pattern, ".") && (strings.HasSuffix(host, pattern) || host == pattern[1:])) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// allowed reports whether requests may be sent to the host
+func (cfg *TuringConfig) allowed(host string) bool {
+	if host == "" || matchHost(host, cfg.DeniedHosts) {
+		return false
+	}
+	return len(cfg.AllowedHosts) == 0 || matchHost(host, cfg.AllowedHosts)
+}
+
+// metricsLabel returns the label that the requests to the host are recorded
+// under. Only the entries of the allow list are labelled, since the hosts
+// are chosen by the callers of the contracts.
+func (cfg *TuringConfig) metricsLabel(host string) string {
+	if pattern := matchingPattern(host, cfg.AllowedHosts); pattern != "" {
+		return pattern
+	}
+	return "other"
+}
+
+// acquire takes a slot for a request, returning false if the maximum number
+// of concurrent requests is in flight
+func (s *turingSettings) acquire() bool {
+	if s.semaphore == nil {
+		return true
+	}
+	select {
+	case s.semaphore <- struct{}{}:
+		turingInflightGauge.Update(int64(len(s.semaphore)))
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees a slot taken with acquire
+func (s *turingSettings) release() {
+	if s.semaphore != nil {
+		<-s.semaphore
+		turingInflightGauge.Update(int64(len(s.semaphore)))
+	}
+}
+
+// turingEndpointMetrics records the requests to the hosts of a metrics label
+type turingEndpointMetrics struct {
+	latency   metrics.Timer
+	cacheHits metrics.Counter
+	prefix    string
+}
+
+// endpointMetrics returns the metrics of a label returned by metricsLabel
+func endpointMetrics(label string) *turingEndpointMetrics {
+	prefix := "turing/endpoints/" + metricsName(label)
+	return &turingEndpointMetrics{
+		latency:   metrics.GetOrRegisterTimer(prefix+"/latency", nil),
+		cacheHits: metrics.GetOrRegisterCounter(prefix+"/cache/hits", nil),
+		prefix:    prefix,
+	}
+}
+
+// markError counts a failed request by its error code
+func (m *turingEndpointMetrics) markError(code int) {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("%s/errors/%d", m.prefix, code), nil).Inc(1)
+}
+
+// metricsName replaces the characters of a label that are not valid in
+// metric names
+func metricsName(label string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, label)
+}
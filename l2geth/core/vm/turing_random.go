@@ -0,0 +1,110 @@
+package vm
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto/ecvrf"
+)
+
+var (
+	// errInvalidRandomnessProof is returned when a proof of a random number
+	// is malformed or was not produced with the expected key
+	errInvalidRandomnessProof = errors.New("invalid randomness proof")
+	// errRandomnessMismatch is returned when a random number was not derived
+	// from its proof
+	errRandomnessMismatch = errors.New("random number does not match proof")
+)
+
+// randomnessProofLength is the length of a proof of a verifiable random
+// number, the seed followed by the VRF proof of the seed
+const randomnessProofLength = common.HashLength + ecvrf.ProofLength
+
+// RandomnessProvider supplies the random numbers of GetRandom requests. The
+// seed is the hash of the caller, origin, origin nonce and calldata of the
+// request, and the proof is nil if the random number cannot be verified.
+type RandomnessProvider interface {
+	Random(seed common.Hash) (random *big.Int, proof []byte, err error)
+}
+
+// CryptoRandomnessProvider draws random numbers from crypto/rand
+type CryptoRandomnessProvider struct{}
+
+// maxRandom is the exclusive upper bound of the random numbers drawn from
+// crypto/rand, 2^256 - 1
+var maxRandom = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+
+// Random draws a cryptographically strong random number without a proof
+func (CryptoRandomnessProvider) Random(seed common.Hash) (*big.Int, []byte, error) {
+	n, err := rand.Int(rand.Reader, maxRandom)
+	return n, nil, err
+}
+
+// SeededRandomnessProvider derives random numbers from a fixed seed and the
+// seed of the request, so that tests and devnets are deterministic. It must
+// not be used in production.
+type SeededRandomnessProvider struct {
+	seed []byte
+}
+
+// NewSeededRandomnessProvider creates a SeededRandomnessProvider
+func NewSeededRandomnessProvider(seed []byte) *SeededRandomnessProvider {
+	return &SeededRandomnessProvider{seed: common.CopyBytes(seed)}
+}
+
+// Random hashes the fixed seed with the seed of the request
+func (p *SeededRandomnessProvider) Random(seed common.Hash) (*big.Int, []byte, error) {
+	return new(big.Int).SetBytes(crypto.Keccak256(p.seed, seed.Bytes())), nil, nil
+}
+
+// VerifiableRandomnessProvider evaluates a verifiable random function on
+// the seed of each request with a key whose public key is published as a
+// commitment. The function has a single output for each seed, so the provider
+// cannot choose between random numbers, and anyone can verify the proof with
+// VerifyRandomness.
+type VerifiableRandomnessProvider struct {
+	key *ecvrf.PrivateKey
+}
+
+// NewVerifiableRandomnessProvider creates a VerifiableRandomnessProvider
+func NewVerifiableRandomnessProvider(key *ecvrf.PrivateKey) *VerifiableRandomnessProvider {
+	return &VerifiableRandomnessProvider{key: key}
+}
+
+// PublicKey returns the compressed public key that proofs are verified
+// against
+func (p *VerifiableRandomnessProvider) PublicKey() []byte {
+	return p.key.PublicKey()
+}
+
+// Random evaluates the function on the seed and returns its output with the
+// seed and the VRF proof as the proof
+func (p *VerifiableRandomnessProvider) Random(seed common.Hash) (*big.Int, []byte, error) {
+	beta, pi, err := p.key.Prove(seed.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := append(seed.Bytes(), pi...)
+	return new(big.Int).SetBytes(beta), proof, nil
+}
+
+// VerifyRandomness checks that a random number was produced for the seed
+// in the proof by the VerifiableRandomnessProvider with the given public key
+func VerifyRandomness(publicKey []byte, random *big.Int, proof []byte) error {
+	if len(proof) != randomnessProofLength {
+		return fmt.Errorf("%w: length %d", errInvalidRandomnessProof, len(proof))
+	}
+	seed, pi := proof[:common.HashLength], proof[common.HashLength:]
+	beta, err := ecvrf.Verify(publicKey, seed, pi)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidRandomnessProof, err)
+	}
+	if new(big.Int).SetBytes(beta).Cmp(random) != 0 {
+		return errRandomnessMismatch
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package vm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto/ecvrf"
+)
+
+// randomRequest builds the calldata of a GetRandom request
+func randomRequest() []byte {
+	input := []byte{73, 61, 87, 214}
+	input = append(input, common.LeftPadBytes([]byte{1}, 32)...)
+	return append(input, make([]byte, 32)...)
+}
+
+func TestSeededRandomness(t *testing.T) {
+	defer SetTuringConfig(DefaultTuringConfig)
+	SetTuringConfig(TuringConfig{Randomness: NewSeededRandomnessProvider([]byte{0x01})})
+
+	first := newTuringEVM(t).bobaTuringRandom(randomRequest(), common.Address{0x01})
+	second := newTuringEVM(t).bobaTuringRandom(randomRequest(), common.Address{0x01})
+	if first[35] != 2 || !bytes.Equal(first, second) {
+		t.Fatalf("Seeded random numbers are not deterministic: %s, %s", first, second)
+	}
+	// Requests from other callers are seeded differently
+	other := newTuringEVM(t).bobaTuringRandom(randomRequest(), common.Address{0x02})
+	if bytes.Equal(first, other) {
+		t.Fatal("Random numbers do not depend on the request")
+	}
+}
+
+func TestVerifiableRandomness(t *testing.T) {
+	defer SetTuringConfig(DefaultTuringConfig)
+	key, err := ecvrf.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewVerifiableRandomnessProvider(key)
+	SetTuringConfig(TuringConfig{Randomness: provider})
+
+	evm := newTuringEVM(t)
+	ret := evm.bobaTuringRandom(randomRequest(), common.Address{0x01})
+	if ret[35] != 2 {
+		t.Fatalf("Unexpected response: %s", ret)
+	}
+	random := new(big.Int).SetBytes(ret[36:68])
+	proof := evm.Context.TuringProof
+	if err := VerifyRandomness(provider.PublicKey(), random, proof); err != nil {
+		t.Fatal(err)
+	}
+	if seed := evm.turingSeed(common.Address{0x01}, randomRequest()); !bytes.Equal(proof[:common.HashLength], seed.Bytes()) {
+		t.Fatal("Proof does not commit to the seed of the request")
+	}
+	// The provider has a single random number for each seed
+	again := newTuringEVM(t).bobaTuringRandom(randomRequest(), common.Address{0x01})
+	if !bytes.Equal(ret, again) {
+		t.Fatal("Random numbers differ for the same seed")
+	}
+
+	other, err := ecvrf.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyRandomness(other.PublicKey(), random, proof); !errors.Is(err, errInvalidRandomnessProof) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := VerifyRandomness(provider.PublicKey(), new(big.Int).Add(random, common.Big1), proof); !errors.Is(err, errRandomnessMismatch) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tampered := common.CopyBytes(proof)
+	tampered[0] ^= 0xff
+	if err := VerifyRandomness(provider.PublicKey(), random, tampered); err == nil {
+		t.Fatal("Tampered proof verified")
+	}
+	if err := VerifyRandomness(provider.PublicKey(), random, proof[1:]); !errors.Is(err, errInvalidRandomnessProof) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package vm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
+)
+
+// turingRequest builds the calldata of a GetResponse request to an endpoint
+func turingRequest(endpoint string, payload []byte) []byte {
+	word := func(n int) []byte {
+		return common.LeftPadBytes([]byte{byte(n)}, 32)
+	}
+	urlWords := (len(endpoint) + 31) / 32
+	input := []byte{125, 147, 97, 108}
+	input = append(input, word(1)...)
+	input = append(input, word(96)...)
+	input = append(input, word(96+32+32*urlWords)...)
+	input = append(input, word(len(endpoint))...)
+	input = append(input, common.RightPadBytes([]byte(endpoint), 32*urlWords)...)
+	input = append(input, word(len(payload))...)
+	return append(input, common.RightPadBytes(payload, 32)...)
+}
+
+func newTuringEVM(t *testing.T) *EVM {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEVM(Context{Sequencer: true}, statedb, params.TestChainConfig, Config{})
+}
+
+func TestTuringCallTransport(t *testing.T) {
+	defer SetTuringConfig(DefaultTuringConfig)
+
+	transport := NewLocalTuringTransport()
+	caller := common.Address{0x01}
+	response := fmt.Sprintf("0x%064x", 42)
+	transport.Register("http://turing.example.com/api", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		if method != caller.String() {
+			return "", fmt.Errorf("unexpected method %s", method)
+		}
+		return response, nil
+	})
+	transport.Register("http://turing.example.com/big", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		return fmt.Sprintf("0x%0128x", 42), nil
+	})
+	transport.Register("http://turing.example.com/fail", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		return "", errors.New("failure")
+	})
+	SetTuringConfig(TuringConfig{
+		Transport:         transport,
+		AllowedHosts:      []string{".example.com"},
+		DeniedHosts:       []string{"denied.example.com"},
+		MaxResponseLength: 32,
+	})
+	evm := newTuringEVM(t)
+
+	input := turingRequest("http://turing.example.com/api", []byte{0x01})
+	ret, code := evm.bobaTuringCall(input, caller, true)
+	if code != 0 {
+		t.Fatalf("Unexpected error code: %d", code)
+	}
+	if ret[35] != 2 || hexutil.Encode(ret[len(ret)-32:]) != response {
+		t.Fatalf("Unexpected response: %s", ret)
+	}
+	// The response is cached for the sequencer, which may not block
+	if cached, code := evm.bobaTuringCall(input, caller, false); code != 0 || !bytes.Equal(cached, ret) {
+		t.Fatalf("Unexpected cached response: %s, code %d", cached, code)
+	}
+
+	tests := []struct {
+		endpoint string
+		code     int
+	}{
+		{"http://turing.example.com/fail", turingErrClient},
+		{"http://turing.example.com/big", turingErrResponseTooBig},
+		{"http://turing.example.com/unknown", turingErrCreateClient},
+		{"http://denied.example.com/api", turingErrNotAllowed},
+		{"http://turing.example.org/api", turingErrNotAllowed},
+	}
+	for _, test := range tests {
+		ret, code := evm.bobaTuringCall(turingRequest(test.endpoint, []byte{0x01}), caller, true)
+		if code != test.code || int(ret[35]) != test.code {
+			t.Errorf("%s: unexpected error code %d, expected %d", test.endpoint, code, test.code)
+		}
+	}
+}
+
+func TestTuringCallConcurrencyLimit(t *testing.T) {
+	defer SetTuringConfig(DefaultTuringConfig)
+
+	transport := NewLocalTuringTransport()
+	called, unblock := make(chan struct{}), make(chan struct{})
+	transport.Register("http://slow.example.com", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		close(called)
+		<-unblock
+		return "0x", nil
+	})
+	transport.Register("http://fast.example.com", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		return "0x", nil
+	})
+	SetTuringConfig(TuringConfig{Transport: transport, MaxConcurrentCalls: 1})
+
+	done := make(chan int)
+	evm := newTuringEVM(t)
+	go func() {
+		_, code := evm.bobaTuringCall(turingRequest("http://slow.example.com", nil), common.Address{0x02}, true)
+		done <- code
+	}()
+	<-called
+	if _, code := evm.bobaTuringCall(turingRequest("http://fast.example.com", nil), common.Address{0x02}, true); code != turingErrTooManyCalls {
+		t.Fatalf("Unexpected error code: %d", code)
+	}
+	close(unblock)
+	if code := <-done; code != 0 {
+		t.Fatalf("Unexpected error code: %d", code)
+	}
+	if _, code := evm.bobaTuringCall(turingRequest("http://fast.example.com", []byte{0x01}), common.Address{0x02}, true); code != 0 {
+		t.Fatalf("Unexpected error code: %d", code)
+	}
+}
+
+func TestTuringAllowedHosts(t *testing.T) {
+	cfg := TuringConfig{
+		AllowedHosts: []string{"api.example.com", ".boba.network"},
+		DeniedHosts:  []string{"bad.boba.network"},
+	}
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{"api.example.com", true},
+		{"www.example.com", false},
+		{"boba.network", true},
+		{"api.boba.network", true},
+		{"bad.boba.network", false},
+		{"notboba.network", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if allowed := cfg.allowed(test.host); allowed != test.allowed {
+			t.Errorf("%q: allowed %t, expected %t", test.host, allowed, test.allowed)
+		}
+	}
+	if !(&TuringConfig{}).allowed("localhost") {
+		t.Error("Hosts not allowed without allow list")
+	}
+
+	// Metrics are labelled by allow list entry so that callers cannot
+	// register metrics for arbitrary hosts
+	labels := map[string]string{
+		"api.example.com":  "api.example.com",
+		"a.boba.network":   ".boba.network",
+		"b.boba.network":   ".boba.network",
+		"www.example.com":  "other",
+		"attacker.example": "other",
+	}
+	for host, want := range labels {
+		if label := cfg.metricsLabel(host); label != want {
+			t.Errorf("%q: label %q, expected %q", host, label, want)
+		}
+	}
+	if label := (&TuringConfig{}).metricsLabel("localhost"); label != "other" {
+		t.Errorf("Unexpected label without allow list: %q", label)
+	}
+}
+
+func TestTuringCallTimeout(t *testing.T) {
+	defer SetTuringConfig(DefaultTuringConfig)
+
+	transport := NewLocalTuringTransport()
+	exited := make(chan struct{})
+	transport.Register("http://hang.example.com", func(ctx context.Context, method string, payload hexutil.Bytes) (string, error) {
+		defer close(exited)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	SetTuringConfig(TuringConfig{Transport: transport, Timeout: 10 * time.Millisecond})
+
+	evm := newTuringEVM(t)
+	if _, code := evm.bobaTuringCall(turingRequest("http://hang.example.com", nil), common.Address{0x02}, true); code == 0 {
+		t.Fatal("Expected the request to time out")
+	}
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("Handler did not exit after the timeout")
+	}
+}
+
+func TestTuringStatus(t *testing.T) {
+	response := func(rType byte) []byte {
+		data := make([]byte, 4+32)
+		data[35] = rType
+		return data
+	}
+	tests := []struct {
+		response []byte
+		status   int
+		text     string
+	}{
+		{response(2), 2, ""},
+		{response(turingErrNotAllowed), turingErrNotAllowed, "endpoint not allowed"},
+		{response(99), 99, "unknown error 99"},
+		{[]byte{0x01}, 0, "response too short"},
+	}
+	for _, test := range tests {
+		if status, text := TuringStatus(test.response); status != test.status || text != test.text {
+			t.Errorf("%x: status %d %q, expected %d %q", test.response, status, text, test.status, test.text)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Package ecvrf implements the ECVRF-P256-SHA256-TAI verifiable random
+// function of RFC 9381. The output of the function for an input is unique
+// for a key, so the holder of the key cannot choose between outputs, and the
+// proof lets anyone with the public key verify the output.
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+)
+
+const (
+	// suiteString identifies ECVRF-P256-SHA256-TAI
+	suiteString = 0x01
+	// ptLen is the length of a compressed point
+	ptLen = 33
+	// cLen is the length of a challenge
+	cLen = 16
+	// qLen is the length of a scalar
+	qLen = 32
+
+	// ProofLength is the length of a proof, the point Gamma followed by the
+	// challenge and the response
+	ProofLength = ptLen + cLen + qLen
+	// OutputLength is the length of the output of the function
+	OutputLength = sha256.Size
+)
+
+var (
+	// ErrInvalidKey is returned when a private or public key is malformed
+	ErrInvalidKey = errors.New("invalid ECVRF key")
+	// ErrInvalidProof is returned when a proof is malformed or does not
+	// verify against the public key and input
+	ErrInvalidProof = errors.New("invalid ECVRF proof")
+)
+
+var curve = elliptic.P256()
+
+// PrivateKey is a P-256 key that evaluates the function
+type PrivateKey struct {
+	d      *big.Int
+	public []byte
+}
+
+// GenerateKey creates a PrivateKey from the random reader
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(curve, rand)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(key.D.Bytes())
+}
+
+// NewPrivateKey creates a PrivateKey from its big endian scalar
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	k := new(big.Int).SetBytes(d)
+	if len(d) > qLen || k.Sign() == 0 || k.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	x, y := curve.ScalarBaseMult(leftPad(d, qLen))
+	return &PrivateKey{d: k, public: elliptic.MarshalCompressed(curve, x, y)}, nil
+}
+
+// LoadPrivateKey loads a hex encoded PrivateKey from a file
+func LoadPrivateKey(file string) (*PrivateKey, error) {
+	buf := make([]byte, 2*qLen)
+	fd, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	if _, err := io.ReadFull(fd, buf); err != nil {
+		return nil, err
+	}
+	d, err := hex.DecodeString(string(buf))
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(d)
+}
+
+// PublicKey returns the compressed public key that proofs are verified
+// against
+func (k *PrivateKey) PublicKey() []byte {
+	return append([]byte{}, k.public...)
+}
+
+// Prove evaluates the function on alpha and returns the output along with
+// its proof
+func (k *PrivateKey) Prove(alpha []byte) (beta []byte, pi []byte, err error) {
+	hx, hy, err := encodeToCurve(k.public, alpha)
+	if err != nil {
+		return nil, nil, err
+	}
+	hString := elliptic.MarshalCompressed(curve, hx, hy)
+	gx, gy := curve.ScalarMult(hx, hy, leftPad(k.d.Bytes(), qLen))
+
+	nonce := generateNonce(k.d, hString)
+	ux, uy := curve.ScalarBaseMult(leftPad(nonce.Bytes(), qLen))
+	vx, vy := curve.ScalarMult(hx, hy, leftPad(nonce.Bytes(), qLen))
+	yx, yy := elliptic.UnmarshalCompressed(curve, k.public)
+	c := challenge(yx, yy, hx, hy, gx, gy, ux, uy, vx, vy)
+
+	s := new(big.Int).Mul(new(big.Int).SetBytes(c), k.d)
+	s.Add(s, nonce)
+	s.Mod(s, curve.Params().N)
+
+	gamma := elliptic.MarshalCompressed(curve, gx, gy)
+	pi = make([]byte, 0, ProofLength)
+	pi = append(pi, gamma...)
+	pi = append(pi, c...)
+	pi = append(pi, leftPad(s.Bytes(), qLen)...)
+	return proofToHash(gamma), pi, nil
+}
+
+// Verify checks the proof of the output of the function on alpha for the
+// public key and returns the output
+func Verify(public, alpha, pi []byte) ([]byte, error) {
+	yx, yy := elliptic.UnmarshalCompressed(curve, public)
+	if yx == nil {
+		return nil, ErrInvalidKey
+	}
+	if len(pi) != ProofLength {
+		return nil, ErrInvalidProof
+	}
+	gamma := pi[:ptLen]
+	gx, gy := elliptic.UnmarshalCompressed(curve, gamma)
+	if gx == nil {
+		return nil, ErrInvalidProof
+	}
+	c, s := pi[ptLen:ptLen+cLen], pi[ptLen+cLen:]
+	if new(big.Int).SetBytes(s).Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidProof
+	}
+	hx, hy, err := encodeToCurve(public, alpha)
+	if err != nil {
+		return nil, err
+	}
+	// U = s*B - c*Y and V = s*H - c*Gamma
+	sbx, sby := curve.ScalarBaseMult(s)
+	cyx, cyy := curve.ScalarMult(yx, yy, c)
+	ux, uy := curve.Add(sbx, sby, cyx, negate(cyy))
+	shx, shy := curve.ScalarMult(hx, hy, s)
+	cgx, cgy := curve.ScalarMult(gx, gy, c)
+	vx, vy := curve.Add(shx, shy, cgx, negate(cgy))
+
+	if !bytes.Equal(c, challenge(yx, yy, hx, hy, gx, gy, ux, uy, vx, vy)) {
+		return nil, ErrInvalidProof
+	}
+	return proofToHash(gamma), nil
+}
+
+// encodeToCurve hashes the input to a point with the try and increment
+// method, salted with the public key
+func encodeToCurve(public, alpha []byte) (*big.Int, *big.Int, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha256.New()
+		h.Write([]byte{suiteString, 0x01})
+		h.Write(public)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), 0x00})
+		x, y := elliptic.UnmarshalCompressed(curve, h.Sum([]byte{0x02}))
+		if x != nil {
+			return x, y, nil
+		}
+	}
+	return nil, nil, errors.New("cannot encode ECVRF input to the curve")
+}
+
+// generateNonce derives the nonce of a proof from the private key and the
+// encoded input as specified by RFC 6979
+func generateNonce(d *big.Int, hString []byte) *big.Int {
+	n := curve.Params().N
+	h1 := sha256.Sum256(hString)
+	z := new(big.Int).SetBytes(h1[:])
+	z.Mod(z, n)
+	bx := append(leftPad(d.Bytes(), qLen), leftPad(z.Bytes(), qLen)...)
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	v := bytes.Repeat([]byte{0x01}, sha256.Size)
+	k := make([]byte, sha256.Size)
+	k = mac(k, v, []byte{0x00}, bx)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, bx)
+	v = mac(k, v)
+	for {
+		v = mac(k, v)
+		nonce := new(big.Int).SetBytes(v)
+		if nonce.Sign() > 0 && nonce.Cmp(n) < 0 {
+			return nonce
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+// challenge hashes the points of a proof into a challenge
+func challenge(points ...*big.Int) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, 0x02})
+	for i := 0; i < len(points); i += 2 {
+		h.Write(elliptic.MarshalCompressed(curve, points[i], points[i+1]))
+	}
+	h.Write([]byte{0x00})
+	return h.Sum(nil)[:cLen]
+}
+
+// proofToHash derives the output of the function from the point Gamma of a
+// proof. The cofactor of P-256 is one.
+func proofToHash(gamma []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, 0x03})
+	h.Write(gamma)
+	h.Write([]byte{0x00})
+	return h.Sum(nil)
+}
+
+// negate returns the y coordinate of the negation of a point
+func negate(y *big.Int) *big.Int {
+	return new(big.Int).Sub(curve.Params().P, y)
+}
+
+// leftPad pads a big endian integer with zeros to the given length
+func leftPad(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b
+	}
+	return append(make([]byte, n-len(b)), b...)
+}
@@ -0,0 +1,81 @@
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// TestVector checks the ECVRF-P256-SHA256-TAI example of RFC 9381 whose key
+// is the P-256 key of RFC 6979
+func TestVector(t *testing.T) {
+	d, _ := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	key, err := NewPrivateKey(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, _ := hex.DecodeString("0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6")
+	if !bytes.Equal(key.PublicKey(), public) {
+		t.Fatalf("Unexpected public key: %x", key.PublicKey())
+	}
+	wantPi, _ := hex.DecodeString("035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4" +
+		"a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f")
+	wantBeta, _ := hex.DecodeString("a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e")
+
+	beta, pi, err := key.Prove([]byte("sample"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pi, wantPi) {
+		t.Fatalf("Unexpected proof: %x", pi)
+	}
+	if !bytes.Equal(beta, wantBeta) {
+		t.Fatalf("Unexpected output: %x", beta)
+	}
+	if beta, err = Verify(public, []byte("sample"), pi); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(beta, wantBeta) {
+		t.Fatalf("Unexpected verified output: %x", beta)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("alpha")
+	_, pi, err := key.Prove(alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(other.PublicKey(), alpha, pi); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("Proof verified with another key: %v", err)
+	}
+	if _, err := Verify(key.PublicKey(), []byte("beta"), pi); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("Proof verified for another input: %v", err)
+	}
+	for i := 0; i < ProofLength; i += 8 {
+		tampered := append([]byte{}, pi...)
+		tampered[i] ^= 0x01
+		if _, err := Verify(key.PublicKey(), alpha, tampered); err == nil {
+			t.Fatalf("Proof tampered at byte %d verified", i)
+		}
+	}
+	if _, err := Verify(key.PublicKey(), alpha, pi[1:]); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := Verify([]byte{0x02}, alpha, pi); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := NewPrivateKey(make([]byte, qLen)); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
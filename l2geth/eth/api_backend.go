@@ -35,9 +35,9 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/eth/gasprice"
 	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
 	"github.com/ethereum-optimism/optimism/l2geth/event"
+	"github.com/ethereum-optimism/optimism/l2geth/internal/ethapi"
 	"github.com/ethereum-optimism/optimism/l2geth/log"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 	"github.com/ethereum-optimism/optimism/l2geth/rpc"
 )
 
@@ -88,6 +88,43 @@ func (b *EthAPIBackend) GetRollupContext() (uint64, uint64, uint64) {
 	return index, queueIndex, verifiedIndex
 }
 
+func (b *EthAPIBackend) GetStateDivergence() ethapi.StateDivergenceInfo {
+	status := b.eth.syncService.GetStateDivergence()
+	divergences := make([]ethapi.StateDivergence, len(status.Divergences))
+	for i, divergence := range status.Divergences {
+		divergences[i] = ethapi.StateDivergence{
+			Index:      divergence.Index,
+			BatchIndex: divergence.BatchIndex,
+			PostedRoot: divergence.PostedRoot,
+			LocalRoot:  divergence.LocalRoot,
+		}
+	}
+	return ethapi.StateDivergenceInfo{
+		Enabled:      status.Enabled,
+		Halted:       status.Halted,
+		CheckedIndex: status.CheckedIndex,
+		Divergences:  divergences,
+	}
+}
+
+func (b *EthAPIBackend) GetSyncStatus() (*ethapi.SyncStatusInfo, error) {
+	status := b.eth.syncService.GetHealthStatus()
+	return &ethapi.SyncStatusInfo{
+		Healthy:          status.Healthy,
+		Syncing:          status.Syncing,
+		LocalIndex:       status.LocalIndex,
+		RemoteIndex:      status.RemoteIndex,
+		IndexLag:         status.IndexLag,
+		VerifiedIndex:    status.VerifiedIndex,
+		LocalQueueIndex:  status.LocalQueueIndex,
+		RemoteQueueIndex: status.RemoteQueueIndex,
+		QueueLag:         status.QueueLag,
+		L1ContextAge:     status.L1ContextAge,
+		LastAppliedAge:   status.LastAppliedAge,
+		Problems:         status.Problems,
+	}, nil
+}
+
 // ChainConfig returns the active chain configuration.
 func (b *EthAPIBackend) ChainConfig() *params.ChainConfig {
 	return b.eth.blockchain.Config()
@@ -258,14 +295,14 @@ func (b *EthAPIBackend) GetEVM(ctx context.Context, msg core.Message, state *sta
 	// https://github.com/ethereum-optimism/optimism/l2geth/commit/39f502329fac4640cfb71959c3496f19ea88bc85#diff-9886da3412b43831145f62cec6e895eb3613a175b945e5b026543b7463454603
 	// We're throwing this behind a UsingOVM flag for now as to not break
 	// any tests that may depend on this behavior.
-	if !rcfg.UsingOVM {
+	if !b.UsingOVM {
 		state.SetBalance(msg.From(), math.MaxBig256)
 	}
 	vmError := func() error { return nil }
 	if vmCfg == nil {
 		vmCfg = b.eth.blockchain.GetVMConfig()
 	}
-	context := core.NewEVMContext(msg, header, b.eth.BlockChain(), nil)
+	context := core.NewEVMContext(msg, header, b.eth.BlockChain(), b.ChainConfig(), nil)
 	return vm.NewEVM(context, state, b.eth.blockchain.Config(), *vmCfg), vmError, nil
 }
 
@@ -309,7 +346,7 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 				return fmt.Errorf("Calldata cannot be larger than %d, sent %d", b.MaxCallDataSize, len(signedTx.Data()))
 			}
 		}
-		return b.eth.syncService.ValidateAndApplySequencerTransaction(signedTx)
+		return b.eth.syncService.ValidateAndApplySequencerTransaction(ctx, signedTx)
 	}
 	// OVM Disabled
 	return b.eth.txPool.AddLocal(signedTx)
@@ -380,6 +417,14 @@ func (b *EthAPIBackend) SetL2GasPrice(ctx context.Context, gasPrice *big.Int) er
 	return b.rollupGpo.SetL2GasPrice(gasPrice)
 }
 
+func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return b.rollupGpo.SuggestGasTipCap(ctx)
+}
+
+func (b *EthAPIBackend) FeeHistory(ctx context.Context, blocks int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	return b.rollupGpo.FeeHistory(ctx, b, blocks, lastBlock, rewardPercentiles)
+}
+
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.eth.ChainDb()
 }
@@ -181,6 +181,8 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 			}
 		}
 	}
+	statedb.SetOVMConfig(api.eth.blockchain.Config().OVMConfig())
+
 	// Execute all the transaction contained within the chain concurrently for each block
 	blocks := int(end.NumberU64() - origin)
 
@@ -204,8 +206,9 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
+					core.ReplayTuring(api.eth.ChainDb(), tx)
 					msg, _ := tx.AsMessage(signer)
-					vmctx := core.NewEVMContext(msg, task.block.Header(), api.eth.blockchain, nil)
+					vmctx := core.NewEVMContext(msg, task.block.Header(), api.eth.blockchain, api.eth.blockchain.Config(), nil)
 
 					res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
 					if err != nil {
@@ -466,6 +469,11 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 		pend = new(sync.WaitGroup)
 		jobs = make(chan *txTraceTask, len(txs))
 	)
+	// Replay the recorded Turing responses before the transactions are
+	// shared between the tracing threads
+	for _, tx := range txs {
+		core.ReplayTuring(api.eth.ChainDb(), tx)
+	}
 	threads := runtime.NumCPU()
 	if threads > len(txs) {
 		threads = len(txs)
@@ -478,7 +486,7 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 			// Fetch and execute the next transaction trace tasks
 			for task := range jobs {
 				msg, _ := txs[task.index].AsMessage(signer)
-				vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
+				vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, api.eth.blockchain.Config(), nil)
 
 				res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
 				if err != nil {
@@ -497,7 +505,7 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 
 		// Generate the next state snapshot fast without tracing
 		msg, _ := tx.AsMessage(signer)
-		vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
+		vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, api.eth.blockchain.Config(), nil)
 
 		vmenv := vm.NewEVM(vmctx, statedb, api.eth.blockchain.Config(), vm.Config{})
 		if _, _, _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
@@ -584,10 +592,11 @@ func (api *PrivateDebugAPI) standardTraceBlockToFile(ctx context.Context, block
 	}
 
 	for i, tx := range block.Transactions() {
+		core.ReplayTuring(api.eth.ChainDb(), tx)
 		// Prepare the trasaction for un-traced execution
 		var (
 			msg, _ = tx.AsMessage(signer)
-			vmctx  = core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
+			vmctx  = core.NewEVMContext(msg, block.Header(), api.eth.blockchain, api.eth.blockchain.Config(), nil)
 
 			vmConf vm.Config
 			dump   *os.File
@@ -681,6 +690,8 @@ func (api *PrivateDebugAPI) computeStateDB(block *types.Block, reexec uint64) (*
 			return nil, err
 		}
 	}
+	statedb.SetOVMConfig(api.eth.blockchain.Config().OVMConfig())
+
 	// State was available at historical point, regenerate
 	var (
 		start  = time.Now()
@@ -758,15 +769,17 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 				return nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
+		// Constuct the native or JavaScript tracer to execute with
+		if native, ok := tracers.NewNative(*config.Tracer); ok {
+			tracer = native
+		} else if tracer, err = tracers.New(*config.Tracer); err != nil {
 			return nil, err
 		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			tracer.(interface{ Stop(error) }).Stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
 
@@ -790,12 +803,19 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 	// Run the transaction with tracing enabled.
 	vmenv := vm.NewEVM(vmctx, statedb, chainConfig, vm.Config{Debug: true, Tracer: tracer})
 
-	ret, gas, failed, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
+	if native, ok := tracer.(tracers.NativeTracer); ok {
+		native.CaptureTxStart(vmenv, message)
+	}
+	ret, gas, failed, fees, err := core.ApplyMessageWithFees(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
 	if err != nil {
 		return nil, fmt.Errorf("tracing failed: %v", err)
 	}
 	// Depending on the tracer type, format and return the output
 	switch tracer := tracer.(type) {
+	case tracers.NativeTracer:
+		tracer.CaptureTxEnd(fees)
+		return tracer.GetResult()
+
 	case *vm.StructLogger:
 		return &ethapi.ExecutionResult{
 			Gas:         gas,
@@ -837,8 +857,9 @@ func (api *PrivateDebugAPI) computeTxEnv(blockHash common.Hash, txIndex int, ree
 
 	for idx, tx := range block.Transactions() {
 		// Assemble the transaction call message and return if the requested offset
+		core.ReplayTuring(api.eth.ChainDb(), tx)
 		msg, _ := tx.AsMessage(signer)
-		context := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
+		context := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, api.eth.blockchain.Config(), nil)
 		if idx == txIndex {
 			return msg, context, statedb, nil
 		}
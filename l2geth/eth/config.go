@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/miner"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 // DefaultConfig contains default settings for use on the Ethereum main net.
@@ -80,6 +81,18 @@ var DefaultConfig = Config{
 		// is additional overhead that is unaccounted. Round down to 127000 for
 		// safety.
 		MaxCallDataSize: 127000,
+		// Fetch elements ahead of applying them to hide the latency of the
+		// remote server while syncing
+		SyncConcurrency: 8,
+		// Wait for as many layer one confirmations as the data transport
+		// layer does by default before ingesting a block
+		L1Confirmations: 35,
+		// The OVM settings default to the environment for backwards
+		// compatibility
+		UsingOVM:                  rcfg.Default.UsingOVM,
+		TuringCreditAddress:       rcfg.Default.TuringCreditAddress,
+		BobaGasPriceOracleAddress: rcfg.Default.BobaGasPriceOracleAddress,
+		L2BobaTokenAddress:        rcfg.Default.L2BobaTokenAddress,
 	},
 }
 
@@ -0,0 +1,188 @@
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+// maxFeeHistory is the maximum number of blocks of a fee history
+const maxFeeHistory = 1024
+
+var (
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errRequestBeyondHead = errors.New("request beyond head block")
+)
+
+// FeeHistoryBackend provides the blocks, receipts and states that fee
+// histories are computed from
+type FeeHistoryBackend interface {
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
+	BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error)
+	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
+}
+
+// FeeHistory returns the fee history of up to maxFeeHistory blocks ending
+// with lastBlock. The base fee of a block is the L2 gas price held by the
+// OVM_GasPriceOracle in the state of its parent, and the rewards are the percentiles of the part of
+// the gas prices above the base fee, weighted by gas used. The base fees
+// include the base fee of the block after lastBlock. Pending blocks are not
+// supported and fall back to the latest block. An error is returned if the
+// state of a block is not available.
+func (gpo *RollupOracle) FeeHistory(ctx context.Context, backend FeeHistoryBackend, blocks int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	if blocks < 1 {
+		return common.Big0, nil, nil, nil, nil
+	}
+	if blocks > maxFeeHistory {
+		blocks = maxFeeHistory
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return common.Big0, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return common.Big0, nil, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	head, err := backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return common.Big0, nil, nil, nil, err
+	}
+	if head == nil {
+		return common.Big0, nil, nil, nil, errors.New("head block not found")
+	}
+	last := head.Number.Uint64()
+	if lastBlock >= 0 {
+		if uint64(lastBlock) > last {
+			return common.Big0, nil, nil, nil, fmt.Errorf("%w: requested %d, head %d", errRequestBeyondHead, lastBlock, last)
+		}
+		last = uint64(lastBlock)
+	}
+	if uint64(blocks) > last+1 {
+		blocks = int(last + 1)
+	}
+	oldest := last + 1 - uint64(blocks)
+
+	var (
+		reward       [][]*big.Int
+		baseFee      = make([]*big.Int, blocks+1)
+		gasUsedRatio = make([]float64, blocks)
+	)
+	if len(rewardPercentiles) != 0 {
+		reward = make([][]*big.Int, blocks)
+	}
+	for i := 0; i < blocks; i++ {
+		number := oldest + uint64(i)
+		parent := number
+		if number > 0 {
+			parent = number - 1
+		}
+		if baseFee[i], err = l2GasPriceAt(ctx, backend, parent); err != nil {
+			return common.Big0, nil, nil, nil, err
+		}
+
+		var header *types.Header
+		if len(rewardPercentiles) != 0 {
+			block, err := backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+			if err != nil {
+				return common.Big0, nil, nil, nil, err
+			}
+			if block == nil {
+				return common.Big0, nil, nil, nil, fmt.Errorf("block %d not found", number)
+			}
+			if reward[i], err = blockRewards(ctx, backend, block, baseFee[i], rewardPercentiles); err != nil {
+				return common.Big0, nil, nil, nil, err
+			}
+			header = block.Header()
+		} else {
+			if header, err = backend.HeaderByNumber(ctx, rpc.BlockNumber(number)); err != nil {
+				return common.Big0, nil, nil, nil, err
+			}
+			if header == nil {
+				return common.Big0, nil, nil, nil, fmt.Errorf("block %d not found", number)
+			}
+		}
+		if header.GasLimit > 0 {
+			gasUsedRatio[i] = float64(header.GasUsed) / float64(header.GasLimit)
+		}
+	}
+	if baseFee[blocks], err = l2GasPriceAt(ctx, backend, last); err != nil {
+		return common.Big0, nil, nil, nil, err
+	}
+	return new(big.Int).SetUint64(oldest), reward, baseFee, gasUsedRatio, nil
+}
+
+// l2GasPriceAt reads the L2 gas price from the OVM_GasPriceOracle in the
+// state of a block. The price is in force from the next block on.
+func l2GasPriceAt(ctx context.Context, backend FeeHistoryBackend, number uint64) (*big.Int, error) {
+	statedb, _, err := backend.StateAndHeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, fmt.Errorf("state of block %d not available: %w", number, err)
+	}
+	if statedb == nil {
+		return nil, fmt.Errorf("state of block %d not available", number)
+	}
+	return statedb.GetState(rcfg.L2GasPriceOracleAddress, rcfg.L2GasPriceSlot).Big(), nil
+}
+
+// txGasAndReward is the gas used by a transaction and the part of its gas
+// price above the base fee
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+// blockRewards returns the percentiles of the rewards of the transactions in
+// a block, weighted by gas used
+func blockRewards(ctx context.Context, backend FeeHistoryBackend, block *types.Block, baseFee *big.Int, percentiles []float64) ([]*big.Int, error) {
+	rewards := make([]*big.Int, len(percentiles))
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards, nil
+	}
+	receipts, err := backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("block %d has %d receipts for %d transactions", block.NumberU64(), len(receipts), len(txs))
+	}
+	sorted := make([]txGasAndReward, len(txs))
+	var gasUsed uint64
+	for i, tx := range txs {
+		reward := new(big.Int).Sub(tx.GasPrice(), baseFee)
+		if reward.Sign() < 0 {
+			reward.SetUint64(0)
+		}
+		sorted[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: reward}
+		gasUsed += receipts[i].GasUsed
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].reward.Cmp(sorted[j].reward) < 0
+	})
+	var (
+		index      int
+		sumGasUsed = sorted[0].gasUsed
+	)
+	for i, p := range percentiles {
+		threshold := uint64(float64(gasUsed) * p / 100)
+		for sumGasUsed < threshold && index < len(sorted)-1 {
+			index++
+			sumGasUsed += sorted[index].gasUsed
+		}
+		rewards[i] = new(big.Int).Set(sorted[index].reward)
+	}
+	return rewards, nil
+}
@@ -0,0 +1,140 @@
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+type testFeeHistoryBackend struct {
+	db       state.Database
+	blocks   []*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func (b *testFeeHistoryBackend) block(number rpc.BlockNumber) *types.Block {
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return b.blocks[len(b.blocks)-1]
+	}
+	if int(number) >= len(b.blocks) {
+		return nil
+	}
+	return b.blocks[number]
+}
+
+func (b *testFeeHistoryBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if block := b.block(number); block != nil {
+		return block.Header(), nil
+	}
+	return nil, nil
+}
+
+func (b *testFeeHistoryBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+	block := b.block(number)
+	if block == nil {
+		return nil, nil, errors.New("header not found")
+	}
+	statedb, err := state.New(block.Root(), b.db)
+	return statedb, block.Header(), err
+}
+
+func (b *testFeeHistoryBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	return b.block(number), nil
+}
+
+func (b *testFeeHistoryBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	return b.receipts[hash], nil
+}
+
+// newTestFeeHistoryBackend creates a chain where the OVM_GasPriceOracle
+// holds the L2 gas price l2GasPrices[i] in the state of block i, and block
+// i > 0 contains a single transaction with gas price gasPrices[i-1] that
+// uses 21000 gas
+func newTestFeeHistoryBackend(t *testing.T, l2GasPrices []int64, gasPrices ...int64) *testFeeHistoryBackend {
+	backend := &testFeeHistoryBackend{
+		db:       state.NewDatabase(rawdb.NewMemoryDatabase()),
+		receipts: make(map[common.Hash]types.Receipts),
+	}
+	commit := func(number int) common.Hash {
+		statedb, _ := state.New(common.Hash{}, backend.db)
+		statedb.SetState(rcfg.L2GasPriceOracleAddress, rcfg.L2GasPriceSlot, common.BigToHash(big.NewInt(l2GasPrices[number])))
+		root, err := statedb.Commit(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return root
+	}
+	backend.blocks = append(backend.blocks, types.NewBlock(&types.Header{Number: common.Big0, GasLimit: 42000, Root: commit(0)}, nil, nil, nil))
+	for i, gasPrice := range gasPrices {
+		tx := types.NewTransaction(uint64(i), common.Address{}, common.Big0, 21000, big.NewInt(gasPrice), nil)
+		receipt := &types.Receipt{GasUsed: 21000}
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), GasLimit: 42000, GasUsed: 21000, Root: commit(i + 1)}
+		block := types.NewBlock(header, []*types.Transaction{tx}, nil, []*types.Receipt{receipt})
+		backend.blocks = append(backend.blocks, block)
+		backend.receipts[block.Hash()] = types.Receipts{receipt}
+	}
+	return backend
+}
+
+func TestFeeHistory(t *testing.T) {
+	// The price set in the state of block 3 is in force from block 4 on
+	backend := newTestFeeHistoryBackend(t, []int64{10, 10, 10, 15, 15}, 10, 12, 9, 20)
+	gpo := NewRollupOracle()
+
+	oldest, reward, baseFee, gasUsedRatio, err := gpo.FeeHistory(context.Background(), backend, 3, rpc.LatestBlockNumber, []float64{50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest.Uint64() != 2 {
+		t.Fatalf("Unexpected oldest block: %d", oldest)
+	}
+	expectedBaseFee := []int64{10, 10, 15, 15}
+	if len(baseFee) != len(expectedBaseFee) {
+		t.Fatalf("Unexpected number of base fees: %d", len(baseFee))
+	}
+	for i, fee := range expectedBaseFee {
+		if baseFee[i].Cmp(big.NewInt(fee)) != 0 {
+			t.Errorf("Base fee %d: %d, expected %d", i, baseFee[i], fee)
+		}
+	}
+	// Rewards are the part of the gas price above the base fee
+	expectedReward := []int64{2, 0, 5}
+	for i, r := range expectedReward {
+		if reward[i][0].Cmp(big.NewInt(r)) != 0 {
+			t.Errorf("Reward %d: %d, expected %d", i, reward[i][0], r)
+		}
+		if gasUsedRatio[i] != 0.5 {
+			t.Errorf("Gas used ratio %d: %f", i, gasUsedRatio[i])
+		}
+	}
+
+	// The history is cut at the genesis block
+	oldest, reward, baseFee, _, err = gpo.FeeHistory(context.Background(), backend, 10, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest.Uint64() != 0 || len(baseFee) != 3 || reward != nil {
+		t.Fatalf("Unexpected history: oldest %d, %d base fees, rewards %v", oldest, len(baseFee), reward)
+	}
+
+	if _, _, _, _, err := gpo.FeeHistory(context.Background(), backend, 1, 10, nil); !errors.Is(err, errRequestBeyondHead) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, _, _, err := gpo.FeeHistory(context.Background(), backend, 1, rpc.LatestBlockNumber, []float64{50, 10}); !errors.Is(err, errInvalidPercentile) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Base fees are not made up when the state of a block is unavailable
+	backend.blocks[2] = types.NewBlock(&types.Header{Number: big.NewInt(2), Root: common.Hash{0x01}}, nil, nil, nil)
+	if _, _, _, _, err := gpo.FeeHistory(context.Background(), backend, 2, rpc.LatestBlockNumber, nil); err == nil {
+		t.Fatal("Expected an error for an unavailable state")
+	}
+}
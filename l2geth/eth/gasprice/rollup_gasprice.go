@@ -65,6 +65,13 @@ func (gpo *RollupOracle) SetL2GasPrice(gasPrice *big.Int) error {
 	return nil
 }
 
+// SuggestGasTipCap returns the priority fee to pay on top of the L2 gas
+// price. The L2 gas price is the whole price of L2 gas and transactions
+// are only accepted with gas prices close to it, so there is no tip.
+func (gpo *RollupOracle) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
 // SuggestOverhead returns the cached overhead value from the
 // OVM_GasPriceOracle
 func (gpo *RollupOracle) SuggestOverhead(ctx context.Context) (*big.Int, error) {
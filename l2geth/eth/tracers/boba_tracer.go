@@ -0,0 +1,361 @@
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
+)
+
+// bobaTuringCall is the Turing request of a call
+type bobaTuringCall struct {
+	Helper   *common.Address `json:"helper,omitempty"`
+	Request  hexutil.Bytes   `json:"request"`
+	Response hexutil.Bytes   `json:"response"`
+	// Status is the rType of the response, 2 for a valid response and a
+	// Turing error code otherwise
+	Status hexutil.Uint `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// bobaCallFrame is a call of the call tree reported by the Boba tracer
+type bobaCallFrame struct {
+	Type    string           `json:"type"`
+	From    common.Address   `json:"from"`
+	To      *common.Address  `json:"to,omitempty"`
+	Value   *hexutil.Big     `json:"value,omitempty"`
+	Gas     *hexutil.Uint64  `json:"gas,omitempty"`
+	GasUsed *hexutil.Uint64  `json:"gasUsed,omitempty"`
+	Input   hexutil.Bytes    `json:"input"`
+	Output  *hexutil.Bytes   `json:"output,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Turing  *bobaTuringCall  `json:"turing,omitempty"`
+	Calls   []*bobaCallFrame `json:"calls,omitempty"`
+
+	gasIn   uint64 // gas available before the call opcode
+	gasCost uint64 // cost of the call opcode
+	outOff  uint64 // memory offset of the return data
+	outLen  uint64 // size of the return data
+	hasGas  bool   // whether the gas given to the call is known
+}
+
+// bobaFees is the fee charged for a transaction
+type bobaFees struct {
+	L1Fee     *hexutil.Big `json:"l1Fee"`
+	L2Fee     *hexutil.Big `json:"l2Fee"`
+	BobaFee   *hexutil.Big `json:"bobaFee"`
+	TuringFee *hexutil.Big `json:"turingFee"`
+}
+
+// bobaTraceResult is the output of the Boba tracer
+type bobaTraceResult struct {
+	bobaCallFrame
+	QueueOrigin string `json:"queueOrigin"`
+	// L1MessageSender is the sender of an L1 to L2 message
+	L1MessageSender *common.Address `json:"l1MessageSender,omitempty"`
+	FeeToken        string          `json:"feeToken"`
+	Fees            *bobaFees       `json:"fees,omitempty"`
+}
+
+// bobaTracer is a native call tracer that annotates the call tree with the
+// Turing request of the transaction and the fees charged for it. It follows
+// the call frames like the JavaScript callTracer.
+type bobaTracer struct {
+	env       *vm.EVM
+	callstack []*bobaCallFrame
+	// descended tracks whether we've just descended from an outer call into
+	// an inner call
+	descended bool
+	result    *bobaTraceResult
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newBobaTracer() NativeTracer {
+	return &bobaTracer{
+		callstack: []*bobaCallFrame{{}},
+		result:    &bobaTraceResult{FeeToken: "ETH"},
+	}
+}
+
+// CaptureTxStart records the queue origin and the fee token of the
+// transaction before it is applied.
+func (t *bobaTracer) CaptureTxStart(env *vm.EVM, msg core.Message) {
+	t.env = env
+	t.result.QueueOrigin = msg.QueueOrigin().String()
+	if msg.QueueOrigin() == types.QueueOriginL1ToL2 {
+		// L1 to L2 messages are sent by their L1 message sender
+		sender := msg.From()
+		t.result.L1MessageSender = &sender
+	}
+	if env.ChainConfig().UsingOVM() && env.ChainConfig().IsFeeTokenUpdate(env.BlockNumber) {
+		if env.StateDB.GetFeeTokenSelection(msg.From()).Cmp(common.Big1) == 0 {
+			t.result.FeeToken = "BOBA"
+		}
+	}
+}
+
+// CaptureTxEnd records the fees charged for the transaction after it was
+// applied.
+func (t *bobaTracer) CaptureTxEnd(fees *types.FeeRecord) {
+	if fees != nil {
+		t.result.Fees = &bobaFees{
+			L1Fee:     (*hexutil.Big)(fees.L1Fee),
+			L2Fee:     (*hexutil.Big)(fees.L2Fee),
+			BobaFee:   (*hexutil.Big)(fees.BobaFee),
+			TuringFee: (*hexutil.Big)(fees.TuringFee),
+		}
+	}
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *bobaTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	root := &t.result.bobaCallFrame
+	root.Type = "CALL"
+	if create {
+		root.Type = "CREATE"
+	}
+	root.From = from
+	root.To = &to
+	root.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	root.Gas = uint64Ptr(gas)
+	root.Input = common.CopyBytes(input)
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *bobaTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return nil
+	}
+	if err != nil {
+		return t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		inOff, inLen := stack.Back(1).Uint64(), stack.Back(2).Uint64()
+		t.callstack = append(t.callstack, &bobaCallFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			Input:   memorySlice(memory, inOff, inLen),
+			Value:   (*hexutil.Big)(new(big.Int).Set(stack.Back(0))),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return nil
+
+	case vm.SELFDESTRUCT:
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, &bobaCallFrame{Type: op.String()})
+		return nil
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		// Skip any pre-compile invocations, those are just fancy opcodes
+		to := common.BigToAddress(stack.Back(1))
+		if isPrecompiled(env, to) {
+			return nil
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		inOff, inLen := stack.Back(2+off).Uint64(), stack.Back(3+off).Uint64()
+		call := &bobaCallFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			To:      &to,
+			Input:   memorySlice(memory, inOff, inLen),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  stack.Back(4 + off).Uint64(),
+			outLen:  stack.Back(5 + off).Uint64(),
+		}
+		if off == 1 {
+			call.Value = (*hexutil.Big)(new(big.Int).Set(stack.Back(2)))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return nil
+	}
+	// If we've just descended into an inner call, retrieve its true allowance.
+	// We need to extract it from within the call as there may be funky gas
+	// dynamics with regard to requested and actually given gas.
+	if t.descended {
+		if depth >= len(t.callstack) {
+			call := t.callstack[len(t.callstack)-1]
+			call.Gas = uint64Ptr(gas)
+			call.hasGas = true
+		}
+		t.descended = false
+	}
+	if op == vm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = "execution reverted"
+		return nil
+	}
+	if depth == len(t.callstack)-1 {
+		// Pop off the last call and get the execution results
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		ret := stack.Back(0)
+		if call.Type == "CREATE" || call.Type == "CREATE2" {
+			call.GasUsed = uint64Ptr(call.gasIn - call.gasCost - gas)
+			if ret.Sign() != 0 {
+				to := common.BigToAddress(ret)
+				call.To = &to
+				call.Output = bytesPtr(env.StateDB.GetCode(to))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		} else if call.hasGas {
+			call.GasUsed = uint64Ptr(call.gasIn - call.gasCost + uint64(*call.Gas) - gas)
+			if ret.Sign() != 0 {
+				call.Output = bytesPtr(memorySlice(memory, call.outOff, call.outLen))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		}
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, call)
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *bobaTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	// If the topmost call already reverted, don't handle the additional fault again
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return nil
+	}
+	// Pop off the just failed call
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+
+	// Consume all available gas
+	if call.hasGas {
+		call.GasUsed = call.Gas
+	}
+	// Flatten the failed call into its parent
+	if len(t.callstack) > 0 {
+		parent := t.callstack[len(t.callstack)-1]
+		parent.Calls = append(parent.Calls, call)
+		return nil
+	}
+	// Last call failed too, leave it in the stack
+	t.callstack = append(t.callstack, call)
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *bobaTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	root := &t.result.bobaCallFrame
+	root.GasUsed = uint64Ptr(gasUsed)
+	root.Output = bytesPtr(common.CopyBytes(output))
+	if err != nil {
+		root.Error = err.Error()
+	}
+	return nil
+}
+
+// GetResult returns the annotated call tree, or the reason the tracing was
+// interrupted
+func (t *bobaTracer) GetResult() (json.RawMessage, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return nil, t.reason
+	}
+	root := &t.result.bobaCallFrame
+	if root.Type == "" {
+		return nil, errors.New("no call was traced")
+	}
+	root.Calls = t.callstack[0].Calls
+	if t.callstack[0].Error != "" {
+		root.Error = t.callstack[0].Error
+	}
+	if root.Error != "" {
+		root.Output = nil
+	}
+	if t.env != nil && len(t.env.Context.TuringRequest) > 0 {
+		turing := &bobaTuringCall{
+			Request:  t.env.Context.TuringRequest,
+			Response: t.env.Context.Turing,
+		}
+		status, text := vm.TuringStatus(turing.Response)
+		turing.Status, turing.Error = hexutil.Uint(status), text
+		if helper := t.env.Context.TuringHelper; helper != (common.Address{}) {
+			turing.Helper = &helper
+		}
+		if call := findTuringCall(root, turing); call != nil {
+			call.Turing = turing
+		} else {
+			root.Turing = turing
+		}
+	}
+	return json.Marshal(t.result)
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *bobaTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// findTuringCall returns the call that made a Turing request, which is a
+// call of the TuringHelper contract with the request as input
+func findTuringCall(call *bobaCallFrame, turing *bobaTuringCall) *bobaCallFrame {
+	if bytes.Equal(call.Input, turing.Request) && (turing.Helper == nil || call.From == *turing.Helper) {
+		return call
+	}
+	for _, inner := range call.Calls {
+		if found := findTuringCall(inner, turing); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// isPrecompiled returns whether an address is a precompiled contract at the
+// block of the EVM
+func isPrecompiled(env *vm.EVM, addr common.Address) bool {
+	config := env.ChainConfig()
+	precompiles := vm.PrecompiledContractsHomestead
+	if config.IsByzantium(env.BlockNumber) {
+		precompiles = vm.PrecompiledContractsByzantium
+	}
+	if config.IsIstanbul(env.BlockNumber) {
+		precompiles = vm.PrecompiledContractsIstanbul
+	}
+	if config.IsBerlin(env.BlockNumber) {
+		precompiles = vm.PrecompiledContractsBerlin
+	}
+	_, ok := precompiles[addr]
+	return ok
+}
+
+// memorySlice returns a copy of a range of memory, which is empty if the
+// range is out of bounds
+func memorySlice(memory *vm.Memory, offset, size uint64) []byte {
+	if size == 0 || offset+size < offset || offset+size > uint64(memory.Len()) {
+		return []byte{}
+	}
+	return memory.GetCopy(int64(offset), int64(size))
+}
+
+func uint64Ptr(n uint64) *hexutil.Uint64 {
+	return (*hexutil.Uint64)(&n)
+}
+
+func bytesPtr(b []byte) *hexutil.Bytes {
+	return (*hexutil.Bytes)(&b)
+}
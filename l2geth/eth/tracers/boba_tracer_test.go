@@ -0,0 +1,174 @@
+package tracers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/tests"
+)
+
+// Runs the Boba tracer against the callTracer test harness, the call trees
+// of both tracers must match.
+func TestBobaTracerCallTree(t *testing.T) {
+	files, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), "call_tracer_") {
+			continue
+		}
+		file := file // capture range variable
+		t.Run(camel(strings.TrimSuffix(strings.TrimPrefix(file.Name(), "call_tracer_"), ".json")), func(t *testing.T) {
+			t.Parallel()
+
+			blob, err := ioutil.ReadFile(filepath.Join("testdata", file.Name()))
+			if err != nil {
+				t.Fatalf("failed to read testcase: %v", err)
+			}
+			test := new(callTracerTest)
+			if err := json.Unmarshal(blob, test); err != nil {
+				t.Fatalf("failed to parse testcase: %v", err)
+			}
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(common.FromHex(test.Input), tx); err != nil {
+				t.Fatalf("failed to parse testcase input: %v", err)
+			}
+			signer := types.MakeSigner(test.Genesis.Config, new(big.Int).SetUint64(uint64(test.Context.Number)))
+			origin, _ := signer.Sender(tx)
+
+			context := vm.Context{
+				CanTransfer: core.CanTransfer,
+				Transfer:    core.Transfer,
+				Origin:      origin,
+				Coinbase:    test.Context.Miner,
+				BlockNumber: new(big.Int).SetUint64(uint64(test.Context.Number)),
+				Time:        new(big.Int).SetUint64(uint64(test.Context.Time)),
+				Difficulty:  (*big.Int)(test.Context.Difficulty),
+				GasLimit:    uint64(test.Context.GasLimit),
+				GasPrice:    tx.GasPrice(),
+			}
+			statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), test.Genesis.Alloc)
+
+			tracer, ok := NewNative("bobaTracer")
+			if !ok {
+				t.Fatal("Boba tracer not found")
+			}
+			evm := vm.NewEVM(context, statedb, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
+
+			msg, err := tx.AsMessage(signer)
+			if err != nil {
+				t.Fatalf("failed to prepare transaction for tracing: %v", err)
+			}
+			tracer.CaptureTxStart(evm, msg)
+			_, _, _, fees, err := core.ApplyMessageWithFees(evm, msg, new(core.GasPool).AddGas(tx.Gas()))
+			if err != nil {
+				t.Fatalf("failed to execute transaction: %v", err)
+			}
+			tracer.CaptureTxEnd(fees)
+			res, err := tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			ret := new(callTrace)
+			if err := json.Unmarshal(res, ret); err != nil {
+				t.Fatalf("failed to unmarshal trace result: %v", err)
+			}
+			if !reflect.DeepEqual(ret, test.Result) {
+				t.Fatalf("trace mismatch: \nhave %+v\nwant %+v", ret, test.Result)
+			}
+		})
+	}
+}
+
+func TestBobaTracerAnnotations(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		helper = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		target = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	)
+	alloc := core.GenesisAlloc{
+		// The helper forwards its calldata to the target
+		helper: {
+			Code:    hexutil.MustDecode("0x366000600037600080366000600060cc5af1"),
+			Balance: new(big.Int),
+		},
+		target: {Code: []byte{0x00}, Balance: new(big.Int)},
+	}
+	statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), alloc)
+
+	request := hexutil.MustDecode("0x01020304" + strings.Repeat("00", 31) + "01")
+	msg := types.NewMessage(sender, &helper, 0, new(big.Int), 100000, new(big.Int), request, false, nil, 0, nil, types.QueueOriginL1ToL2)
+	context := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Origin:      sender,
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		Difficulty:  new(big.Int),
+		GasLimit:    1000000,
+		GasPrice:    new(big.Int),
+	}
+	tracer, _ := NewNative("bobaTracer")
+	evm := vm.NewEVM(context, statedb, params.AllEthashProtocolChanges, vm.Config{Debug: true, Tracer: tracer})
+	tracer.CaptureTxStart(evm, msg)
+	if _, _, _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	// Pretend the helper made a Turing request that failed as the endpoint
+	// is not allowed
+	response := common.CopyBytes(request)
+	response[35] = 19
+	evm.Context.TuringRequest = request
+	evm.Context.Turing = response
+	evm.Context.TuringHelper = helper
+
+	fees := types.NewFeeRecord()
+	fees.L1Fee.SetUint64(1)
+	fees.TuringFee.SetUint64(10)
+	tracer.CaptureTxEnd(fees)
+
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	ret := new(bobaTraceResult)
+	if err := json.Unmarshal(res, ret); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if ret.QueueOrigin != types.QueueOriginL1ToL2.String() || ret.L1MessageSender == nil || *ret.L1MessageSender != sender {
+		t.Errorf("unexpected origin %s, L1 message sender %v", ret.QueueOrigin, ret.L1MessageSender)
+	}
+	if ret.FeeToken != "ETH" {
+		t.Errorf("unexpected fee token %s", ret.FeeToken)
+	}
+	if ret.Fees == nil || ret.Fees.L1Fee.ToInt().Uint64() != 1 || ret.Fees.TuringFee.ToInt().Uint64() != 10 {
+		t.Errorf("unexpected fees %+v", ret.Fees)
+	}
+	if len(ret.Calls) != 1 || ret.Calls[0].To == nil || *ret.Calls[0].To != target {
+		t.Fatalf("unexpected calls %+v", ret.Calls)
+	}
+	if ret.Turing != nil {
+		t.Errorf("Turing request annotated on the transaction")
+	}
+	turing := ret.Calls[0].Turing
+	if turing == nil {
+		t.Fatal("Turing request not annotated on the call of the helper")
+	}
+	if turing.Helper == nil || *turing.Helper != helper || turing.Status != 19 || turing.Error != "endpoint not allowed" {
+		t.Errorf("unexpected Turing annotation %+v", turing)
+	}
+}
@@ -18,15 +18,48 @@
 package tracers
 
 import (
+	"encoding/json"
 	"strings"
 	"unicode"
 
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
 	"github.com/ethereum-optimism/optimism/l2geth/eth/tracers/internal/tracers"
 )
 
 // all contains all the built in JavaScript tracers by name.
 var all = make(map[string]string)
 
+// native contains the constructors of the built in Go tracers by name.
+var native = map[string]func() NativeTracer{
+	"bobaTracer": newBobaTracer,
+}
+
+// NativeTracer is a transaction tracer implemented in Go, which is looked up
+// by name like the built in JavaScript tracers.
+type NativeTracer interface {
+	vm.Tracer
+
+	// CaptureTxStart is called with the EVM before the message is applied
+	CaptureTxStart(env *vm.EVM, msg core.Message)
+	// CaptureTxEnd is called with the fees collected from the message after
+	// it was applied
+	CaptureTxEnd(fees *types.FeeRecord)
+	// GetResult returns the result of the tracing
+	GetResult() (json.RawMessage, error)
+	// Stop terminates the tracing at the first opportune moment
+	Stop(err error)
+}
+
+// NewNative creates a built in Go tracer by name.
+func NewNative(name string) (NativeTracer, bool) {
+	if constructor, ok := native[name]; ok {
+		return constructor(), true
+	}
+	return nil, false
+}
+
 // camel converts a snake cased input string into a camel cased output.
 func camel(str string) string {
 	pieces := strings.Split(str, "_")
@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/consensus/ethash"
 	"github.com/ethereum-optimism/optimism/l2geth/core"
 	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
 	"github.com/ethereum-optimism/optimism/l2geth/core/types"
 	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
 	"github.com/ethereum-optimism/optimism/l2geth/crypto"
@@ -45,8 +46,9 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/p2p"
 	"github.com/ethereum-optimism/optimism/l2geth/params"
 	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
 	"github.com/ethereum-optimism/optimism/l2geth/rollup/fees"
-	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/util"
 	"github.com/ethereum-optimism/optimism/l2geth/rpc"
 	"github.com/tyler-smith/go-bip39"
 )
@@ -82,6 +84,53 @@ func (s *PublicEthereumAPI) GasPrice(ctx context.Context) (*hexutil.Big, error)
 	return (*hexutil.Big)(gasPrice), nil
 }
 
+// MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee
+// transactions. The L2 gas price is the whole price of L2 gas, so this is
+// always zero.
+func (s *PublicEthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tipcap, err := s.b.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), nil
+}
+
+type feeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee market history. The base fee of a block is the
+// L2 gas price that was in force at the block.
+func (s *PublicEthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*feeHistoryResult, error) {
+	oldest, reward, baseFee, gasUsed, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	results := &feeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(oldest),
+		GasUsedRatio: gasUsed,
+	}
+	if reward != nil {
+		results.Reward = make([][]*hexutil.Big, len(reward))
+		for i, w := range reward {
+			results.Reward[i] = make([]*hexutil.Big, len(w))
+			for j, v := range w {
+				results.Reward[i][j] = (*hexutil.Big)(v)
+			}
+		}
+	}
+	if baseFee != nil {
+		results.BaseFee = make([]*hexutil.Big, len(baseFee))
+		for i, v := range baseFee {
+			results.BaseFee[i] = (*hexutil.Big)(v)
+		}
+	}
+	return results, nil
+}
+
 // ProtocolVersion returns the current Ethereum protocol version this node supports
 func (s *PublicEthereumAPI) ProtocolVersion() hexutil.Uint {
 	return hexutil.Uint(s.b.ProtocolVersion())
@@ -800,53 +849,109 @@ type account struct {
 	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
 }
 
-func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg *vm.Config, timeout time.Duration, globalGasCap *big.Int) ([]byte, uint64, bool, error) {
-	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
-
-	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
-	if state == nil || err != nil {
-		return nil, 0, false, err
-	}
-	// Set sender address or use a default if none specified
-	var addr common.Address
-	if args.From == nil {
-		if !rcfg.UsingOVM {
-			if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
-				if accounts := wallets[0].Accounts(); len(accounts) > 0 {
-					addr = accounts[0].Address
-				}
-			}
-		}
-	} else {
-		addr = *args.From
-	}
-	// Override the fields of specified contracts before execution.
+// applyOverrides replaces the fields of the accounts in overrides
+func applyOverrides(statedb *state.StateDB, overrides map[common.Address]account) error {
 	for addr, account := range overrides {
 		// Override account nonce.
 		if account.Nonce != nil {
-			state.SetNonce(addr, uint64(*account.Nonce))
+			statedb.SetNonce(addr, uint64(*account.Nonce))
 		}
 		// Override account(contract) code.
 		if account.Code != nil {
-			state.SetCode(addr, *account.Code)
+			statedb.SetCode(addr, *account.Code)
 		}
 		// Override account balance.
 		if account.Balance != nil {
-			state.SetBalance(addr, (*big.Int)(*account.Balance))
+			statedb.SetBalance(addr, (*big.Int)(*account.Balance))
 		}
 		if account.State != nil && account.StateDiff != nil {
-			return nil, 0, false, fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
 		}
 		// Replace entire state if caller requires.
 		if account.State != nil {
-			state.SetStorage(addr, *account.State)
+			statedb.SetStorage(addr, *account.State)
 		}
 		// Apply state diff into specified accounts.
 		if account.StateDiff != nil {
 			for key, value := range *account.StateDiff {
-				state.SetState(addr, key, value)
+				statedb.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// l1Context returns the L1 block number and timestamp that calls are executed
+// with on top of a block
+func l1Context(ctx context.Context, b Backend, header *types.Header) (*big.Int, uint64, error) {
+	// Currently, the blocknumber and timestamp actually refer to the L1BlockNumber and L1Timestamp
+	// attached to each transaction. We need to modify the blocknumber and timestamp to reflect this,
+	// or else the result of `eth_call` will not be correct.
+	blockNumber := header.Number
+	timestamp := header.Time
+	if b.ChainConfig().UsingOVM() {
+		block, err := b.BlockByNumber(ctx, rpc.BlockNumber(header.Number.Uint64()))
+		if err != nil {
+			return nil, 0, err
+		}
+		if block != nil {
+			txs := block.Transactions()
+			if header.Number.Uint64() != 0 {
+				if len(txs) != 1 {
+					return nil, 0, fmt.Errorf("block %d has more than 1 transaction", header.Number.Uint64())
+				}
+				tx := txs[0]
+				blockNumber = tx.L1BlockNumber()
+				timestamp = tx.L1Timestamp()
+			}
+		}
+	}
+	return blockNumber, timestamp, nil
+}
+
+func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg *vm.Config, timeout time.Duration, globalGasCap *big.Int) ([]byte, uint64, bool, error) {
+	result, err := doCall(ctx, b, args, blockNrOrHash, overrides, vmCfg, timeout, globalGasCap)
+	if result == nil {
+		return nil, 0, false, err
+	}
+	return result.ret, result.gas, result.failed, err
+}
+
+// callResult is the outcome of a message applied by doCall
+type callResult struct {
+	ret    []byte
+	gas    uint64
+	failed bool
+	fees   *types.FeeRecord
+	// turingHelper is the TuringHelper contract charged for a Turing request
+	turingHelper common.Address
+}
+
+// doCall executes a call like DoCall and also returns the fees collected
+// from it. The result is nil if the message was not applied.
+func doCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides map[common.Address]account, vmCfg *vm.Config, timeout time.Duration, globalGasCap *big.Int) (*callResult, error) {
+	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
+
+	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	// Set sender address or use a default if none specified
+	var addr common.Address
+	if args.From == nil {
+		if !b.ChainConfig().UsingOVM() {
+			if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
+				if accounts := wallets[0].Accounts(); len(accounts) > 0 {
+					addr = accounts[0].Address
+				}
 			}
 		}
+	} else {
+		addr = *args.From
+	}
+	// Override the fields of specified contracts before execution.
+	if err := applyOverrides(state, overrides); err != nil {
+		return nil, err
 	}
 	// Set default gas & gas price if none were set
 	gas := uint64(math.MaxUint64 / 2)
@@ -872,27 +977,9 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 		data = []byte(*args.Data)
 	}
 
-	// Currently, the blocknumber and timestamp actually refer to the L1BlockNumber and L1Timestamp
-	// attached to each transaction. We need to modify the blocknumber and timestamp to reflect this,
-	// or else the result of `eth_call` will not be correct.
-	blockNumber := header.Number
-	timestamp := header.Time
-	if rcfg.UsingOVM {
-		block, err := b.BlockByNumber(ctx, rpc.BlockNumber(header.Number.Uint64()))
-		if err != nil {
-			return nil, 0, false, err
-		}
-		if block != nil {
-			txs := block.Transactions()
-			if header.Number.Uint64() != 0 {
-				if len(txs) != 1 {
-					return nil, 0, false, fmt.Errorf("block %d has more than 1 transaction", header.Number.Uint64())
-				}
-				tx := txs[0]
-				blockNumber = tx.L1BlockNumber()
-				timestamp = tx.L1Timestamp()
-			}
-		}
+	blockNumber, timestamp, err := l1Context(ctx, b, header)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create new call message
@@ -913,7 +1000,7 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	// Get a new instance of the EVM.
 	evm, vmError, err := b.GetEVM(ctx, msg, state, header, vmCfg)
 	if err != nil {
-		return nil, 0, false, err
+		return nil, err
 	}
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -925,15 +1012,15 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	// Setup the gas pool (also for unmetered requests)
 	// and apply the message.
 	gp := new(core.GasPool).AddGas(math.MaxUint64)
-	res, gas, failed, err := core.ApplyMessage(evm, msg, gp)
+	res, gas, failed, fees, err := core.ApplyMessageWithFees(evm, msg, gp)
 	if err := vmError(); err != nil {
-		return nil, 0, false, err
+		return nil, err
 	}
 	// If the timer caused an abort, return an appropriate error message
 	if evm.Cancelled() {
-		return nil, 0, false, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
 	}
-	return res, gas, failed, err
+	return &callResult{ret: res, gas: gas, failed: failed, fees: fees, turingHelper: evm.Context.TuringHelper}, err
 }
 
 // Call executes the given transaction on the state for the given block number.
@@ -968,15 +1055,76 @@ func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOr
 // fees can compensate for the additional costs the sequencer pays for publishing the
 // transaction calldata
 func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap *big.Int) (hexutil.Uint64, error) {
-	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
-		lo               uint64 = params.TxGas - 1
-		hi               uint64
-		cap              uint64
 		blockNr          *big.Int
 		isGasUpdate      bool = true
 		isFeeTokenUpdate bool = true
 	)
+	hi, err := estimateExecutionGas(ctx, b, args, blockNrOrHash, gasCap)
+	if err != nil {
+		return 0, err
+	}
+	// Get hard fork status
+	block, err := b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err == nil {
+		blockNr = block.Number()
+		isGasUpdate = b.ChainConfig().IsGasUpdate(blockNr)
+		isFeeTokenUpdate = b.ChainConfig().IsFeeTokenUpdate(blockNr)
+	}
+	if !isGasUpdate {
+		return hexutil.Uint64(hi), nil
+	}
+	// Get state
+	state, _, _ := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	// If gas price is 0 or nil, the returned gas limit doesn't include the
+	// l1 security fee
+	gasPrice := new(big.Int)
+	price, err := b.SuggestL2GasPrice(context.Background())
+	if err == nil && isFeeTokenUpdate {
+		if args.GasPrice == nil {
+			// gasPrice is used to calculate the l2ExtraFee
+			gasPrice = price
+		} else {
+			// Set gasPrice to the gas price from input
+			gasPrice = (*big.Int)(args.GasPrice)
+		}
+	}
+
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+
+	l2ExtraGas := new(big.Int)
+	if b.ChainConfig().UsingOVM() {
+		if gasPrice.Cmp(common.Big0) != 0 {
+			l2ExtraGas, _ = fees.CalculateL1GasFromState(data, state, nil)
+		}
+	}
+
+	// Get gas usage for l1 security fee
+	intrGas, err := core.IntrinsicGas(data, args.To == nil, true, b.ChainConfig().IsIstanbul(blockNr))
+	if err != nil {
+		return hexutil.Uint64(hi + l2ExtraGas.Uint64()), nil
+	}
+	// Add l1 security fee if it hasn't been calculated into gas limit
+	if hi >= intrGas+l2ExtraGas.Uint64() && args.GasPrice != nil {
+		return hexutil.Uint64(hi), nil
+	} else {
+		return hexutil.Uint64(hi + l2ExtraGas.Uint64()), nil
+	}
+}
+
+// estimateExecutionGas binary searches the lowest gas limit at which the call
+// executes, which includes the l1 security fee only if a gas price is set
+// after the fee token fork
+func estimateExecutionGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap *big.Int) (uint64, error) {
+	// Binary search the gas requirement, as it may be higher than the amount used
+	var (
+		lo  uint64 = params.TxGas - 1
+		hi  uint64
+		cap uint64
+	)
 	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
 		hi = uint64(*args.Gas)
 	} else {
@@ -993,7 +1141,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 	}
 	cap = hi
 
-	if !rcfg.UsingOVM {
+	if !b.ChainConfig().UsingOVM() {
 		// Set sender address or use a default if none specified
 		if args.From == nil {
 			if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
@@ -1007,28 +1155,6 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 	if args.From == nil {
 		args.From = &common.Address{}
 	}
-	// Get hard fork status
-	block, err := b.BlockByNumberOrHash(ctx, blockNrOrHash)
-	if err == nil {
-		blockNr = block.Number()
-		isGasUpdate = b.ChainConfig().IsGasUpdate(blockNr)
-		isFeeTokenUpdate = b.ChainConfig().IsFeeTokenUpdate(blockNr)
-	}
-	// Get state
-	state, _, _ := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
-	// If gas price is 0 or nil, the returned gas limit doesn't include the
-	// l1 security fee
-	gasPrice := new(big.Int)
-	price, err := b.SuggestL2GasPrice(context.Background())
-	if err == nil && isFeeTokenUpdate {
-		if args.GasPrice == nil {
-			// gasPrice is used to calculate the l2ExtraFee
-			gasPrice = price
-		} else {
-			// Set gasPrice to the gas price from input
-			gasPrice = (*big.Int)(args.GasPrice)
-		}
-	}
 	// Create a helper to check if a gas allowance results in an executable transaction
 	executable := func(gas uint64) (bool, []byte, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
@@ -1069,42 +1195,68 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
 		}
 	}
+	return hi, nil
+}
 
-	if !isGasUpdate {
-		return hexutil.Uint64(hi), nil
-	}
-
-	var data []byte
-	if args.Data != nil {
-		data = []byte(*args.Data)
-	}
+// EstimateGasOptions selects the estimation mode of eth_estimateGas
+type EstimateGasOptions struct {
+	// Turing reports the Turing credit charged for the transaction together
+	// with the gas estimate
+	Turing bool `json:"turing"`
+}
 
-	l2ExtraGas := new(big.Int)
-	if rcfg.UsingOVM {
-		if gasPrice.Cmp(common.Big0) != 0 {
-			l2ExtraGas, _ = fees.CalculateL1GasFromState(data, state, nil)
-		}
-	}
+// TuringGasEstimate is a gas estimate together with the Turing credit that
+// is charged to a TuringHelper contract for the off-chain request of the
+// transaction. The credit is not part of the gas estimate.
+type TuringGasEstimate struct {
+	Gas          hexutil.Uint64  `json:"gas"`
+	TuringHelper *common.Address `json:"turingHelper"`
+	TuringCharge *hexutil.Big    `json:"turingCharge"`
+}
 
-	// Get gas usage for l1 security fee
-	intrGas, err := core.IntrinsicGas(data, args.To == nil, true, b.ChainConfig().IsIstanbul(blockNr))
+// EstimateGas returns an estimate of the amount of gas needed to execute the
+// given transaction against the given block, which defaults to the current
+// pending block. This is modified to encode the fee in wei as gas price is
+// always 1. If the Turing estimation mode is selected, the Turing credit
+// charged for the transaction is reported separately.
+func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, opts *EstimateGasOptions) (interface{}, error) {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	gas, err := DoEstimateGas(ctx, s.b, args, bNrOrHash, s.b.RPCGasCap())
 	if err != nil {
-		return hexutil.Uint64(hi + l2ExtraGas.Uint64()), nil
+		return nil, err
 	}
-	// Add l1 security fee if it hasn't been calculated into gas limit
-	if hi >= intrGas+l2ExtraGas.Uint64() && args.GasPrice != nil {
-		return hexutil.Uint64(hi), nil
-	} else {
-		return hexutil.Uint64(hi + l2ExtraGas.Uint64()), nil
+	if opts == nil || !opts.Turing {
+		return gas, nil
 	}
+	return estimateTuringCharge(ctx, s.b, args, bNrOrHash, gas)
 }
 
-// EstimateGas returns an estimate of the amount of gas needed to execute the
-// given transaction against the current pending block. This is modified to
-// encode the fee in wei as gas price is always 1
-func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (hexutil.Uint64, error) {
-	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
-	return DoEstimateGas(ctx, s.b, args, blockNrOrHash, s.b.RPCGasCap())
+// estimateTuringCharge executes a transaction with the estimated gas and
+// returns the Turing credit it is charged
+func estimateTuringCharge(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gas hexutil.Uint64) (*TuringGasEstimate, error) {
+	if args.From == nil {
+		args.From = &common.Address{}
+	}
+	args.Gas = &gas
+	result, err := doCall(ctx, b, args, blockNrOrHash, nil, &vm.Config{}, 5*time.Second, b.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	if result.failed {
+		return nil, errors.New("execution reverted with the estimated gas")
+	}
+	estimate := &TuringGasEstimate{
+		Gas:          gas,
+		TuringCharge: (*hexutil.Big)(new(big.Int)),
+	}
+	if result.fees.TuringFee.Sign() > 0 {
+		estimate.TuringHelper = &result.turingHelper
+		estimate.TuringCharge = (*hexutil.Big)(result.fees.TuringFee)
+	}
+	return estimate, nil
 }
 
 // ExecutionResult groups all structured logs emitted by the EVM
@@ -1680,7 +1832,7 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 // SendTransaction creates a transaction for the given argument, sign it and submit it to the
 // transaction pool.
 func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args SendTxArgs) (common.Hash, error) {
-	if rcfg.UsingOVM {
+	if s.b.ChainConfig().UsingOVM() {
 		return common.Hash{}, errOVMUnsupported
 	}
 	// Look up the wallet containing the requested signer
@@ -1715,7 +1867,7 @@ func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args Sen
 // FillTransaction fills the defaults (nonce, gas, gasPrice) on a given unsigned transaction,
 // and returns it to the caller for further processing (signing + broadcast)
 func (s *PublicTransactionPoolAPI) FillTransaction(ctx context.Context, args SendTxArgs) (*SignTransactionResult, error) {
-	if rcfg.UsingOVM {
+	if s.b.ChainConfig().UsingOVM() {
 		return nil, errOVMUnsupported
 	}
 	// Set some sanity defaults and terminate on failure
@@ -1776,7 +1928,7 @@ func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, encod
 //
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_sign
 func (s *PublicTransactionPoolAPI) Sign(addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
-	if rcfg.UsingOVM {
+	if s.b.ChainConfig().UsingOVM() {
 		return nil, errOVMUnsupported
 	}
 	// Look up the wallet containing the requested signer
@@ -1804,7 +1956,7 @@ type SignTransactionResult struct {
 // The node needs to have the private key of the account corresponding with
 // the given from address and it needs to be unlocked.
 func (s *PublicTransactionPoolAPI) SignTransaction(ctx context.Context, args SendTxArgs) (*SignTransactionResult, error) {
-	if rcfg.UsingOVM {
+	if s.b.ChainConfig().UsingOVM() {
 		return nil, errOVMUnsupported
 	}
 	if args.Gas == nil {
@@ -1960,6 +2112,131 @@ func (api *PublicRollupAPI) GetInfo(ctx context.Context) rollupInfo {
 	}
 }
 
+// StateDivergence represents a state root posted to the State Commitment
+// Chain that does not match the locally computed state root
+type StateDivergence struct {
+	Index      uint64      `json:"index"`
+	BatchIndex uint64      `json:"batchIndex"`
+	PostedRoot common.Hash `json:"postedRoot"`
+	LocalRoot  common.Hash `json:"localRoot"`
+}
+
+// StateDivergenceInfo represents the state of the state root divergence
+// checks of a verifier.
+// CheckedIndex is the greatest CTC index whose state root was checked
+// Divergences are the most recently detected divergences
+type StateDivergenceInfo struct {
+	Enabled      bool              `json:"enabled"`
+	Halted       bool              `json:"halted"`
+	CheckedIndex *uint64           `json:"checkedIndex"`
+	Divergences  []StateDivergence `json:"divergences"`
+}
+
+// GetStateDivergence returns the state roots posted to the State Commitment
+// Chain that do not match the locally computed state roots
+func (api *PublicRollupAPI) GetStateDivergence(ctx context.Context) StateDivergenceInfo {
+	return api.b.GetStateDivergence()
+}
+
+// SyncStatusInfo represents the sync status of the node compared to the
+// remote server it syncs from. Healthy is false if any of the problems
+// apply. Ages are in seconds.
+type SyncStatusInfo struct {
+	Healthy          bool     `json:"healthy"`
+	Syncing          bool     `json:"syncing"`
+	LocalIndex       *uint64  `json:"localIndex"`
+	RemoteIndex      *uint64  `json:"remoteIndex"`
+	IndexLag         uint64   `json:"indexLag"`
+	VerifiedIndex    *uint64  `json:"verifiedIndex"`
+	LocalQueueIndex  *uint64  `json:"localQueueIndex"`
+	RemoteQueueIndex *uint64  `json:"remoteQueueIndex"`
+	QueueLag         uint64   `json:"queueLag"`
+	L1ContextAge     uint64   `json:"l1ContextAge"`
+	LastAppliedAge   uint64   `json:"lastAppliedAge"`
+	Problems         []string `json:"problems"`
+}
+
+// SyncStatus returns the sync status of the node compared to the remote
+// server it syncs from
+func (api *PublicRollupAPI) SyncStatus(ctx context.Context) (*SyncStatusInfo, error) {
+	return api.b.GetSyncStatus()
+}
+
+// RollupTransaction represents a transaction that was applied to the chain
+// along with its rollup metadata. Transaction is the RLP encoded transaction
+// and Meta is the encoded TransactionMeta, which includes the index, queue
+// index, L1 block number, L1 timestamp and Turing payload.
+type RollupTransaction struct {
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	Index       *hexutil.Uint64 `json:"index"`
+	Transaction hexutil.Bytes   `json:"transaction"`
+	Meta        hexutil.Bytes   `json:"meta"`
+}
+
+// newRollupTransaction returns a transaction of a block that will serialize
+// to the RollupTransaction representation
+func newRollupTransaction(tx *types.Transaction, block *types.Block) (*RollupTransaction, error) {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &RollupTransaction{
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		BlockHash:   block.Hash(),
+		Transaction: raw,
+	}
+	if meta := tx.GetMeta(); meta != nil {
+		result.Meta = types.TxMetaEncode(meta)
+		if meta.Index != nil {
+			index := hexutil.Uint64(*meta.Index)
+			result.Index = &index
+		}
+	}
+	return result, nil
+}
+
+// Transactions creates a subscription that is notified of each transaction
+// that is added to the canonical chain, along with its rollup metadata.
+// Replicas subscribe to the sequencer to apply transactions without waiting
+// to poll for them.
+func (api *PublicRollupAPI) Transactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		chainEvents := make(chan core.ChainEvent, 128)
+		chainSub := api.b.SubscribeChainEvent(chainEvents)
+		defer chainSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-chainEvents:
+				for _, tx := range ev.Block.Transactions() {
+					rollupTx, err := newRollupTransaction(tx, ev.Block)
+					if err != nil {
+						log.Error("Cannot encode rollup transaction", "hash", tx.Hash().Hex(), "err", err)
+						continue
+					}
+					notifier.Notify(rpcSub.ID, rollupTx)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-chainSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 type gasPrices struct {
 	L1GasPrice *hexutil.Big `json:"l1GasPrice"`
 	L2GasPrice *hexutil.Big `json:"l2GasPrice"`
@@ -1981,6 +2258,126 @@ func (api *PublicRollupAPI) GasPrices(ctx context.Context) (*gasPrices, error) {
 	}, nil
 }
 
+// FeeEstimate is the fee of a transaction split into its L1 and L2 portions
+type FeeEstimate struct {
+	// Gas limit that covers the execution and, after the fee token fork, the
+	// L1 security fee
+	GasLimit hexutil.Uint64 `json:"gasLimit"`
+	// L2 gas used by the execution
+	L2Gas hexutil.Uint64 `json:"l2Gas"`
+	// L1 gas used by the transaction data, including the overhead
+	L1Gas hexutil.Uint64 `json:"l1Gas"`
+	// L2 gas that pays for the L1 security fee
+	L2ExtraGas hexutil.Uint64 `json:"l2ExtraGas"`
+	Overhead   *hexutil.Big   `json:"overhead"`
+	Scalar     string         `json:"scalar"`
+	L1GasPrice *hexutil.Big   `json:"l1GasPrice"`
+	L2GasPrice *hexutil.Big   `json:"l2GasPrice"`
+	// L1 security fee and L2 execution fee in wei
+	L1Fee *hexutil.Big `json:"l1Fee"`
+	L2Fee *hexutil.Big `json:"l2Fee"`
+	// Total fee in wei
+	TotalFee *hexutil.Big `json:"totalFee"`
+	// Fee token selected by the sender, ETH or BOBA
+	FeeToken string `json:"feeToken"`
+	// Price ratio of BOBA and ETH and the total fee in BOBA if the sender
+	// selected BOBA as fee token
+	BobaPriceRatio *hexutil.Big `json:"bobaPriceRatio,omitempty"`
+	BobaFee        *hexutil.Big `json:"bobaFee,omitempty"`
+}
+
+// EstimateFees returns the fee of a transaction with the gas used on L2, the
+// L1 security fee and the values of the gas price oracle they are derived
+// from. The fee is quoted in BOBA if the sender selected BOBA as fee token.
+func (api *PublicRollupAPI) EstimateFees(ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*FeeEstimate, error) {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	gasPrice := (*big.Int)(args.GasPrice)
+	if gasPrice == nil {
+		if gasPrice, err = api.b.SuggestL2GasPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+	// Estimate the execution without a gas price so that the L1 security
+	// fee is not included
+	execArgs := args
+	execArgs.GasPrice = nil
+	l2Gas, err := estimateExecutionGas(ctx, api.b, execArgs, bNrOrHash, api.b.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+	msg := types.NewMessage(from, args.To, state.GetNonce(from), value, l2Gas, gasPrice, data, false, nil, 0, nil, types.QueueOriginSequencer)
+	config := api.b.ChainConfig()
+	breakdown, err := fees.DeriveFeeBreakdown(msg, state, config.IsFeeTokenUpdate(header.Number))
+	if err != nil {
+		return nil, err
+	}
+	return newFeeEstimate(config, state, header.Number, msg, breakdown, l2Gas), nil
+}
+
+// newFeeEstimate splits the fee of a message into its L1 and L2 portions,
+// where l2Gas is the gas used by the execution of the message
+func newFeeEstimate(config *params.ChainConfig, statedb *state.StateDB, number *big.Int, msg types.Message, breakdown *fees.FeeBreakdown, l2Gas uint64) *FeeEstimate {
+	var (
+		isGasUpdate      = config.IsGasUpdate(number)
+		isFeeTokenUpdate = config.IsFeeTokenUpdate(number)
+		gasPrice         = msg.GasPrice()
+	)
+	// The L1 security fee is paid as L2 gas after the gas update fork and is
+	// part of the gas limit after the fee token fork
+	gasLimit := l2Gas
+	l1Fee := new(big.Int).Set(breakdown.L1Fee)
+	if isGasUpdate {
+		l1Fee.Mul(breakdown.L2ExtraGas, gasPrice)
+	}
+	if isFeeTokenUpdate {
+		gasLimit += breakdown.L2ExtraGas.Uint64()
+	}
+	l2Fee := new(big.Int).Mul(new(big.Int).SetUint64(l2Gas), gasPrice)
+	total := new(big.Int).Add(l1Fee, l2Fee)
+
+	estimate := &FeeEstimate{
+		GasLimit:   hexutil.Uint64(gasLimit),
+		L2Gas:      hexutil.Uint64(l2Gas),
+		L1Gas:      hexutil.Uint64(breakdown.L1GasUsed.Uint64()),
+		L2ExtraGas: hexutil.Uint64(breakdown.L2ExtraGas.Uint64()),
+		Overhead:   (*hexutil.Big)(breakdown.Overhead),
+		Scalar:     breakdown.Scalar.String(),
+		L1GasPrice: (*hexutil.Big)(breakdown.L1GasPrice),
+		L2GasPrice: (*hexutil.Big)(gasPrice),
+		L1Fee:      (*hexutil.Big)(l1Fee),
+		L2Fee:      (*hexutil.Big)(l2Fee),
+		TotalFee:   (*hexutil.Big)(total),
+		FeeToken:   "ETH",
+	}
+	// The fee in BOBA is the fee in ETH multiplied by the price ratio
+	if isFeeTokenUpdate && statedb.GetFeeTokenSelection(msg.From()).Cmp(common.Big1) == 0 {
+		ratio := statedb.GetBobaPriceRatio()
+		estimate.FeeToken = "BOBA"
+		estimate.BobaPriceRatio = (*hexutil.Big)(ratio)
+		estimate.BobaFee = (*hexutil.Big)(new(big.Int).Mul(total, ratio))
+	}
+	return estimate
+}
+
 // PrivatelRollupAPI provides private RPC methods to control the sequencer.
 // These methods can be abused by external users and must be considered insecure for use by untrusted users.
 type PrivateRollupAPI struct {
@@ -2004,6 +2401,490 @@ func (api *PrivateRollupAPI) SetL2GasPrice(ctx context.Context, gasPrice hexutil
 	return api.b.SetL2GasPrice(ctx, (*big.Int)(&gasPrice))
 }
 
+// PublicBobaAPI is the collection of APIs specific to Boba
+type PublicBobaAPI struct {
+	b Backend
+}
+
+// NewPublicBobaAPI creates a new API definition for the Boba methods of the
+// Ethereum service.
+func NewPublicBobaAPI(b Backend) *PublicBobaAPI {
+	return &PublicBobaAPI{b: b}
+}
+
+// TuringResponse is an off-chain Turing request of a transaction together with
+// the modified calldata that was returned to the TuringHelper contract.
+// The request is not known for transactions that were applied before the
+// requests were recorded.
+type TuringResponse struct {
+	Request  hexutil.Bytes `json:"request"`
+	Response hexutil.Bytes `json:"response"`
+	Proof    hexutil.Bytes `json:"proof,omitempty"`
+}
+
+// GetTuringResponses returns the Turing requests and responses of a
+// transaction, falling back to the L1Turing meta of the transaction
+func (api *PublicBobaAPI) GetTuringResponses(ctx context.Context, hash common.Hash) ([]*TuringResponse, error) {
+	if records := rawdb.ReadTuringRecords(api.b.ChainDb(), hash); len(records) != 0 {
+		responses := make([]*TuringResponse, len(records))
+		for i, record := range records {
+			responses[i] = &TuringResponse{
+				Request:  record.Request,
+				Response: record.Response,
+				Proof:    record.Proof,
+			}
+		}
+		return responses, nil
+	}
+	tx, _, _, _ := rawdb.ReadTransaction(api.b.ChainDb(), hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	if turing := tx.L1Turing(); len(turing) > 1 {
+		return []*TuringResponse{{Response: turing}}, nil
+	}
+	return []*TuringResponse{}, nil
+}
+
+// ValueDiff is the value of a field before and after a transaction
+type ValueDiff struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AccountDiff holds the fields of an account that were modified by a
+// transaction
+type AccountDiff struct {
+	Balance *ValueDiff                 `json:"balance,omitempty"`
+	Nonce   *ValueDiff                 `json:"nonce,omitempty"`
+	Code    *ValueDiff                 `json:"code,omitempty"`
+	Storage map[common.Hash]*ValueDiff `json:"storage,omitempty"`
+}
+
+// SimulatedTransaction is the result of a transaction of a simulated bundle
+type SimulatedTransaction struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Logs       []*types.Log   `json:"logs"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Failed     bool           `json:"failed"`
+	// Error is set if the transaction could not be applied, in which case
+	// ReturnData holds the error encoded as a Solidity error
+	Error        string `json:"error,omitempty"`
+	RevertReason string `json:"revertReason,omitempty"`
+	// Turing holds the modified calldata of a Turing request
+	Turing    hexutil.Bytes                   `json:"turing,omitempty"`
+	Fee       *FeeEstimate                    `json:"fee"`
+	StateDiff map[common.Address]*AccountDiff `json:"stateDiff"`
+}
+
+// SimulateTransactions executes a sequence of transactions on top of a block,
+// pending by default, where each transaction sees the state changes of the
+// previous ones. Nonces are taken from the state and fees are only charged
+// for transactions with a gas price. Nothing is written to the chain.
+func (api *PublicBobaAPI) SimulateTransactions(ctx context.Context, txs []CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *map[common.Address]account) ([]*SimulatedTransaction, error) {
+	defer func(start time.Time) { log.Debug("Simulating transactions finished", "runtime", time.Since(start)) }(time.Now())
+
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions to simulate")
+	}
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	config := api.b.ChainConfig()
+	deleteEmptyObjects := config.IsEIP158(header.Number)
+	if overrides != nil {
+		if err := applyOverrides(statedb, *overrides); err != nil {
+			return nil, err
+		}
+		statedb.Finalise(deleteEmptyObjects)
+	}
+	blockNumber, timestamp, err := l1Context(ctx, api.b, header)
+	if err != nil {
+		return nil, err
+	}
+	l2GasPrice, err := api.b.SuggestL2GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isGasUpdate := config.IsGasUpdate(header.Number)
+	isFeeTokenUpdate := config.IsFeeTokenUpdate(header.Number)
+
+	// The whole bundle shares the timeout of a single call
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	gp := new(core.GasPool).AddGas(math.MaxUint64)
+	results := make([]*SimulatedTransaction, len(txs))
+	for i, args := range txs {
+		var from common.Address
+		if args.From != nil {
+			from = *args.From
+		}
+		gas := uint64(math.MaxUint64 / 2)
+		if args.Gas != nil {
+			gas = uint64(*args.Gas)
+		}
+		if gasCap := api.b.RPCGasCap(); gasCap != nil && gasCap.Uint64() < gas {
+			gas = gasCap.Uint64()
+		}
+		gasPrice := new(big.Int)
+		if args.GasPrice != nil {
+			gasPrice = args.GasPrice.ToInt()
+		}
+		value := new(big.Int)
+		if args.Value != nil {
+			value = args.Value.ToInt()
+		}
+		var data []byte
+		if args.Data != nil {
+			data = []byte(*args.Data)
+		}
+		nonce := statedb.GetNonce(from)
+		msg := types.NewMessage(from, args.To, nonce, value, gas, gasPrice, data, false, blockNumber, timestamp, []byte{0}, types.QueueOriginSequencer)
+
+		// Quote the fee at the suggested gas price if the transaction is
+		// executed without one. The L1 security fee depends on the state
+		// before the execution.
+		quote := msg
+		if gasPrice.Sign() == 0 {
+			quote = types.NewMessage(from, args.To, nonce, value, gas, l2GasPrice, data, false, blockNumber, timestamp, nil, types.QueueOriginSequencer)
+		}
+		breakdown, err := fees.DeriveFeeBreakdown(quote, statedb, isFeeTokenUpdate)
+		if err != nil {
+			return nil, err
+		}
+
+		// Transactions are told apart by their index, as they have no hash
+		statedb.Prepare(common.BigToHash(big.NewInt(int64(i))), common.Hash{}, i)
+		// The preimages of the OVM_ETH balance slots written by contracts
+		// identify the accounts of the balances in the state diff
+		evm, vmError, err := api.b.GetEVM(ctx, msg, statedb, header, &vm.Config{EnablePreimageRecording: config.UsingOVM()})
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+		ret, gasUsed, failed, applyErr := core.ApplyMessage(evm, msg, gp)
+		if err := vmError(); err != nil {
+			return nil, err
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", 5*time.Second)
+		}
+
+		result := &SimulatedTransaction{
+			ReturnData: ret,
+			Logs:       statedb.GetLogs(common.BigToHash(big.NewInt(int64(i)))),
+			GasUsed:    hexutil.Uint64(gasUsed),
+			Failed:     failed,
+			StateDiff:  stateDiff(statedb, &from, args.To, &evm.Coinbase),
+		}
+		if result.Logs == nil {
+			result.Logs = []*types.Log{}
+		}
+		for _, l := range result.Logs {
+			l.TxHash = common.Hash{}
+		}
+		if applyErr != nil {
+			result.Failed = true
+			result.Error = applyErr.Error()
+			if result.ReturnData, err = util.EncodeSolidityError(applyErr); err != nil {
+				return nil, err
+			}
+		} else if failed && len(ret) >= 4 && bytes.Equal(ret[:4], abi.RevertSelector) {
+			if reason, err := abi.UnpackRevert(ret); err == nil {
+				result.RevertReason = reason
+			}
+		}
+		if turing := evm.Context.Turing; len(turing) > 1 {
+			result.Turing = turing
+		}
+		// The gas used includes the L2 gas that pays for the L1 security
+		// fee when the fee is charged
+		l2Gas := gasUsed
+		if gasPrice.Sign() > 0 && config.UsingOVM() && isGasUpdate {
+			if extra := breakdown.L2ExtraGas.Uint64(); extra < l2Gas {
+				l2Gas -= extra
+			}
+		}
+		result.Fee = newFeeEstimate(config, statedb, header.Number, quote, breakdown, l2Gas)
+		results[i] = result
+
+		statedb.Finalise(deleteEmptyObjects)
+	}
+	return results, nil
+}
+
+// stateDiff returns the accounts modified since the last call to Finalise
+// with their fields before and after the modification. Fields that were
+// changed back to their original value are left out. Under the OVM, the
+// balances held in the storage of OVM_ETH are reported as the balances of
+// their accounts when the account of the slot is known, either as one of the
+// given accounts, a modified account or from the recorded preimages.
+func stateDiff(statedb *state.StateDB, accounts ...*common.Address) map[common.Address]*AccountDiff {
+	diffs := make(map[common.Address]*AccountDiff)
+	changes := statedb.Changes()
+	for addr, change := range changes {
+		diff := &AccountDiff{Storage: make(map[common.Hash]*ValueDiff)}
+		if change.Balance != nil {
+			if balance := statedb.GetBalance(addr); balance.Cmp(change.Balance) != 0 {
+				diff.Balance = &ValueDiff{From: (*hexutil.Big)(change.Balance), To: (*hexutil.Big)(balance)}
+			}
+		}
+		if change.Nonce != nil {
+			if nonce := statedb.GetNonce(addr); nonce != *change.Nonce {
+				diff.Nonce = &ValueDiff{From: hexutil.Uint64(*change.Nonce), To: hexutil.Uint64(nonce)}
+			}
+		}
+		if change.Code != nil {
+			if code := statedb.GetCode(addr); !bytes.Equal(code, *change.Code) {
+				diff.Code = &ValueDiff{From: hexutil.Bytes(*change.Code), To: hexutil.Bytes(code)}
+			}
+		}
+		for key, prev := range change.Storage {
+			if value := statedb.GetState(addr, key); value != prev {
+				diff.Storage[key] = &ValueDiff{From: prev, To: value}
+			}
+		}
+		diffs[addr] = diff
+	}
+
+	if ovmEth := diffs[dump.OvmEthAddress]; ovmEth != nil && statedb.OVMConfig().UsingOVM {
+		owners := make(map[common.Hash]common.Address)
+		for _, addr := range accounts {
+			if addr != nil {
+				owners[state.GetOVMBalanceKey(*addr)] = *addr
+			}
+		}
+		for addr := range changes {
+			owners[state.GetOVMBalanceKey(addr)] = addr
+		}
+		preimages := statedb.Preimages()
+		for key, value := range ovmEth.Storage {
+			owner, ok := owners[key]
+			if !ok {
+				owner, ok = balanceSlotOwner(preimages[key])
+			}
+			if !ok {
+				continue
+			}
+			diff := diffs[owner]
+			if diff == nil {
+				diff = &AccountDiff{Storage: make(map[common.Hash]*ValueDiff)}
+				diffs[owner] = diff
+			}
+			from, to := value.From.(common.Hash), value.To.(common.Hash)
+			diff.Balance = &ValueDiff{From: (*hexutil.Big)(from.Big()), To: (*hexutil.Big)(to.Big())}
+			delete(ovmEth.Storage, key)
+		}
+	}
+
+	for addr, diff := range diffs {
+		if diff.Balance == nil && diff.Nonce == nil && diff.Code == nil && len(diff.Storage) == 0 {
+			delete(diffs, addr)
+		}
+	}
+	return diffs
+}
+
+// balanceSlotOwner returns the account of an OVM_ETH balance slot from the
+// preimage of the slot, which is the padded address followed by the zero
+// position of the balances mapping
+func balanceSlotOwner(preimage []byte) (common.Address, bool) {
+	if len(preimage) != 2*common.HashLength {
+		return common.Address{}, false
+	}
+	if !bytes.Equal(preimage[:common.HashLength-common.AddressLength], make([]byte, common.HashLength-common.AddressLength)) ||
+		!bytes.Equal(preimage[common.HashLength:], make([]byte, common.HashLength)) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(preimage[common.HashLength-common.AddressLength : common.HashLength]), true
+}
+
+// maxFeeSummaryBlocks is the maximum number of blocks of a fee summary
+const maxFeeSummaryBlocks = 10000
+
+// FeeSummary is the sum of the fees collected in a range of blocks. The fees
+// are in wei, except for the BOBA fee.
+type FeeSummary struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	L1Fee     *hexutil.Big   `json:"l1Fee"`
+	L2Fee     *hexutil.Big   `json:"l2Fee"`
+	BobaFee   *hexutil.Big   `json:"bobaFee"`
+	TuringFee *hexutil.Big   `json:"turingFee"`
+	// ETH fee credited to the sequencer that the senders could not pay
+	Uncollected *hexutil.Big `json:"uncollected"`
+	// Number of blocks in the range without recorded fees, which were
+	// imported before fees were recorded
+	UnrecordedBlocks hexutil.Uint64 `json:"unrecordedBlocks"`
+}
+
+// GetFeeSummary returns the sum of the fees collected in a range of blocks,
+// including both ends
+func (api *PublicBobaAPI) GetFeeSummary(ctx context.Context, startNumber rpc.BlockNumber, endNumber rpc.BlockNumber) (*FeeSummary, error) {
+	headers, err := headerRange(ctx, api.b, startNumber, endNumber)
+	if err != nil {
+		return nil, err
+	}
+	fees := types.NewFeeRecord()
+	summary := &FeeSummary{
+		FromBlock: hexutil.Uint64(headers[0].Number.Uint64()),
+		ToBlock:   hexutil.Uint64(headers[len(headers)-1].Number.Uint64()),
+	}
+	for _, header := range headers {
+		record := rawdb.ReadFeeRecord(api.b.ChainDb(), header.Hash(), header.Number.Uint64())
+		if record == nil {
+			summary.UnrecordedBlocks++
+			continue
+		}
+		fees.Add(record)
+	}
+	summary.L1Fee = (*hexutil.Big)(fees.L1Fee)
+	summary.L2Fee = (*hexutil.Big)(fees.L2Fee)
+	summary.BobaFee = (*hexutil.Big)(fees.BobaFee)
+	summary.TuringFee = (*hexutil.Big)(fees.TuringFee)
+	summary.Uncollected = (*hexutil.Big)(fees.Uncollected)
+	return summary, nil
+}
+
+// headerRange returns the headers of a range of at most maxFeeSummaryBlocks
+// blocks, including both ends
+func headerRange(ctx context.Context, b Backend, startNumber rpc.BlockNumber, endNumber rpc.BlockNumber) ([]*types.Header, error) {
+	start, err := b.HeaderByNumber(ctx, startNumber)
+	if err != nil {
+		return nil, err
+	}
+	if start == nil {
+		return nil, fmt.Errorf("start of block range (%d) does not exist", startNumber)
+	}
+	end, err := b.HeaderByNumber(ctx, endNumber)
+	if err != nil {
+		return nil, err
+	}
+	if end == nil {
+		return nil, fmt.Errorf("end of block range (%d) does not exist", endNumber)
+	}
+	from, to := start.Number.Uint64(), end.Number.Uint64()
+	if to < from {
+		return nil, fmt.Errorf("start of block range (%d) is greater than end of block range (%d)", from, to)
+	}
+	if to-from >= maxFeeSummaryBlocks {
+		return nil, fmt.Errorf("requested block range is too large (max is %d, requested %d blocks)", maxFeeSummaryBlocks, to-from+1)
+	}
+	headers := make([]*types.Header, 0, to-from+1)
+	for number := from; number < to; number++ {
+		header, err := b.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block %d does not exist", number)
+		}
+		headers = append(headers, header)
+	}
+	return append(headers, end), nil
+}
+
+// TuringCredit is the prepaid Turing credit of a TuringHelper contract
+type TuringCredit struct {
+	Helper common.Address `json:"helper"`
+	Credit *hexutil.Big   `json:"credit"`
+	// Credit charged per Turing request
+	Price *hexutil.Big `json:"price"`
+	// Number of Turing requests the credit pays for, which is null if
+	// requests are free
+	Calls *hexutil.Big `json:"calls"`
+}
+
+// GetTuringCredit returns the prepaid Turing credit of a TuringHelper
+// contract at a block
+func (api *PublicBobaAPI) GetTuringCredit(ctx context.Context, helper common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*TuringCredit, error) {
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	credit := state.GetTuringCredit(helper)
+	price := state.GetTuringPrice()
+	result := &TuringCredit{
+		Helper: helper,
+		Credit: (*hexutil.Big)(credit),
+		Price:  (*hexutil.Big)(price),
+	}
+	if price.Sign() > 0 {
+		result.Calls = (*hexutil.Big)(new(big.Int).Div(credit, price))
+	}
+	return result, state.Error()
+}
+
+// TuringBlockUsage is the Turing credit charged to a TuringHelper contract
+// in a block
+type TuringBlockUsage struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Calls       hexutil.Uint64 `json:"calls"`
+	Credit      *hexutil.Big   `json:"credit"`
+}
+
+// TuringUsage is the Turing credit charged to a TuringHelper contract in a
+// range of blocks
+type TuringUsage struct {
+	Helper    common.Address      `json:"helper"`
+	FromBlock hexutil.Uint64      `json:"fromBlock"`
+	ToBlock   hexutil.Uint64      `json:"toBlock"`
+	Calls     hexutil.Uint64      `json:"calls"`
+	Credit    *hexutil.Big        `json:"credit"`
+	Blocks    []*TuringBlockUsage `json:"blocks"`
+	// Number of blocks in the range without recorded fees, which were
+	// imported before Turing charges were recorded
+	UnrecordedBlocks hexutil.Uint64 `json:"unrecordedBlocks"`
+}
+
+// GetTuringUsage returns the Turing requests charged to a TuringHelper
+// contract in a range of blocks, including both ends
+func (api *PublicBobaAPI) GetTuringUsage(ctx context.Context, helper common.Address, startNumber rpc.BlockNumber, endNumber rpc.BlockNumber) (*TuringUsage, error) {
+	headers, err := headerRange(ctx, api.b, startNumber, endNumber)
+	if err != nil {
+		return nil, err
+	}
+	credit := new(big.Int)
+	usage := &TuringUsage{
+		Helper:    helper,
+		FromBlock: hexutil.Uint64(headers[0].Number.Uint64()),
+		ToBlock:   hexutil.Uint64(headers[len(headers)-1].Number.Uint64()),
+		Credit:    (*hexutil.Big)(credit),
+		Blocks:    []*TuringBlockUsage{},
+	}
+	for _, header := range headers {
+		hash, number := header.Hash(), header.Number.Uint64()
+		if rawdb.ReadFeeRecord(api.b.ChainDb(), hash, number) == nil {
+			usage.UnrecordedBlocks++
+			continue
+		}
+		var block *TuringBlockUsage
+		for _, charge := range rawdb.ReadTuringCharges(api.b.ChainDb(), hash, number) {
+			if charge.Helper != helper {
+				continue
+			}
+			if block == nil {
+				block = &TuringBlockUsage{BlockNumber: hexutil.Uint64(number), Credit: (*hexutil.Big)(new(big.Int))}
+				usage.Blocks = append(usage.Blocks, block)
+			}
+			block.Calls++
+			block.Credit.ToInt().Add(block.Credit.ToInt(), charge.Credit)
+			usage.Calls++
+			credit.Add(credit, charge.Credit)
+		}
+	}
+	return usage, nil
+}
+
 // PublicDebugAPI is the collection of Ethereum APIs exposed over the public
 // debugging endpoint.
 type PublicDebugAPI struct {
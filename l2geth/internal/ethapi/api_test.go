@@ -0,0 +1,83 @@
+package ethapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/state"
+	"github.com/ethereum-optimism/optimism/l2geth/core/vm"
+	"github.com/ethereum-optimism/optimism/l2geth/params"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/dump"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
+)
+
+// Tests that under the OVM the balance changes of a value transfer, which
+// are writes to the storage of OVM_ETH, are reported as account balances
+func TestStateDiffOVMBalances(t *testing.T) {
+	ovm := &rcfg.Config{UsingOVM: true}
+	config := *params.TestChainConfig
+	config.OVM = ovm
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetOVMConfig(ovm)
+
+	var (
+		from     = common.Address{0x01}
+		to       = common.Address{0x02}
+		coinbase = common.Address{0x03}
+		holder   = common.Address{0x04}
+	)
+	// OVM_ETH sets the balance of the holder to 5 through its own balances
+	// mapping, whose slot is hashed by the contract
+	code := append([]byte{0x73}, holder.Bytes()...)
+	code = append(code, 0x60, 0x00, 0x52, 0x60, 0x40, 0x60, 0x00, 0x20, 0x60, 0x05, 0x90, 0x55, 0x00)
+	statedb.SetCode(dump.OvmEthAddress, code)
+	statedb.SetBalance(from, big.NewInt(100))
+	statedb.Finalise(true)
+
+	context := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Origin:      from,
+		Coinbase:    coinbase,
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		GasPrice:    new(big.Int),
+	}
+	evm := vm.NewEVM(context, statedb, &config, vm.Config{EnablePreimageRecording: true})
+	statedb.SetNonce(from, 1)
+	if _, _, err := evm.Call(vm.AccountRef(from), to, nil, 100000, big.NewInt(30)); err != nil {
+		t.Fatal(err)
+	}
+	statedb.AddBalance(coinbase, big.NewInt(1))
+	if _, _, err := evm.Call(vm.AccountRef(from), dump.OvmEthAddress, nil, 100000, new(big.Int)); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs := stateDiff(statedb, &from, &to, &coinbase)
+	balances := map[common.Address][2]int64{
+		from:     {100, 70},
+		to:       {0, 30},
+		coinbase: {0, 1},
+		holder:   {0, 5},
+	}
+	for addr, want := range balances {
+		diff := diffs[addr]
+		if diff == nil || diff.Balance == nil {
+			t.Fatalf("Missing balance diff of %s", addr.Hex())
+		}
+		from, to := diff.Balance.From.(*hexutil.Big).ToInt(), diff.Balance.To.(*hexutil.Big).ToInt()
+		if from.Int64() != want[0] || to.Int64() != want[1] {
+			t.Fatalf("Unexpected balance diff of %s: %v to %v", addr.Hex(), from, to)
+		}
+	}
+	if diffs[from].Nonce == nil {
+		t.Fatal("Missing nonce diff of the sender")
+	}
+	if diff, ok := diffs[dump.OvmEthAddress]; ok {
+		t.Fatalf("Balance slots reported as OVM_ETH storage: %v", diff.Storage)
+	}
+}
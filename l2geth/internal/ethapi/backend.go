@@ -90,11 +90,15 @@ type Backend interface {
 	IsSyncing() bool
 	GetEthContext() (uint64, uint64)
 	GetRollupContext() (uint64, uint64, uint64)
+	GetStateDivergence() StateDivergenceInfo
+	GetSyncStatus() (*SyncStatusInfo, error)
 	GasLimit() uint64
 	SuggestL1GasPrice(ctx context.Context) (*big.Int, error)
 	SetL1GasPrice(context.Context, *big.Int) error
 	SuggestL2GasPrice(context.Context) (*big.Int, error)
 	SetL2GasPrice(context.Context, *big.Int) error
+	SuggestGasTipCap(context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blocks int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error)
 	IngestTransactions([]*types.Transaction) error
 	SequencerClientHttp() string
 }
@@ -122,6 +126,11 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicRollupAPI(apiBackend),
 			Public:    true,
+		}, {
+			Namespace: "boba",
+			Version:   "1.0",
+			Service:   NewPublicBobaAPI(apiBackend),
+			Public:    true,
 		}, {
 			Namespace: "rollup_personal",
 			Version:   "1.0",
@@ -78,6 +78,14 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	if _, isCompat := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !isCompat {
 		return nil, genesisErr
 	}
+	// The OVM settings of the node apply unless the genesis configures them,
+	// they are not stored with the chain configuration
+	storedConfig := chainConfig
+	if chainConfig.OVM == nil {
+		cfg := *chainConfig
+		cfg.OVM = config.Rollup.OVMConfig()
+		chainConfig = &cfg
+	}
 	log.Info("Initialised chain configuration", "config", chainConfig)
 
 	peers := newPeerSet()
@@ -140,7 +148,7 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
 		log.Warn("Rewinding chain to upgrade configuration", "err", compat)
 		leth.blockchain.SetHead(compat.RewindTo)
-		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
+		rawdb.WriteChainConfig(chainDb, genesisHash, storedConfig)
 	}
 
 	leth.ApiBackend = &LesApiBackend{ctx.ExtRPCEnabled(), leth, nil}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/l2geth/common"
 	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 // Genesis hashes to enforce below configs on.
@@ -215,16 +216,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(108), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(108), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, new(EthashConfig), nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(420), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(420), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, new(EthashConfig), nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 
 	// OpMainnetChainID is the ID of Boba's mainnet chain.
@@ -323,6 +324,15 @@ type ChainConfig struct {
 
 	EWASMBlock *big.Int `json:"ewasmBlock,omitempty"` // EWASM switch block (nil = no fork, 0 = already activated)
 
+	// Boba forks (nil = default of the chain, which is the known height on
+	// Boba networks and already activated on other chains)
+	BobaSDUpdateBlock  *big.Int `json:"bobaSDUpdateBlock,omitempty"`  // SD update switch block
+	BobaGasUpdateBlock *big.Int `json:"bobaGasUpdateBlock,omitempty"` // Gas update switch block
+	BobaFeeTokenBlock  *big.Int `json:"bobaFeeTokenBlock,omitempty"`  // Fee token update switch block
+
+	// OVM settings (nil = read from the environment)
+	OVM *rcfg.Config `json:"ovm,omitempty"`
+
 	// Various consensus engines
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
@@ -358,7 +368,7 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, Berlin: %v, Engine: %v}",
+	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, Berlin: %v, Boba SD Update: %v, Boba Gas Update: %v, Boba Fee Token: %v, Engine: %v}",
 		c.ChainID,
 		c.HomesteadBlock,
 		c.DAOForkBlock,
@@ -372,6 +382,9 @@ func (c *ChainConfig) String() string {
 		c.IstanbulBlock,
 		c.MuirGlacierBlock,
 		c.BerlinBlock,
+		c.bobaForkBlock(bobaSDUpdateFork),
+		c.bobaForkBlock(bobaGasUpdateFork),
+		c.bobaForkBlock(bobaFeeTokenFork),
 		engine,
 	)
 }
@@ -438,41 +451,84 @@ func (c *ChainConfig) IsEWASM(num *big.Int) bool {
 	return isForked(c.EWASMBlock, num)
 }
 
-// IsSDUpdate returns whether num represents a block number after the SD update fork
-func (c *ChainConfig) IsSDUpdate(num *big.Int) bool {
-	if c.ChainID.Cmp(OpMainnetChainID) == 0 {
-		return isForked(OpMainnetSDUpdateForkNum, num)
+// bobaFork is a Boba fork that is scheduled by an optional ChainConfig field,
+// falling back to the height of the fork on the known Boba networks. Future
+// Boba forks are added to bobaForks along with their ChainConfig field.
+type bobaFork struct {
+	name    string
+	block   func(c *ChainConfig) *big.Int
+	mainnet *big.Int
+	rinkeby *big.Int
+}
+
+var (
+	bobaSDUpdateFork = &bobaFork{
+		name:    "Boba SD update fork block",
+		block:   func(c *ChainConfig) *big.Int { return c.BobaSDUpdateBlock },
+		mainnet: OpMainnetSDUpdateForkNum,
+		rinkeby: OpRinkebySDUpdateForkNum,
+	}
+	bobaGasUpdateFork = &bobaFork{
+		name:    "Boba gas update fork block",
+		block:   func(c *ChainConfig) *big.Int { return c.BobaGasUpdateBlock },
+		mainnet: BobaMainnetGasUpdatedForkNum,
+		rinkeby: BobaRinkebyGasUpdatedForkNum,
+	}
+	bobaFeeTokenFork = &bobaFork{
+		name:    "Boba fee token fork block",
+		block:   func(c *ChainConfig) *big.Int { return c.BobaFeeTokenBlock },
+		mainnet: BobaMainnetFeeUpdatedForkNum,
+		rinkeby: BobaRinkebyFeeUpdatedForkNum,
+	}
+
+	// bobaForks are all of the Boba forks, which are checked for
+	// compatibility in the order of activation
+	bobaForks = []*bobaFork{bobaSDUpdateFork, bobaGasUpdateFork, bobaFeeTokenFork}
+)
+
+// bobaForkBlock returns the block at which a Boba fork activates
+func (c *ChainConfig) bobaForkBlock(fork *bobaFork) *big.Int {
+	if block := fork.block(c); block != nil {
+		return block
 	}
-	if c.ChainID.Cmp(OpRinkebyChainID) == 0 {
-		return isForked(OpRinkebySDUpdateForkNum, num)
+	if c.ChainID != nil {
+		if c.ChainID.Cmp(OpMainnetChainID) == 0 {
+			return fork.mainnet
+		}
+		if c.ChainID.Cmp(OpRinkebyChainID) == 0 {
+			return fork.rinkeby
+		}
 	}
-	return true
+	return common.Big0
+}
+
+// IsSDUpdate returns whether num represents a block number after the SD update fork
+func (c *ChainConfig) IsSDUpdate(num *big.Int) bool {
+	return isForked(c.bobaForkBlock(bobaSDUpdateFork), num)
 }
 
+// IsGasUpdate returns whether num represents a block number after the gas update fork
 func (c *ChainConfig) IsGasUpdate(num *big.Int) bool {
-	if c.ChainID == nil {
-		return true
-	}
-	if c.ChainID.Cmp(OpMainnetChainID) == 0 {
-		return isForked(BobaMainnetGasUpdatedForkNum, num)
-	}
-	if c.ChainID.Cmp(OpRinkebyChainID) == 0 {
-		return isForked(BobaRinkebyGasUpdatedForkNum, num)
-	}
-	return true
+	return isForked(c.bobaForkBlock(bobaGasUpdateFork), num)
 }
 
+// IsFeeTokenUpdate returns whether num represents a block number after the fee token update fork
 func (c *ChainConfig) IsFeeTokenUpdate(num *big.Int) bool {
-	if c.ChainID == nil {
-		return true
-	}
-	if c.ChainID.Cmp(OpMainnetChainID) == 0 {
-		return isForked(BobaMainnetFeeUpdatedForkNum, num)
-	}
-	if c.ChainID.Cmp(OpRinkebyChainID) == 0 {
-		return isForked(BobaRinkebyFeeUpdatedForkNum, num)
+	return isForked(c.bobaForkBlock(bobaFeeTokenFork), num)
+}
+
+// OVMConfig returns the OVM settings of the chain, falling back to the
+// settings read from the environment
+func (c *ChainConfig) OVMConfig() *rcfg.Config {
+	if c == nil || c.OVM == nil {
+		return rcfg.Default
 	}
-	return true
+	return c.OVM
+}
+
+// UsingOVM returns whether the functionality necessary for the OVM is enabled
+func (c *ChainConfig) UsingOVM() bool {
+	return c.OVMConfig().UsingOVM
 }
 
 // CheckCompatible checks whether scheduled fork transitions have been imported
@@ -574,6 +630,12 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.EWASMBlock, newcfg.EWASMBlock, head) {
 		return newCompatError("ewasm fork block", c.EWASMBlock, newcfg.EWASMBlock)
 	}
+	for _, fork := range bobaForks {
+		stored, updated := c.bobaForkBlock(fork), newcfg.bobaForkBlock(fork)
+		if isForkIncompatible(stored, updated, head) {
+			return newCompatError(fork.name, stored, updated)
+		}
+	}
 	return nil
 }
 
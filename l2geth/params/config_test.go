@@ -20,6 +20,9 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 func TestCheckCompatible(t *testing.T) {
@@ -70,6 +73,28 @@ func TestCheckCompatible(t *testing.T) {
 				RewindTo:     9,
 			},
 		},
+		{
+			stored: &ChainConfig{ChainID: big.NewInt(1), BobaFeeTokenBlock: big.NewInt(10)},
+			new:    &ChainConfig{ChainID: big.NewInt(1), BobaFeeTokenBlock: big.NewInt(20)},
+			head:   9,
+		},
+		{
+			stored: &ChainConfig{ChainID: big.NewInt(1), BobaFeeTokenBlock: big.NewInt(10)},
+			new:    &ChainConfig{ChainID: big.NewInt(1), BobaFeeTokenBlock: big.NewInt(20)},
+			head:   15,
+			wantErr: &ConfigCompatError{
+				What:         "Boba fee token fork block",
+				StoredConfig: big.NewInt(10),
+				NewConfig:    big.NewInt(20),
+				RewindTo:     9,
+			},
+		},
+		{
+			// Configs without Boba forks use the heights of the Boba networks
+			stored: &ChainConfig{ChainID: OpMainnetChainID},
+			new:    &ChainConfig{ChainID: OpMainnetChainID, BobaGasUpdateBlock: BobaMainnetGasUpdatedForkNum},
+			head:   500000,
+		},
 	}
 
 	for _, test := range tests {
@@ -79,3 +104,51 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestBobaForks(t *testing.T) {
+	tests := []struct {
+		config   *ChainConfig
+		num      int64
+		sd       bool
+		gas      bool
+		feeToken bool
+	}{
+		{&ChainConfig{ChainID: OpMainnetChainID}, 310214, false, false, false},
+		{&ChainConfig{ChainID: OpMainnetChainID}, 400000, true, true, false},
+		{&ChainConfig{ChainID: OpMainnetChainID}, 485000, true, true, true},
+		{&ChainConfig{ChainID: OpRinkebyChainID}, 49999, true, true, false},
+		{&ChainConfig{ChainID: OpRinkebyChainID}, 50000, true, true, true},
+		{&ChainConfig{ChainID: big.NewInt(31338)}, 0, true, true, true},
+		{&ChainConfig{}, 0, true, true, true},
+		{&ChainConfig{ChainID: big.NewInt(31338), BobaGasUpdateBlock: big.NewInt(10), BobaFeeTokenBlock: big.NewInt(20)}, 9, true, false, false},
+		{&ChainConfig{ChainID: big.NewInt(31338), BobaGasUpdateBlock: big.NewInt(10), BobaFeeTokenBlock: big.NewInt(20)}, 19, true, true, false},
+		{&ChainConfig{ChainID: OpMainnetChainID, BobaFeeTokenBlock: big.NewInt(20)}, 20, false, false, true},
+	}
+	for i, test := range tests {
+		num := big.NewInt(test.num)
+		if sd := test.config.IsSDUpdate(num); sd != test.sd {
+			t.Errorf("test %d: SD update %t, want %t", i, sd, test.sd)
+		}
+		if gas := test.config.IsGasUpdate(num); gas != test.gas {
+			t.Errorf("test %d: gas update %t, want %t", i, gas, test.gas)
+		}
+		if feeToken := test.config.IsFeeTokenUpdate(num); feeToken != test.feeToken {
+			t.Errorf("test %d: fee token update %t, want %t", i, feeToken, test.feeToken)
+		}
+	}
+}
+
+func TestOVMConfig(t *testing.T) {
+	var nilConfig *ChainConfig
+	if nilConfig.OVMConfig() != rcfg.Default {
+		t.Error("nil config does not use the default OVM settings")
+	}
+	if (&ChainConfig{}).OVMConfig() != rcfg.Default {
+		t.Error("config without OVM settings does not use the default")
+	}
+	ovm := &rcfg.Config{UsingOVM: true, L2BobaTokenAddress: common.Address{0x01}}
+	config := &ChainConfig{OVM: ovm}
+	if config.OVMConfig() != ovm || !config.UsingOVM() {
+		t.Error("config does not use its OVM settings")
+	}
+}
@@ -0,0 +1,278 @@
+package rollup
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/metrics"
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// errSenderRateLimited is returned when a sender submits transactions
+	// faster than its configured rate
+	errSenderRateLimited = errors.New("sender rate limit exceeded")
+	// errIPRateLimited is returned when a remote address submits transactions
+	// faster than its configured rate
+	errIPRateLimited = errors.New("ip rate limit exceeded")
+	// errIngressQueueFull is returned when the ingress queue is full of
+	// transactions that pay at least as much as the rejected transaction
+	errIngressQueueFull = errors.New("ingress queue full")
+	// errNonceGapTooLarge is returned when the nonce of a transaction is too
+	// far ahead of the nonce of its sender
+	errNonceGapTooLarge = errors.New("nonce gap too large")
+)
+
+var (
+	senderRateLimitedCounter = metrics.NewRegisteredCounter("rollup/admission/rejected/sender", nil)
+	ipRateLimitedCounter     = metrics.NewRegisteredCounter("rollup/admission/rejected/ip", nil)
+	ingressQueueFullCounter  = metrics.NewRegisteredCounter("rollup/admission/rejected/queue", nil)
+	nonceGapCounter          = metrics.NewRegisteredCounter("rollup/admission/rejected/noncegap", nil)
+	ingressQueueGauge        = metrics.NewRegisteredGauge("rollup/admission/queued", nil)
+)
+
+// maxTrackedLimiters is the maximum number of senders and remote addresses
+// whose rate limiters are kept, the least recently used are evicted first
+const maxTrackedLimiters = 65536
+
+// admissionConfig configures the admission control of sequencer
+// transactions. A zero rate, queue size or nonce gap disables the
+// corresponding check.
+type admissionConfig struct {
+	SenderTxRate     float64
+	SenderTxBurst    int
+	IPTxRate         float64
+	IPTxBurst        int
+	IngressQueueSize int
+	MaxNonceGap      uint64
+}
+
+// admissionController decides whether sequencer transactions are admitted
+// before they are validated and applied
+type admissionController struct {
+	cfg     admissionConfig
+	senders *lru.Cache
+	ips     *lru.Cache
+	queue   *ingressQueue
+}
+
+// newAdmissionController creates an admissionController, returning nil if
+// all of the checks are disabled
+func newAdmissionController(cfg admissionConfig) *admissionController {
+	if cfg.SenderTxRate <= 0 && cfg.IPTxRate <= 0 && cfg.IngressQueueSize <= 0 && cfg.MaxNonceGap == 0 {
+		return nil
+	}
+	a := &admissionController{cfg: cfg}
+	if cfg.SenderTxRate > 0 {
+		a.senders, _ = lru.New(maxTrackedLimiters)
+	}
+	if cfg.IPTxRate > 0 {
+		a.ips, _ = lru.New(maxTrackedLimiters)
+	}
+	if cfg.IngressQueueSize > 0 {
+		a.queue = newIngressQueue(cfg.IngressQueueSize)
+	}
+	return a
+}
+
+// allow takes a token from the buckets of the sender and the remote address.
+// The remote address is empty if it is not known.
+func (a *admissionController) allow(sender common.Address, remote string) error {
+	if a.senders != nil && !allowKey(a.senders, sender, a.cfg.SenderTxRate, a.cfg.SenderTxBurst) {
+		senderRateLimitedCounter.Inc(1)
+		return fmt.Errorf("%w: %s", errSenderRateLimited, sender.Hex())
+	}
+	if ip := remoteIP(remote); a.ips != nil && ip != "" && !allowKey(a.ips, ip, a.cfg.IPTxRate, a.cfg.IPTxBurst) {
+		ipRateLimitedCounter.Inc(1)
+		return fmt.Errorf("%w: %s", errIPRateLimited, ip)
+	}
+	return nil
+}
+
+// checkNonceGap rejects transactions whose nonce is too far ahead of the
+// nonce of the sender
+func (a *admissionController) checkNonceGap(txNonce, stateNonce uint64) error {
+	if a.cfg.MaxNonceGap == 0 || txNonce <= stateNonce {
+		return nil
+	}
+	if gap := txNonce - stateNonce; gap > a.cfg.MaxNonceGap {
+		nonceGapCounter.Inc(1)
+		return fmt.Errorf("%w: nonce %d is %d ahead of %d", errNonceGapTooLarge, txNonce, gap, stateNonce)
+	}
+	return nil
+}
+
+// allowKey takes a token from the bucket of a key, creating the bucket if it
+// does not exist
+func allowKey(cache *lru.Cache, key interface{}, r float64, burst int) bool {
+	if burst <= 0 {
+		burst = int(r)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	if limiter, ok := cache.Get(key); ok {
+		return limiter.(*rate.Limiter).Allow()
+	}
+	limiter := rate.NewLimiter(rate.Limit(r), burst)
+	if previous, ok, _ := cache.PeekOrAdd(key, limiter); ok {
+		limiter = previous.(*rate.Limiter)
+	}
+	return limiter.Allow()
+}
+
+// remoteIP returns the host of a remote address, which is set by the HTTP RPC
+// server in the context of a request
+func remoteIP(remote string) string {
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// remoteFromContext returns the remote address of an RPC request, or the
+// empty string if it is not known
+func remoteFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	remote, _ := ctx.Value("remote").(string)
+	return remote
+}
+
+// ingressEntry is a transaction waiting in the ingress queue
+type ingressEntry struct {
+	gasPrice *big.Int
+	seq      uint64
+	ready    chan error
+	index    int
+}
+
+// ingressHeap orders waiting transactions by gas price, highest first, and
+// then by arrival
+type ingressHeap []*ingressEntry
+
+func (h ingressHeap) Len() int { return len(h) }
+func (h ingressHeap) Less(i, j int) bool {
+	if c := h[i].gasPrice.Cmp(h[j].gasPrice); c != 0 {
+		return c > 0
+	}
+	return h[i].seq < h[j].seq
+}
+func (h ingressHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *ingressHeap) Push(x interface{}) {
+	entry := x.(*ingressEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+func (h *ingressHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
+
+// ingressQueue admits one transaction at a time. Transactions that arrive
+// while another is being applied wait in a bounded queue and are admitted by
+// highest gas price. When the queue is full, the transaction with the lowest
+// gas price is rejected.
+type ingressQueue struct {
+	mu      sync.Mutex
+	size    int
+	busy    bool
+	seq     uint64
+	waiting ingressHeap
+}
+
+// newIngressQueue creates an ingressQueue that holds up to size waiting
+// transactions
+func newIngressQueue(size int) *ingressQueue {
+	return &ingressQueue{size: size}
+}
+
+// acquire blocks until the transaction is admitted, returning
+// errIngressQueueFull if it is rejected. A waiting transaction leaves the
+// queue when ctx is done, so that it cannot push out live transactions. Each
+// successful acquire must be followed by a release.
+func (q *ingressQueue) acquire(ctx context.Context, gasPrice *big.Int) error {
+	q.mu.Lock()
+	if !q.busy {
+		q.busy = true
+		q.mu.Unlock()
+		return nil
+	}
+	if len(q.waiting) >= q.size {
+		lowest := q.waiting[0]
+		for _, entry := range q.waiting[1:] {
+			if entry.gasPrice.Cmp(lowest.gasPrice) < 0 || (entry.gasPrice.Cmp(lowest.gasPrice) == 0 && entry.seq > lowest.seq) {
+				lowest = entry
+			}
+		}
+		if lowest.gasPrice.Cmp(gasPrice) >= 0 {
+			q.mu.Unlock()
+			ingressQueueFullCounter.Inc(1)
+			return errIngressQueueFull
+		}
+		heap.Remove(&q.waiting, lowest.index)
+		ingressQueueFullCounter.Inc(1)
+		lowest.ready <- errIngressQueueFull
+	}
+	entry := &ingressEntry{
+		gasPrice: gasPrice,
+		seq:      q.seq,
+		ready:    make(chan error, 1),
+	}
+	q.seq++
+	heap.Push(&q.waiting, entry)
+	ingressQueueGauge.Update(int64(len(q.waiting)))
+	q.mu.Unlock()
+
+	select {
+	case err := <-entry.ready:
+		return err
+	case <-ctx.Done():
+	}
+	q.mu.Lock()
+	if entry.index >= 0 {
+		heap.Remove(&q.waiting, entry.index)
+		ingressQueueGauge.Update(int64(len(q.waiting)))
+		q.mu.Unlock()
+		return ctx.Err()
+	}
+	q.mu.Unlock()
+
+	// The transaction was admitted or rejected while ctx was done, the turn
+	// of an admitted transaction is passed on
+	if err := <-entry.ready; err == nil {
+		q.release()
+	}
+	return ctx.Err()
+}
+
+// release admits the waiting transaction with the highest gas price
+func (q *ingressQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.busy = false
+		return
+	}
+	entry := heap.Pop(&q.waiting).(*ingressEntry)
+	ingressQueueGauge.Update(int64(len(q.waiting)))
+	entry.ready <- nil
+}
@@ -0,0 +1,158 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+)
+
+func TestAdmissionControllerRateLimits(t *testing.T) {
+	if newAdmissionController(admissionConfig{}) != nil {
+		t.Fatal("Admission controller created without checks")
+	}
+	a := newAdmissionController(admissionConfig{
+		SenderTxRate:  0.001,
+		SenderTxBurst: 2,
+		IPTxRate:      0.001,
+		IPTxBurst:     3,
+	})
+
+	alice, bob, carol := common.Address{0x01}, common.Address{0x02}, common.Address{0x03}
+	for i := 0; i < 2; i++ {
+		if err := a.allow(alice, "10.0.0.1:1234"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.allow(alice, "10.0.0.2:1234"); !errors.Is(err, errSenderRateLimited) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The port of the remote address is ignored
+	if err := a.allow(bob, "10.0.0.1:4321"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.allow(carol, "10.0.0.1:1234"); !errors.Is(err, errIPRateLimited) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Remote addresses are not rate limited when unknown
+	if err := a.allow(carol, remoteFromContext(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdmissionControllerNonceGap(t *testing.T) {
+	a := newAdmissionController(admissionConfig{MaxNonceGap: 2})
+	if err := a.checkNonceGap(5, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.checkNonceGap(7, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.checkNonceGap(8, 5); !errors.Is(err, errNonceGapTooLarge) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Nonces that are too low are rejected by the tx pool
+	if err := a.checkNonceGap(1, 5); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIngressQueuePriority(t *testing.T) {
+	q := newIngressQueue(3)
+	if err := q.acquire(context.Background(), big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	admitted := make(chan int64, 4)
+	rejected := make(chan int64, 4)
+	wait := func(price int64) {
+		if err := q.acquire(context.Background(), big.NewInt(price)); err != nil {
+			if !errors.Is(err, errIngressQueueFull) {
+				t.Error(err)
+			}
+			rejected <- price
+			return
+		}
+		admitted <- price
+	}
+	waitQueued := func(n int) {
+		for i := 0; i < 100; i++ {
+			q.mu.Lock()
+			queued := len(q.waiting)
+			q.mu.Unlock()
+			if queued == n {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Fatalf("Expected %d queued transactions", n)
+	}
+	for i, price := range []int64{1, 3, 2} {
+		go wait(price)
+		waitQueued(i + 1)
+	}
+
+	// The queue is full, so the transaction with the lowest gas price is
+	// rejected in favour of a transaction with a higher gas price
+	go wait(4)
+	if price := <-rejected; price != 1 {
+		t.Fatalf("Unexpected transaction rejected: %d", price)
+	}
+	waitQueued(3)
+	go wait(2)
+	if price := <-rejected; price != 2 {
+		t.Fatalf("Unexpected transaction rejected: %d", price)
+	}
+
+	for _, expected := range []int64{4, 3, 2} {
+		q.release()
+		if price := <-admitted; price != expected {
+			t.Fatalf("Unexpected transaction admitted: %d, expected %d", price, expected)
+		}
+	}
+	q.release()
+	if q.busy {
+		t.Fatal("Queue is busy after releasing every transaction")
+	}
+}
+
+func TestIngressQueueCancel(t *testing.T) {
+	q := newIngressQueue(1)
+	if err := q.acquire(context.Background(), big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- q.acquire(ctx, big.NewInt(5))
+	}()
+	for i := 0; i < 100; i++ {
+		q.mu.Lock()
+		queued := len(q.waiting)
+		q.mu.Unlock()
+		if queued == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The cancelled transaction leaves its slot to a lower priced one
+	go func() {
+		done <- q.acquire(context.Background(), big.NewInt(2))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.release()
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	q.release()
+	if q.busy || len(q.waiting) != 0 {
+		t.Fatal("Queue is not empty after releasing every transaction")
+	}
+}
@@ -25,6 +25,10 @@ const (
 // found. It applies to transactions, queue elements and batches
 var errElementNotFound = errors.New("element not found")
 
+// errRangeNotSupported represents the error case of the remote server not
+// supporting range queries for a backend
+var errRangeNotSupported = errors.New("range not supported")
+
 // errHttpError represents the error case of when the remote server
 // returns a 400 or greater error
 var errHTTPError = errors.New("http error")
@@ -135,6 +139,22 @@ type RollupClient interface {
 	SyncStatus(Backend) (*SyncStatus, error)
 }
 
+// StateRootClient is able to query for the state roots that have been
+// posted to the State Commitment Chain
+type StateRootClient interface {
+	GetStateRootBatch(uint64) (*Batch, []common.Hash, error)
+	GetLatestStateRootBatchIndex() (*uint64, error)
+}
+
+// RangeClient is able to query for a range of transactions with fewer round
+// trips than querying each transaction individually
+type RangeClient interface {
+	// GetTransactionRange returns the transactions starting at start, up to
+	// and including end. Fewer transactions may be returned than requested,
+	// but at least one is returned if there is no error.
+	GetTransactionRange(start, end uint64, backend Backend) ([]*types.Transaction, error)
+}
+
 // Client is an HTTP based RollupClient
 type Client struct {
 	client  *resty.Client
@@ -155,6 +175,20 @@ type TransactionBatchResponse struct {
 	Transactions []*transaction `json:"transactions"`
 }
 
+// stateRoot represents a state root posted to the State Commitment Chain
+type stateRoot struct {
+	Index      uint64      `json:"index"`
+	BatchIndex uint64      `json:"batchIndex"`
+	Value      common.Hash `json:"value"`
+}
+
+// StateRootBatchResponse represents the response from the remote server
+// when querying state root batches.
+type StateRootBatchResponse struct {
+	Batch      *Batch       `json:"batch"`
+	StateRoots []*stateRoot `json:"stateRoots"`
+}
+
 // NewClient create a new Client given a remote HTTP url and a chain id
 func NewClient(url string, chainID *big.Int) *Client {
 	client := resty.New()
@@ -455,6 +489,53 @@ func (c *Client) GetTransaction(index uint64, backend Backend) (*types.Transacti
 	return batchedTransactionToTransaction(res.Transaction, c.chainID)
 }
 
+// GetTransactionRange will get the transactions from start to end (inclusive)
+// that are in the same batch as the transaction at start. The remote server
+// only serves ranges of batched transactions, so the range is read from the
+// batch endpoint and BackendL2 is not supported.
+func (c *Client) GetTransactionRange(start, end uint64, backend Backend) ([]*types.Transaction, error) {
+	if backend != BackendL1 {
+		return nil, fmt.Errorf("%w: backend %s", errRangeNotSupported, backend.String())
+	}
+	if start > end {
+		return nil, fmt.Errorf("Invalid range: %d - %d", start, end)
+	}
+	str := strconv.FormatUint(start, 10)
+	response, err := c.client.R().
+		SetPathParams(map[string]string{
+			"index": str,
+		}).
+		SetQueryParams(map[string]string{
+			"backend": backend.String(),
+		}).
+		SetResult(&TransactionResponse{}).
+		Get("/transaction/index/{index}")
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch transaction: %w", err)
+	}
+	res, ok := response.Result().(*TransactionResponse)
+	if !ok {
+		return nil, fmt.Errorf("could not get tx with index %d", start)
+	}
+	if res.Transaction == nil || res.Batch == nil {
+		return nil, errElementNotFound
+	}
+	batch, txs, err := c.GetTransactionBatch(res.Batch.Index)
+	if err != nil {
+		return nil, err
+	}
+	offset := start - uint64(batch.PrevTotalElements)
+	if start < uint64(batch.PrevTotalElements) || offset >= uint64(len(txs)) {
+		return nil, fmt.Errorf("Transaction %d not found in batch %d", start, batch.Index)
+	}
+	txs = txs[offset:]
+	if count := end - start + 1; uint64(len(txs)) > count {
+		txs = txs[:count]
+	}
+	return txs, nil
+}
+
 // GetLatestTransaction will get the latest transaction, meaning the transaction
 // with the greatest Canonical Transaction Chain index
 func (c *Client) GetLatestTransaction(backend Backend) (*types.Transaction, error) {
@@ -518,6 +599,12 @@ func (c *Client) GetLatestEthContext() (*EthContext, error) {
 // GetLastConfirmedEnqueue will get the last `enqueue` transaction that has been
 // batched up
 func (c *Client) GetLastConfirmedEnqueue() (*types.Transaction, error) {
+	return getLastConfirmedEnqueue(c)
+}
+
+// getLastConfirmedEnqueue will get the last `enqueue` transaction that has
+// been batched up by working backwards from the latest `enqueue`
+func getLastConfirmedEnqueue(c RollupClient) (*types.Transaction, error) {
 	enqueue, err := c.GetLatestEnqueue()
 	if err != nil {
 		return nil, fmt.Errorf("Cannot get latest enqueue: %w", err)
@@ -625,3 +712,61 @@ func parseTransactionBatchResponse(txBatch *TransactionBatchResponse, chainID *b
 	}
 	return batch, txs, nil
 }
+
+// GetStateRootBatch will return the state root batch by batch index
+func (c *Client) GetStateRootBatch(index uint64) (*Batch, []common.Hash, error) {
+	str := strconv.FormatUint(index, 10)
+	response, err := c.client.R().
+		SetResult(&StateRootBatchResponse{}).
+		SetPathParams(map[string]string{
+			"index": str,
+		}).
+		Get("/batch/stateroot/index/{index}")
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("Cannot get state root batch %d: %w", index, err)
+	}
+	rootBatch, ok := response.Result().(*StateRootBatchResponse)
+	if !ok {
+		return nil, nil, fmt.Errorf("Cannot parse state root batch response")
+	}
+	return parseStateRootBatchResponse(rootBatch)
+}
+
+// GetLatestStateRootBatchIndex returns the latest state root batch index
+func (c *Client) GetLatestStateRootBatchIndex() (*uint64, error) {
+	response, err := c.client.R().
+		SetResult(&StateRootBatchResponse{}).
+		Get("/batch/stateroot/latest")
+
+	if err != nil {
+		return nil, errors.New("Cannot get latest state root batch")
+	}
+	rootBatch, ok := response.Result().(*StateRootBatchResponse)
+	if !ok {
+		return nil, fmt.Errorf("Cannot parse state root batch response")
+	}
+	batch, _, err := parseStateRootBatchResponse(rootBatch)
+	if err != nil {
+		return nil, err
+	}
+	index := batch.Index
+	return &index, nil
+}
+
+// parseStateRootBatchResponse will turn a StateRootBatchResponse into a Batch
+// and its state roots, ordered by CTC index
+func parseStateRootBatchResponse(rootBatch *StateRootBatchResponse) (*Batch, []common.Hash, error) {
+	if rootBatch == nil || rootBatch.Batch == nil {
+		return nil, nil, errElementNotFound
+	}
+	batch := rootBatch.Batch
+	roots := make([]common.Hash, len(rootBatch.StateRoots))
+	for i, root := range rootBatch.StateRoots {
+		if root == nil || root.Index != uint64(batch.PrevTotalElements)+uint64(i) {
+			return nil, nil, fmt.Errorf("Unexpected state root at position %d of batch %d", i, batch.Index)
+		}
+		roots[i] = root.Value
+	}
+	return batch, roots, nil
+}
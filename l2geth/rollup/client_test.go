@@ -73,3 +73,58 @@ func TestDecodedJSON(t *testing.T) {
 		t.Fatal("Cannot decode")
 	}
 }
+
+func TestRollupClientGetTransactionRange(t *testing.T) {
+	client := NewClient(url, big.NewInt(1))
+	httpmock.ActivateNonDefault(client.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	batch := map[string]interface{}{
+		"index":             2,
+		"size":              3,
+		"prevTotalElements": 4,
+	}
+	transactions := make([]map[string]interface{}, 3)
+	for i := range transactions {
+		transactions[i] = map[string]interface{}{
+			"index":       4 + i,
+			"batchIndex":  2,
+			"gasLimit":    "0",
+			"target":      "0x4200000000000000000000000000000000000005",
+			"queueOrigin": "sequencer",
+			"value":       "0x0",
+			"data":        "0x",
+		}
+	}
+	transactionResponse, _ := httpmock.NewJsonResponder(200, map[string]interface{}{
+		"transaction": transactions[1],
+		"batch":       batch,
+	})
+	httpmock.RegisterResponder("GET", url+"/transaction/index/5", transactionResponse)
+	batchResponse, _ := httpmock.NewJsonResponder(200, map[string]interface{}{
+		"batch":        batch,
+		"transactions": transactions,
+	})
+	httpmock.RegisterResponder("GET", url+"/batch/transaction/index/2", batchResponse)
+
+	// The range is limited to the batch of the first transaction
+	txs, err := client.GetTransactionRange(5, 10, BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || *txs[0].GetMeta().Index != 5 || *txs[1].GetMeta().Index != 6 {
+		t.Fatal("Unexpected transactions in range")
+	}
+	// The range is limited to the end
+	txs, err = client.GetTransactionRange(5, 5, BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || *txs[0].GetMeta().Index != 5 {
+		t.Fatal("Unexpected transactions in range")
+	}
+
+	if _, err := client.GetTransactionRange(5, 10, BackendL2); !errors.Is(err, errRangeNotSupported) {
+		t.Fatalf("Incorrect error returned: %s", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 type Config struct {
@@ -23,7 +24,7 @@ type Config struct {
 	// Turns on checking of state for L2 gas price
 	EnableL2GasPolling bool
 	// Deployment Height of the canonical transaction chain
-	CanonicalTransactionChainDeployHeight *big.Int
+	CanonicalTransactionChainDeployHeight *big.Int `toml:",omitempty"`
 	// Polling interval for rollup client
 	PollInterval time.Duration
 	// Interval for updating the timestamp
@@ -35,8 +36,76 @@ type Config struct {
 	// Allow fees within a buffer upwards or downwards
 	// to take fee volatility into account between being
 	// quoted and the transaction being executed
-	FeeThresholdDown *big.Float
-	FeeThresholdUp   *big.Float
+	FeeThresholdDown *big.Float `toml:",omitempty"`
+	FeeThresholdUp   *big.Float `toml:",omitempty"`
 	// HTTP endpoint of the sequencer
 	SequencerClientHttp string
+	// WebSocket endpoint of the sequencer, replicas subscribe to the
+	// transactions applied by the sequencer when set
+	SequencerClientWs string
+	// Maximum number of blocks that may be rolled back when a batched
+	// transaction does not match the local chain. Reorgs are disabled if zero
+	MaxReorgDepth uint64
+	// Log the reorgs that would be performed without performing them
+	ReorgDryRun bool
+	// Represents the source that the rollup client reads from
+	ClientType ClientType
+	// HTTP endpoint of layer one, used when reading directly from layer one
+	L1RpcHttp string
+	// Address of the canonical transaction chain on layer one
+	L1CanonicalTransactionChainAddress common.Address
+	// Number of layer one blocks to wait for before ingesting a block
+	L1Confirmations uint64
+	// Layer two block height from which batched transactions carry the
+	// Turing v1 header
+	TuringV1Height uint64
+	// Compare the state roots posted to the State Commitment Chain with the
+	// locally computed state roots
+	StateDivergenceCheck bool
+	// Halt syncing at the first state root divergence
+	HaltOnStateDivergence bool
+	// Maximum number of elements that are fetched from the rollup client
+	// ahead of being applied while syncing
+	SyncConcurrency int
+	// Address of the HTTP health endpoint, disabled if empty
+	HealthAddr string
+	// Thresholds above which the health endpoint reports unhealthy, each
+	// check is disabled if zero
+	HealthMaxIndexLag     uint64
+	HealthMaxQueueLag     uint64
+	HealthMaxL1ContextAge time.Duration
+	HealthMaxTxAge        time.Duration
+	// Number of transactions per second that a sender may submit to the
+	// sequencer, along with the burst size. Disabled if zero
+	SenderTxRate  float64
+	SenderTxBurst int
+	// Number of transactions per second that a remote address may submit
+	// to the sequencer over HTTP, along with the burst size. Disabled if zero
+	IPTxRate  float64
+	IPTxBurst int
+	// Maximum number of transactions waiting to be applied by the sequencer,
+	// which are applied by highest gas price. Disabled if zero
+	IngressQueueSize int
+	// Maximum number of nonces that a transaction may be ahead of its
+	// sender. Disabled if zero
+	MaxNonceGap uint64
+	// Enable the functionality necessary for the OVM
+	UsingOVM bool
+	// Address of the Turing credit contract
+	TuringCreditAddress common.Address
+	// Address of the Boba gas price oracle
+	BobaGasPriceOracleAddress common.Address
+	// Address of the L2 Boba token
+	L2BobaTokenAddress common.Address
+}
+
+// OVMConfig returns the OVM settings of the node, which apply to chains
+// whose genesis does not configure them
+func (c *Config) OVMConfig() *rcfg.Config {
+	return &rcfg.Config{
+		UsingOVM:                  c.UsingOVM,
+		TuringCreditAddress:       c.TuringCreditAddress,
+		BobaGasPriceOracleAddress: c.BobaGasPriceOracleAddress,
+		L2BobaTokenAddress:        c.L2BobaTokenAddress,
+	}
 }
@@ -0,0 +1,194 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/metrics"
+)
+
+var (
+	stateRootsCheckedCounter = metrics.NewRegisteredCounter("rollup/divergence/checked", nil)
+	stateDivergencesCounter  = metrics.NewRegisteredCounter("rollup/divergence/detected", nil)
+	stateRootIndexGauge      = metrics.NewRegisteredGauge("rollup/divergence/index", nil)
+	divergenceHaltedGauge    = metrics.NewRegisteredGauge("rollup/divergence/halted", nil)
+)
+
+// maxRecordedDivergences is the number of divergences that are kept in
+// memory, older divergences are only logged
+const maxRecordedDivergences = 100
+
+// StateDivergence represents a state root posted to the State Commitment
+// Chain that does not match the state root computed locally
+type StateDivergence struct {
+	// Index is the CTC index of the transaction, one less than the block
+	// number
+	Index      uint64
+	BatchIndex uint64
+	PostedRoot common.Hash
+	LocalRoot  common.Hash
+}
+
+// StateDivergenceStatus represents the state of the divergence detector
+type StateDivergenceStatus struct {
+	Enabled bool
+	// Halted is true if syncing was halted at the first divergence
+	Halted bool
+	// CheckedIndex is the greatest CTC index whose state root was checked
+	CheckedIndex *uint64
+	// Divergences holds the most recent divergences that were detected
+	Divergences []StateDivergence
+}
+
+// divergenceDetector compares the state roots posted to the State Commitment
+// Chain with the locally computed state roots
+type divergenceDetector struct {
+	client       StateRootClient
+	halt         bool
+	mu           sync.RWMutex
+	halted       bool
+	checkedIndex *uint64
+	divergences  []StateDivergence
+}
+
+// newDivergenceDetector creates a divergenceDetector that reads state roots
+// from the client, optionally halting sync at the first divergence
+func newDivergenceDetector(client StateRootClient, halt bool) *divergenceDetector {
+	return &divergenceDetector{
+		client: client,
+		halt:   halt,
+	}
+}
+
+// record records a divergence, halting if configured to
+func (d *divergenceDetector) record(divergence StateDivergence) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.divergences = append(d.divergences, divergence)
+	if len(d.divergences) > maxRecordedDivergences {
+		d.divergences = d.divergences[1:]
+	}
+	if d.halt {
+		d.halted = true
+	}
+}
+
+// setCheckedIndex sets the greatest CTC index whose state root was checked
+func (d *divergenceDetector) setCheckedIndex(index uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.checkedIndex = &index
+}
+
+// getCheckedIndex returns the greatest CTC index whose state root was checked
+func (d *divergenceDetector) getCheckedIndex() *uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.checkedIndex
+}
+
+// isHalted returns true if syncing was halted at a divergence
+func (d *divergenceDetector) isHalted() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.halted
+}
+
+// status returns a copy of the state of the detector
+func (d *divergenceDetector) status() StateDivergenceStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	status := StateDivergenceStatus{
+		Enabled:     true,
+		Halted:      d.halted,
+		Divergences: make([]StateDivergence, len(d.divergences)),
+	}
+	if d.checkedIndex != nil {
+		index := *d.checkedIndex
+		status.CheckedIndex = &index
+	}
+	copy(status.Divergences, d.divergences)
+	return status
+}
+
+// GetStateDivergence returns the state of the divergence detector
+func (s *SyncService) GetStateDivergence() StateDivergenceStatus {
+	if s.divergence == nil {
+		return StateDivergenceStatus{}
+	}
+	return s.divergence.status()
+}
+
+// isHaltedOnDivergence returns true if syncing was halted at a divergence
+func (s *SyncService) isHaltedOnDivergence() bool {
+	return s.divergence != nil && s.divergence.isHalted()
+}
+
+// checkStateRoots compares the state roots posted to the State Commitment
+// Chain with the state roots of the local blocks, starting from the batch
+// after the last batch that was fully checked. A batch that references blocks
+// that have not yet been synced is checked again once they have been synced.
+func (s *SyncService) checkStateRoots() error {
+	d := s.divergence
+	if d.isHalted() {
+		return nil
+	}
+	latest, err := d.client.GetLatestStateRootBatchIndex()
+	// No state roots have been posted yet
+	if errors.Is(err, errElementNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot get latest state root batch index: %w", err)
+	}
+
+	start := uint64(0)
+	if head := rawdb.ReadHeadStateBatchIndex(s.db); head != nil {
+		start = *head + 1
+	}
+	for i := start; i <= *latest; i++ {
+		batch, roots, err := d.client.GetStateRootBatch(i)
+		if err != nil {
+			return fmt.Errorf("Cannot get state root batch %d: %w", i, err)
+		}
+		for j, root := range roots {
+			index := uint64(batch.PrevTotalElements) + uint64(j)
+			if checked := d.getCheckedIndex(); checked != nil && index <= *checked {
+				continue
+			}
+			block := s.bc.GetBlockByNumber(index + 1)
+			if block == nil {
+				return nil
+			}
+			stateRootsCheckedCounter.Inc(1)
+			if block.Root() != root {
+				stateDivergencesCounter.Inc(1)
+				log.Error("State root divergence detected", "index", index, "batch-index", i, "posted", root.Hex(), "local", block.Root().Hex())
+				d.record(StateDivergence{
+					Index:      index,
+					BatchIndex: i,
+					PostedRoot: root,
+					LocalRoot:  block.Root(),
+				})
+				if d.isHalted() {
+					divergenceHaltedGauge.Update(1)
+					log.Error("Halting sync at state root divergence", "index", index)
+					return nil
+				}
+			}
+			d.setCheckedIndex(index)
+			stateRootIndexGauge.Update(int64(index))
+		}
+		rawdb.WriteHeadStateBatchIndex(s.db, i)
+	}
+	return nil
+}
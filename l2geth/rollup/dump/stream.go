@@ -0,0 +1,366 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+)
+
+// AccountWriter receives the accounts of a dump one storage slot at a time,
+// so that accounts with a large storage, such as OVM_ETH, never have to be
+// held in memory. The storage of an account is written between BeginAccount
+// and EndAccount, which receives the other fields of the account.
+type AccountWriter interface {
+	BeginAccount(addr common.Address) error
+	Storage(key common.Hash, value string) error
+	EndAccount(account *OvmDumpAccount) error
+}
+
+// WriteAccount writes an account that is held in memory to w, with its
+// storage sorted by key.
+func WriteAccount(w AccountWriter, account *OvmDumpAccount) error {
+	if err := w.BeginAccount(account.Address); err != nil {
+		return err
+	}
+	keys := make([]common.Hash, 0, len(account.Storage))
+	for key := range account.Storage {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	for _, key := range keys {
+		if err := w.Storage(key, account.Storage[key]); err != nil {
+			return err
+		}
+	}
+	return w.EndAccount(&OvmDumpAccount{
+		Address:  account.Address,
+		Code:     account.Code,
+		CodeHash: account.CodeHash,
+		Nonce:    account.Nonce,
+		Balance:  account.Balance,
+	})
+}
+
+// encodedAccount holds the fields of an account that the Encoder writes
+// after its storage. The ABI cannot be recovered from the state so it is
+// left out.
+type encodedAccount struct {
+	Code     string       `json:"code"`
+	CodeHash string       `json:"codeHash"`
+	Nonce    uint64       `json:"nonce"`
+	Balance  *hexutil.Big `json:"balance,omitempty"`
+}
+
+// Encoder writes an OvmDump one storage slot at a time so that the full
+// state never has to be held in memory.
+type Encoder struct {
+	w     *bufio.Writer
+	count int
+	slots int
+}
+
+// NewEncoder returns an Encoder that writes the dump to w. Close must be
+// called once all accounts have been written.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode appends an account that is held in memory to the dump.
+func (e *Encoder) Encode(account *OvmDumpAccount) error {
+	return WriteAccount(e, account)
+}
+
+// BeginAccount starts an account in the dump, keyed by its address.
+func (e *Encoder) BeginAccount(addr common.Address) error {
+	key, err := json.Marshal(addr.Hex())
+	if err != nil {
+		return err
+	}
+	prefix := ",\n"
+	if e.count == 0 {
+		prefix = "{\"accounts\":{\n"
+	}
+	e.count++
+	e.slots = 0
+	if _, err := e.w.WriteString(prefix); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(key); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString(":{\"address\":"); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(key); err != nil {
+		return err
+	}
+	_, err = e.w.WriteString(",\"storage\":{")
+	return err
+}
+
+// Storage appends a storage slot to the current account.
+func (e *Encoder) Storage(key common.Hash, value string) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if e.slots > 0 {
+		if err := e.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	e.slots++
+	if _, err := fmt.Fprintf(e.w, "%q:", key.Hex()); err != nil {
+		return err
+	}
+	_, err = e.w.Write(encoded)
+	return err
+}
+
+// EndAccount terminates the current account with its code and nonce.
+func (e *Encoder) EndAccount(account *OvmDumpAccount) error {
+	value, err := json.Marshal(&encodedAccount{
+		Code:     account.Code,
+		CodeHash: account.CodeHash,
+		Nonce:    account.Nonce,
+		Balance:  account.Balance,
+	})
+	if err != nil {
+		return err
+	}
+	// Continue the account object after the storage
+	if _, err := e.w.WriteString("},"); err != nil {
+		return err
+	}
+	_, err = e.w.Write(value[1:])
+	return err
+}
+
+// Count returns the number of accounts written so far.
+func (e *Encoder) Count() int {
+	return e.count
+}
+
+// Close terminates the dump and flushes it to the underlying writer.
+func (e *Encoder) Close() error {
+	suffix := "\n}}\n"
+	if e.count == 0 {
+		suffix = "{\"accounts\":{}}\n"
+	}
+	if _, err := e.w.WriteString(suffix); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// Decoder reads the accounts of an OvmDump as a stream.
+type Decoder struct {
+	dec *json.Decoder
+}
+
+// NewDecoder returns a Decoder reading a dump from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the accounts of the dump and writes them to w as they are
+// read. Every account must have an address. The storage of an account is
+// streamed to w if the address of the account precedes it, and is buffered
+// otherwise.
+func (d *Decoder) Decode(w AccountWriter) error {
+	if err := d.seekAccounts(); err != nil {
+		return err
+	}
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("invalid account key %v", token)
+		}
+		if err := d.decodeAccount(w, key); err != nil {
+			return fmt.Errorf("invalid account %s: %w", key, err)
+		}
+	}
+	// Consume the closing brace of the accounts object
+	_, err := d.dec.Token()
+	return err
+}
+
+// decodeAccount reads a single account object and writes it to w.
+func (d *Decoder) decodeAccount(w AccountWriter, key string) error {
+	if err := d.expectDelim('{'); err != nil {
+		return err
+	}
+	var (
+		account  OvmDumpAccount
+		address  bool
+		begun    bool
+		buffered map[common.Hash]string
+	)
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "address":
+			err = d.dec.Decode(&account.Address)
+			address = true
+		case "code":
+			err = d.dec.Decode(&account.Code)
+		case "codeHash":
+			err = d.dec.Decode(&account.CodeHash)
+		case "nonce":
+			err = d.dec.Decode(&account.Nonce)
+		case "balance":
+			err = d.dec.Decode(&account.Balance)
+		case "storage":
+			if !address {
+				err = d.dec.Decode(&buffered)
+				break
+			}
+			if err = w.BeginAccount(account.Address); err != nil {
+				return err
+			}
+			begun = true
+			err = d.decodeStorage(w)
+		default:
+			var skip json.RawMessage
+			err = d.dec.Decode(&skip)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Consume the closing brace of the account object
+	if _, err := d.dec.Token(); err != nil {
+		return err
+	}
+	// Accounts may be keyed by contract name, so the key cannot stand in
+	// for the address
+	if !address {
+		return errors.New("missing address")
+	}
+	if !begun {
+		if err := w.BeginAccount(account.Address); err != nil {
+			return err
+		}
+	}
+	if err := WriteAccount(storageWriter{w}, &OvmDumpAccount{Storage: buffered}); err != nil {
+		return err
+	}
+	return w.EndAccount(&account)
+}
+
+// decodeStorage streams the storage object of an account to w.
+func (d *Decoder) decodeStorage(w AccountWriter) error {
+	token, err := d.dec.Token()
+	if err != nil || token == nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("unexpected token %v, expected {", token)
+	}
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		text, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("invalid storage key %v", token)
+		}
+		var key common.Hash
+		if err := key.UnmarshalText([]byte(text)); err != nil {
+			return err
+		}
+		var value string
+		if err := d.dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := w.Storage(key, value); err != nil {
+			return err
+		}
+	}
+	_, err = d.dec.Token()
+	return err
+}
+
+// storageWriter passes only the storage slots of an account on to an
+// AccountWriter.
+type storageWriter struct {
+	AccountWriter
+}
+
+func (storageWriter) BeginAccount(common.Address) error { return nil }
+
+func (storageWriter) EndAccount(*OvmDumpAccount) error { return nil }
+
+// accountCollector gathers whole accounts, for dumps that are small enough
+// to be held in memory.
+type accountCollector struct {
+	accounts []*OvmDumpAccount
+	storage  map[common.Hash]string
+}
+
+func (c *accountCollector) BeginAccount(addr common.Address) error {
+	c.storage = make(map[common.Hash]string)
+	return nil
+}
+
+func (c *accountCollector) Storage(key common.Hash, value string) error {
+	c.storage[key] = value
+	return nil
+}
+
+func (c *accountCollector) EndAccount(account *OvmDumpAccount) error {
+	collected := *account
+	collected.Storage = c.storage
+	c.accounts = append(c.accounts, &collected)
+	return nil
+}
+
+// seekAccounts advances the decoder to the first entry of the accounts
+// object, skipping any other top level fields.
+func (d *Decoder) seekAccounts() error {
+	if err := d.expectDelim('{'); err != nil {
+		return err
+	}
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		if token == "accounts" {
+			return d.expectDelim('{')
+		}
+		var skip json.RawMessage
+		if err := d.dec.Decode(&skip); err != nil {
+			return err
+		}
+	}
+	return errors.New("dump has no accounts")
+}
+
+func (d *Decoder) expectDelim(delim json.Delim) error {
+	token, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected token %v, expected %v", token, delim)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package dump
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	accounts := []*OvmDumpAccount{
+		{
+			Address:  common.Address{0x1},
+			Code:     "0x6000",
+			CodeHash: common.Hash{0x2}.Hex(),
+			Storage:  map[common.Hash]string{{0x3}: common.Hash{0x4}.Hex()},
+			Nonce:    5,
+		},
+		{Address: common.Address{0x6}, Code: "0x", Storage: map[common.Hash]string{}},
+	}
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	for _, account := range accounts {
+		if err := enc.Encode(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadOverrides(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, accounts) {
+		t.Fatalf("Have %v, want %v", decoded, accounts)
+	}
+}
+
+func TestDecoderFormats(t *testing.T) {
+	var (
+		addr    = common.Address{0x1}
+		storage = `"storage":{"0x0000000000000000000000000000000000000000000000000000000000000002":"0x03"}`
+	)
+	tests := []struct {
+		input    string
+		accounts []*OvmDumpAccount
+		fail     bool
+	}{
+		{input: `{"accounts":{}}`},
+		{
+			input:    `{"accounts":{"OVM_ETH":{"address":"0x4200000000000000000000000000000000000006","abi":[]}}}`,
+			accounts: []*OvmDumpAccount{{Address: OvmEthAddress, Storage: map[common.Hash]string{}}},
+		},
+		{
+			input:    `{"commit":"abc","accounts":{"a":{"address":"` + addr.Hex() + `"},"b":{"address":"` + addr.Hex() + `","storage":null}},"extra":1}`,
+			accounts: []*OvmDumpAccount{{Address: addr, Storage: map[common.Hash]string{}}, {Address: addr, Storage: map[common.Hash]string{}}},
+		},
+		{
+			// Storage that precedes the address of an account is buffered
+			input: `{"accounts":{"a":{"code":"0x60",` + storage + `,"address":"` + addr.Hex() + `","nonce":4},` +
+				`"b":{"address":"` + addr.Hex() + `",` + storage + `,"code":"0x60","nonce":4}}}`,
+			accounts: []*OvmDumpAccount{
+				{Address: addr, Code: "0x60", Storage: map[common.Hash]string{{31: 0x2}: "0x03"}, Nonce: 4},
+				{Address: addr, Code: "0x60", Storage: map[common.Hash]string{{31: 0x2}: "0x03"}, Nonce: 4},
+			},
+		},
+		{input: `{"commit":"abc"}`, fail: true},
+		{input: `[]`, fail: true},
+		{input: `{"accounts":{"a":{}}}`, fail: true},
+		{input: `{"accounts":{"OVM_ETH":{"code":"0x",` + storage + `}}}`, fail: true},
+		{input: `{"accounts":{"a":1}}`, fail: true},
+		{input: `{"accounts":{"a":{"address":"0x01","storage":{"0x02":"0x03"}}}}`, fail: true},
+	}
+	for i, test := range tests {
+		accounts, err := ReadOverrides(strings.NewReader(test.input))
+		if test.fail {
+			if err == nil {
+				t.Errorf("Test %d: expected failure", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i, err)
+		}
+		if !reflect.DeepEqual(accounts, test.accounts) {
+			t.Errorf("Test %d: have %v, want %v", i, accounts, test.accounts)
+		}
+	}
+}
+
+func TestTransformConflicts(t *testing.T) {
+	addr := common.Address{0x1}
+	if _, err := NewTransform([]common.Address{addr}, []*OvmDumpAccount{{Address: addr}}); err == nil {
+		t.Fatal("Expected error for excluded override")
+	}
+	if _, err := NewTransform(nil, []*OvmDumpAccount{{Address: addr}, {Address: addr}}); err == nil {
+		t.Fatal("Expected error for duplicate override")
+	}
+}
@@ -0,0 +1,141 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+)
+
+// Transform filters and rewrites accounts as they are streamed into a dump.
+// Excluded accounts are dropped. Overrides replace the code and nonce of a
+// predeploy and replace or add storage slots. Overrides for accounts that
+// are not found in the state are returned by Remaining.
+type Transform struct {
+	exclude   map[common.Address]bool
+	overrides map[common.Address]*OvmDumpAccount
+	applied   map[common.Address]bool
+}
+
+// NewTransform creates a Transform from a set of excluded addresses and a
+// set of predeploy overrides, which may be nil. The overrides are held in
+// memory.
+func NewTransform(exclude []common.Address, overrides []*OvmDumpAccount) (*Transform, error) {
+	t := &Transform{
+		exclude:   make(map[common.Address]bool),
+		overrides: make(map[common.Address]*OvmDumpAccount),
+		applied:   make(map[common.Address]bool),
+	}
+	for _, addr := range exclude {
+		t.exclude[addr] = true
+	}
+	for _, account := range overrides {
+		if t.exclude[account.Address] {
+			return nil, fmt.Errorf("account %s is both excluded and overridden", account.Address.Hex())
+		}
+		if _, ok := t.overrides[account.Address]; ok {
+			return nil, fmt.Errorf("duplicate override for account %s", account.Address.Hex())
+		}
+		override := *account
+		if override.Code != "" {
+			code, err := hexutil.Decode(override.Code)
+			if err != nil {
+				return nil, fmt.Errorf("invalid code for account %s: %w", account.Address.Hex(), err)
+			}
+			override.CodeHash = crypto.Keccak256Hash(code).Hex()
+		}
+		t.overrides[account.Address] = &override
+	}
+	return t, nil
+}
+
+// ReadOverrides reads the accounts of a dump to be used as overrides.
+func ReadOverrides(r io.Reader) ([]*OvmDumpAccount, error) {
+	collector := new(accountCollector)
+	if err := NewDecoder(r).Decode(collector); err != nil {
+		return nil, err
+	}
+	return collector.accounts, nil
+}
+
+// Writer returns an AccountWriter that applies the transform to the
+// accounts written to it and passes them on to w.
+func (t *Transform) Writer(w AccountWriter) AccountWriter {
+	return &transformWriter{t: t, w: w}
+}
+
+// Remaining returns the overrides for accounts that were not written to a
+// Writer, sorted by address.
+func (t *Transform) Remaining() []*OvmDumpAccount {
+	var accounts []*OvmDumpAccount
+	for addr, override := range t.overrides {
+		if !t.applied[addr] {
+			accounts = append(accounts, override)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address[:], accounts[j].Address[:]) < 0
+	})
+	return accounts
+}
+
+// transformWriter applies a Transform to a stream of accounts.
+type transformWriter struct {
+	t        *Transform
+	w        AccountWriter
+	excluded bool
+	override *OvmDumpAccount
+}
+
+func (tw *transformWriter) BeginAccount(addr common.Address) error {
+	if tw.t.exclude[addr] {
+		tw.excluded = true
+		return nil
+	}
+	if override, ok := tw.t.overrides[addr]; ok {
+		tw.t.applied[addr] = true
+		tw.override = override
+	}
+	return tw.w.BeginAccount(addr)
+}
+
+func (tw *transformWriter) Storage(key common.Hash, value string) error {
+	if tw.excluded {
+		return nil
+	}
+	// Overridden slots are written at the end of the account
+	if tw.override != nil {
+		if _, ok := tw.override.Storage[key]; ok {
+			return nil
+		}
+	}
+	return tw.w.Storage(key, value)
+}
+
+func (tw *transformWriter) EndAccount(account *OvmDumpAccount) error {
+	if tw.excluded {
+		tw.excluded = false
+		return nil
+	}
+	override := tw.override
+	if override == nil {
+		return tw.w.EndAccount(account)
+	}
+	tw.override = nil
+
+	if override.Code != "" {
+		account.Code = override.Code
+		account.CodeHash = override.CodeHash
+	}
+	if override.Nonce != 0 {
+		account.Nonce = override.Nonce
+	}
+	if err := WriteAccount(storageWriter{tw.w}, &OvmDumpAccount{Storage: override.Storage}); err != nil {
+		return err
+	}
+	return tw.w.EndAccount(account)
+}
@@ -3,6 +3,7 @@ package dump
 import (
 	"github.com/ethereum-optimism/optimism/l2geth/accounts/abi"
 	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
 )
 
 type OvmDumpAccount struct {
@@ -12,6 +13,7 @@ type OvmDumpAccount struct {
 	Storage  map[common.Hash]string `json:"storage"`
 	ABI      abi.ABI                `json:"abi"`
 	Nonce    uint64                 `json:"nonce"`
+	Balance  *hexutil.Big           `json:"balance,omitempty"`
 }
 
 type OvmDump struct {
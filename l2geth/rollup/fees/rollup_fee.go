@@ -251,6 +251,51 @@ func DeriveL1GasDataInfo(msg Message, state StateDB) (*big.Int, *big.Int, *big.I
 	return l1Fee, l1GasPrice, l1GasUsed, scalar, nil
 }
 
+// FeeBreakdown is the L1 security fee of a transaction together with the
+// values of the gas price oracle that it is derived from
+type FeeBreakdown struct {
+	// L1 gas used by the transaction, including the overhead
+	L1GasUsed  *big.Int
+	L1GasPrice *big.Int
+	Overhead   *big.Int
+	Scalar     *big.Float
+	// L2 gas price of the gas price oracle
+	L2GasPrice *big.Int
+	// L1 security fee in wei
+	L1Fee *big.Int
+	// L2 gas that pays for the L1 security fee
+	L2ExtraGas *big.Int
+}
+
+// DeriveFeeBreakdown reads the gas price oracle to compute the L1 security
+// fee of a message. The fee covers the RLP encoded transaction before the fee
+// token fork and only its calldata afterwards.
+func DeriveFeeBreakdown(msg Message, state StateDB, isFeeTokenUpdate bool) (*FeeBreakdown, error) {
+	data := msg.Data()
+	if !isFeeTokenUpdate {
+		raw, err := rlpEncode(asTransaction(msg))
+		if err != nil {
+			return nil, err
+		}
+		data = raw
+	}
+	l1GasPrice, overhead, scalar, l2GasPrice := readGPOStorageSlots(rcfg.L2GasPriceOracleAddress, state)
+	l1Fee := CalculateL1Fee(data, overhead, l1GasPrice, scalar)
+	l2ExtraGas := new(big.Int)
+	if l2GasPrice.BitLen() != 0 {
+		l2ExtraGas.Div(l1Fee, l2GasPrice)
+	}
+	return &FeeBreakdown{
+		L1GasUsed:  CalculateL1GasUsed(data, overhead),
+		L1GasPrice: l1GasPrice,
+		Overhead:   overhead,
+		Scalar:     scalar,
+		L2GasPrice: l2GasPrice,
+		L1Fee:      l1Fee,
+		L2ExtraGas: l2ExtraGas,
+	}, nil
+}
+
 func readGPOStorageSlots(addr common.Address, state StateDB) (*big.Int, *big.Int, *big.Float, *big.Int) {
 	l2GasPrice := state.GetState(addr, rcfg.L2GasPriceSlot)
 	l1GasPrice := state.GetState(addr, rcfg.L1GasPriceSlot)
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/rollup/rcfg"
 )
 
 func TestPaysEnough(t *testing.T) {
@@ -105,3 +107,51 @@ func TestPaysEnough(t *testing.T) {
 		})
 	}
 }
+
+// testStateDB is a StateDB backed by a map
+type testStateDB map[common.Address]map[common.Hash]common.Hash
+
+func (s testStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	return s[addr][key]
+}
+
+func TestDeriveFeeBreakdown(t *testing.T) {
+	state := testStateDB{
+		rcfg.L2GasPriceOracleAddress: {
+			rcfg.L2GasPriceSlot: common.BigToHash(big.NewInt(1000)),
+			rcfg.L1GasPriceSlot: common.BigToHash(big.NewInt(2000)),
+			rcfg.OverheadSlot:   common.BigToHash(big.NewInt(2750)),
+			rcfg.ScalarSlot:     common.BigToHash(big.NewInt(1500000)),
+			rcfg.DecimalsSlot:   common.BigToHash(big.NewInt(6)),
+		},
+	}
+	to := common.Address{0x01}
+	msg := types.NewMessage(common.Address{}, &to, 0, new(big.Int), 21000, big.NewInt(1000), []byte{0x00, 0x01, 0x02}, false, nil, 0, nil, types.QueueOriginSequencer)
+
+	for _, isFeeTokenUpdate := range []bool{false, true} {
+		breakdown, err := DeriveFeeBreakdown(msg, state, isFeeTokenUpdate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if breakdown.Overhead.Cmp(big.NewInt(2750)) != 0 || breakdown.Scalar.Cmp(big.NewFloat(1.5)) != 0 {
+			t.Errorf("fee token fork %t: unexpected overhead %d or scalar %s", isFeeTokenUpdate, breakdown.Overhead, breakdown.Scalar)
+		}
+		l1Fee, _ := CalculateL1MsgFee(msg, state, nil)
+		l2ExtraGas, _ := CalculateL2GasForL1Msg(msg, state, nil)
+		if isFeeTokenUpdate {
+			l1Fee, _ = CalculateL1DataFee(msg.Data(), state, nil)
+			l2ExtraGas, _ = CalculateL1GasFromState(msg.Data(), state, nil)
+		}
+		if breakdown.L1Fee.Cmp(l1Fee) != 0 {
+			t.Errorf("fee token fork %t: L1 fee %d, want %d", isFeeTokenUpdate, breakdown.L1Fee, l1Fee)
+		}
+		if breakdown.L2ExtraGas.Cmp(l2ExtraGas) != 0 {
+			t.Errorf("fee token fork %t: L2 extra gas %d, want %d", isFeeTokenUpdate, breakdown.L2ExtraGas, l2ExtraGas)
+		}
+		expected := new(big.Int).Mul(breakdown.L1GasUsed, breakdown.L1GasPrice)
+		expected = mulByFloat(expected, breakdown.Scalar)
+		if breakdown.L1Fee.Cmp(expected) != 0 {
+			t.Errorf("fee token fork %t: L1 fee %d, want %d", isFeeTokenUpdate, breakdown.L1Fee, expected)
+		}
+	}
+}
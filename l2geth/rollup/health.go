@@ -0,0 +1,193 @@
+package rollup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+)
+
+// healthThresholds configures when the SyncService is considered unhealthy.
+// A zero value disables the corresponding check.
+type healthThresholds struct {
+	// Maximum number of transactions that the local tip may be behind the
+	// remote tip
+	MaxIndexLag uint64
+	// Maximum number of enqueue transactions that the local tip may be
+	// behind the remote tip
+	MaxQueueLag uint64
+	// Maximum age of the L1 timestamp of the latest L1 context
+	MaxL1ContextAge time.Duration
+	// Maximum time since a transaction was last applied
+	MaxTxAge time.Duration
+}
+
+// HealthStatus represents the sync status of the SyncService compared to the
+// remote server. Ages are in seconds.
+type HealthStatus struct {
+	Healthy          bool     `json:"healthy"`
+	Syncing          bool     `json:"syncing"`
+	LocalIndex       *uint64  `json:"localIndex"`
+	RemoteIndex      *uint64  `json:"remoteIndex"`
+	IndexLag         uint64   `json:"indexLag"`
+	VerifiedIndex    *uint64  `json:"verifiedIndex"`
+	LocalQueueIndex  *uint64  `json:"localQueueIndex"`
+	RemoteQueueIndex *uint64  `json:"remoteQueueIndex"`
+	QueueLag         uint64   `json:"queueLag"`
+	L1ContextAge     uint64   `json:"l1ContextAge"`
+	LastAppliedAge   uint64   `json:"lastAppliedAge"`
+	Problems         []string `json:"problems"`
+}
+
+// remoteTip is the latest index of the remote server, or the error that was
+// returned instead, as last seen by the SyncService
+type remoteTip struct {
+	index *uint64
+	err   error
+}
+
+// getRemoteIndex gets the latest transaction index of the remote server and
+// records it for the health endpoint when it is fetched from the backend
+// that the SyncService syncs with
+func (s *SyncService) getRemoteIndex(backend Backend) (*uint64, error) {
+	index, err := s.client.GetLatestTransactionIndex(backend)
+	if backend == s.backend {
+		s.remoteIndex.Store(remoteTip{index: index, err: err})
+	}
+	return index, err
+}
+
+// getRemoteQueueIndex gets the latest enqueue index of the remote server and
+// records it for the health endpoint
+func (s *SyncService) getRemoteQueueIndex() (*uint64, error) {
+	index, err := s.client.GetLatestEnqueueIndex()
+	s.remoteQueueIndex.Store(remoteTip{index: index, err: err})
+	return index, err
+}
+
+// loadRemoteTip returns the recorded remote tip, which is an error if the
+// remote server was not polled yet
+func loadRemoteTip(tip *atomic.Value) (*uint64, error) {
+	recorded, ok := tip.Load().(remoteTip)
+	if !ok {
+		return nil, errors.New("not polled yet")
+	}
+	return recorded.index, recorded.err
+}
+
+// setLastApplied records the time that a transaction was last applied
+func (s *SyncService) setLastApplied(t time.Time) {
+	s.lastApplied.Store(t)
+}
+
+// getLastApplied returns the time that a transaction was last applied, or
+// the time that the SyncService was created if none were applied since
+func (s *SyncService) getLastApplied() time.Time {
+	return s.lastApplied.Load().(time.Time)
+}
+
+// GetHealthStatus compares the local tip to the tip of the remote server and
+// checks the results against the health thresholds. The remote tip is the one
+// last seen by the SyncService, so that the health endpoint does not query
+// the remote server. Failing to reach the remote server is reported as a
+// problem rather than an error.
+func (s *SyncService) GetHealthStatus() *HealthStatus {
+	now := time.Now()
+	status := &HealthStatus{
+		Syncing:         s.IsSyncing(),
+		LocalIndex:      s.GetLatestIndex(),
+		VerifiedIndex:   s.GetLatestVerifiedIndex(),
+		LocalQueueIndex: s.GetLatestEnqueueIndex(),
+		Problems:        []string{},
+	}
+	if ts := s.GetLatestL1Timestamp(); uint64(now.Unix()) > ts {
+		status.L1ContextAge = uint64(now.Unix()) - ts
+	}
+	status.LastAppliedAge = uint64(now.Sub(s.getLastApplied()) / time.Second)
+
+	if status.Syncing {
+		status.Problems = append(status.Problems, "syncing")
+	}
+
+	remoteIndex, err := loadRemoteTip(&s.remoteIndex)
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("cannot get remote index: %v", err))
+	} else {
+		status.RemoteIndex = remoteIndex
+		status.IndexLag = lag(status.LocalIndex, remoteIndex)
+	}
+	remoteQueueIndex, err := loadRemoteTip(&s.remoteQueueIndex)
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("cannot get remote queue index: %v", err))
+	} else {
+		status.RemoteQueueIndex = remoteQueueIndex
+		status.QueueLag = lag(status.LocalQueueIndex, remoteQueueIndex)
+	}
+
+	thresholds := s.healthThresholds
+	if thresholds.MaxIndexLag != 0 && status.IndexLag > thresholds.MaxIndexLag {
+		status.Problems = append(status.Problems, fmt.Sprintf("index lag %d exceeds %d", status.IndexLag, thresholds.MaxIndexLag))
+	}
+	if thresholds.MaxQueueLag != 0 && status.QueueLag > thresholds.MaxQueueLag {
+		status.Problems = append(status.Problems, fmt.Sprintf("queue lag %d exceeds %d", status.QueueLag, thresholds.MaxQueueLag))
+	}
+	if age := time.Duration(status.L1ContextAge) * time.Second; thresholds.MaxL1ContextAge != 0 && age > thresholds.MaxL1ContextAge {
+		status.Problems = append(status.Problems, fmt.Sprintf("L1 context age %s exceeds %s", age, thresholds.MaxL1ContextAge))
+	}
+	if age := time.Duration(status.LastAppliedAge) * time.Second; thresholds.MaxTxAge != 0 && age > thresholds.MaxTxAge {
+		status.Problems = append(status.Problems, fmt.Sprintf("last applied transaction age %s exceeds %s", age, thresholds.MaxTxAge))
+	}
+	status.Healthy = len(status.Problems) == 0
+	return status
+}
+
+// lag returns the number of elements that local is behind remote
+func lag(local, remote *uint64) uint64 {
+	if remote == nil {
+		return 0
+	}
+	if local == nil {
+		return *remote + 1
+	}
+	if *remote > *local {
+		return *remote - *local
+	}
+	return 0
+}
+
+// ServeHTTP serves the HealthStatus as JSON, with a 503 status code when the
+// SyncService is not healthy so that it can be used as a readiness check
+func (s *SyncService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := s.GetHealthStatus()
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Debug("Cannot write health status", "msg", err)
+	}
+}
+
+// startHealthServer serves the health endpoint at /health on the configured
+// address
+func (s *SyncService) startHealthServer() error {
+	listener, err := net.Listen("tcp", s.healthAddr)
+	if err != nil {
+		return fmt.Errorf("Cannot start health server: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/health", s)
+	s.healthServer = &http.Server{Handler: mux}
+	log.Info("Starting health server", "addr", fmt.Sprintf("http://%s/health", listener.Addr()))
+	go func() {
+		if err := s.healthServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error("Failure in running health server", "err", err)
+		}
+	}()
+	return nil
+}
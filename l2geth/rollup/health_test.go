@@ -0,0 +1,98 @@
+package rollup
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+func TestSyncServiceHealthStatus(t *testing.T) {
+	service, _, sub, err := newTestSyncService(true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// The remote server is at index 9 and queue index 2
+	setupMockClient(service, map[string]interface{}{
+		"GetEnqueue": []*types.Transaction{
+			setMockQueueIndex(mockTx(), 2),
+		},
+		"GetTransaction": []*types.Transaction{
+			setMockTxIndex(mockTx(), 9),
+		},
+	})
+	service.SetLatestIndex(newUint64(4))
+	service.SetLatestEnqueueIndex(newUint64(1))
+	service.SetLatestL1Timestamp(uint64(time.Now().Add(-time.Hour).Unix()))
+	service.setSyncStatus(false)
+
+	// The remote tip is unknown until the SyncService polls it
+	status := service.GetHealthStatus()
+	if status.Healthy || len(status.Problems) != 2 || status.RemoteIndex != nil {
+		t.Fatalf("Unexpected problems before polling: %v", status.Problems)
+	}
+	if _, err := service.getRemoteIndex(service.backend); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.getRemoteQueueIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	status = service.GetHealthStatus()
+	if !status.Healthy {
+		t.Fatalf("Unexpected problems without thresholds: %v", status.Problems)
+	}
+	if status.IndexLag != 5 || status.QueueLag != 1 {
+		t.Fatalf("Unexpected lag: index %d, queue %d", status.IndexLag, status.QueueLag)
+	}
+	if status.L1ContextAge < 3600 {
+		t.Fatalf("Unexpected L1 context age: %d", status.L1ContextAge)
+	}
+
+	service.healthThresholds = healthThresholds{
+		MaxIndexLag:     5,
+		MaxQueueLag:     1,
+		MaxL1ContextAge: 2 * time.Hour,
+		MaxTxAge:        time.Minute,
+	}
+	if status := service.GetHealthStatus(); !status.Healthy {
+		t.Fatalf("Unexpected problems within thresholds: %v", status.Problems)
+	}
+
+	// Each threshold that is exceeded is reported
+	service.healthThresholds = healthThresholds{
+		MaxIndexLag:     4,
+		MaxQueueLag:     1,
+		MaxL1ContextAge: time.Minute,
+		MaxTxAge:        time.Minute,
+	}
+	status = service.GetHealthStatus()
+	if status.Healthy || len(status.Problems) != 2 {
+		t.Fatalf("Unexpected problems: %v", status.Problems)
+	}
+
+	recorder := httptest.NewRecorder()
+	service.ServeHTTP(recorder, httptest.NewRequest("GET", "/health", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Unexpected status code: %d", recorder.Code)
+	}
+	var served HealthStatus
+	if err := json.NewDecoder(recorder.Body).Decode(&served); err != nil {
+		t.Fatal(err)
+	}
+	if served.Healthy || *served.RemoteIndex != 9 || *served.LocalIndex != 4 {
+		t.Fatal("Unexpected health status served")
+	}
+
+	service.healthThresholds = healthThresholds{}
+	recorder = httptest.NewRecorder()
+	service.ServeHTTP(recorder, httptest.NewRequest("GET", "/health", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code: %d", recorder.Code)
+	}
+}
@@ -0,0 +1,290 @@
+package rollup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/andybalholm/brotli"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/klauspost/compress/zstd"
+)
+
+// appendSequencerBatchSelector is the function selector of
+// `appendSequencerBatch()`, which reads its arguments from the calldata
+// using a custom encoding
+var appendSequencerBatchSelector = crypto.Keccak256([]byte("appendSequencerBatch()"))[:4]
+
+// turingVersionV1 is the version byte that the batch submitter prefixes to
+// the data of a batched transaction with a Turing payload length
+const turingVersionV1 = 1
+
+// turingMethodIDs are the method IDs of `GetResponse` and `GetRandom`, one of
+// which prefixes the Turing payload appended to a batched transaction
+var turingMethodIDs = [][]byte{
+	{0x7d, 0x93, 0x61, 0x6c},
+	{0x49, 0x3d, 0x57, 0xd6},
+}
+
+// errMalformedBatch represents the error case of batch calldata that cannot
+// be decoded
+var errMalformedBatch = errors.New("malformed batch")
+
+// errUnsupportedBatchType represents the error case of a batch that is
+// encoded with a type that cannot be decoded
+var errUnsupportedBatchType = errors.New("unsupported batch type")
+
+// The batch types signaled by the block number of a marker context, which is
+// a first context with a zero timestamp. Legacy batches have no marker context.
+const (
+	batchTypeLegacy       = -1
+	batchTypeBrotli       = 0
+	batchTypeZstd         = 1
+	batchTypeZstdColumnar = 2
+)
+
+// numTxFields is the number of RLP items of a batched transaction, which are
+// stored as separate columns by zstd columnar batches
+const numTxFields = 9
+
+// sequencerBatchContext groups consecutive transactions of a sequencer batch
+// that share the same L1 block number and timestamp
+type sequencerBatchContext struct {
+	NumSequencedTxs       uint64
+	NumSubsequentQueueTxs uint64
+	Timestamp             uint64
+	BlockNumber           uint64
+}
+
+// sequencerBatch represents the decoded calldata of `appendSequencerBatch()`
+type sequencerBatch struct {
+	ShouldStartAtElement  uint64
+	TotalElementsToAppend uint64
+	Contexts              []sequencerBatchContext
+	Txs                   []*types.Transaction
+	// Raw holds the RLP encoding of each transaction as it was batched
+	Raw [][]byte
+}
+
+// decodeSequencerBatch decodes the calldata of an `appendSequencerBatch()`
+// transaction, including the function selector. The calldata is encoded as:
+//
+//	should_start_at_element:        5 bytes
+//	total_elements_to_append:       3 bytes
+//	num_contexts:                   3 bytes
+//	  num_contexts * batch_context: num_contexts * 16 bytes
+//	[num txs ommitted]
+//	  tx_len:                       3 bytes
+//	  tx_bytes:                     tx_len bytes
+//
+// If the first context has a zero timestamp, it is a marker context whose
+// block number signals the compression of the transactions: 0 for brotli,
+// 1 for zstd and 2 for zstd with the fields of the transactions in columns.
+func decodeSequencerBatch(calldata []byte) (*sequencerBatch, error) {
+	if len(calldata) < 4 || !bytes.Equal(calldata[:4], appendSequencerBatchSelector) {
+		return nil, fmt.Errorf("%w: unexpected function selector", errMalformedBatch)
+	}
+	r := bytes.NewReader(calldata[4:])
+
+	batch := new(sequencerBatch)
+	var err error
+	if batch.ShouldStartAtElement, err = readBatchUint(r, 5); err != nil {
+		return nil, err
+	}
+	if batch.TotalElementsToAppend, err = readBatchUint(r, 3); err != nil {
+		return nil, err
+	}
+	numContexts, err := readBatchUint(r, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	batchType := batchTypeLegacy
+	for i := uint64(0); i < numContexts; i++ {
+		var context sequencerBatchContext
+		if context.NumSequencedTxs, err = readBatchUint(r, 3); err != nil {
+			return nil, err
+		}
+		if context.NumSubsequentQueueTxs, err = readBatchUint(r, 3); err != nil {
+			return nil, err
+		}
+		if context.Timestamp, err = readBatchUint(r, 5); err != nil {
+			return nil, err
+		}
+		if context.BlockNumber, err = readBatchUint(r, 5); err != nil {
+			return nil, err
+		}
+		if i == 0 && context.Timestamp == 0 {
+			batchType = int(context.BlockNumber)
+			continue
+		}
+		batch.Contexts = append(batch.Contexts, context)
+	}
+
+	switch batchType {
+	case batchTypeLegacy:
+		err = readBatchTxs(r, batch)
+	case batchTypeBrotli:
+		var data []byte
+		if data, err = ioutil.ReadAll(brotli.NewReader(r)); err != nil {
+			return nil, fmt.Errorf("Cannot decompress batch: %w", err)
+		}
+		err = readBatchTxs(bytes.NewReader(data), batch)
+	case batchTypeZstd:
+		var data []byte
+		if data, err = zstdDecompress(r); err != nil {
+			return nil, fmt.Errorf("Cannot decompress batch: %w", err)
+		}
+		err = readBatchTxs(bytes.NewReader(data), batch)
+	case batchTypeZstdColumnar:
+		var data []byte
+		if data, err = zstdDecompress(r); err != nil {
+			return nil, fmt.Errorf("Cannot decompress batch: %w", err)
+		}
+		err = readColumnarBatchTxs(bytes.NewReader(data), batch)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedBatchType, batchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure that the contexts account for every element of the batch
+	var numSequenced, numQueued uint64
+	for _, context := range batch.Contexts {
+		numSequenced += context.NumSequencedTxs
+		numQueued += context.NumSubsequentQueueTxs
+	}
+	if numSequenced != uint64(len(batch.Txs)) {
+		return nil, fmt.Errorf("%w: contexts reference %d transactions, found %d",
+			errMalformedBatch, numSequenced, len(batch.Txs))
+	}
+	if numSequenced+numQueued != batch.TotalElementsToAppend {
+		return nil, fmt.Errorf("%w: contexts reference %d elements, expected %d",
+			errMalformedBatch, numSequenced+numQueued, batch.TotalElementsToAppend)
+	}
+	return batch, nil
+}
+
+// readBatchTxs reads length prefixed transactions into the batch. The number
+// of transactions is ommitted from the encoding, so the reader is consumed.
+func readBatchTxs(r io.Reader, batch *sequencerBatch) error {
+	for {
+		txLen, err := readBatchUint(r, 3)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		raw := make([]byte, txLen)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return fmt.Errorf("%w: truncated transaction", errMalformedBatch)
+		}
+		if err := appendBatchTx(batch, raw); err != nil {
+			return err
+		}
+	}
+}
+
+// readColumnarBatchTxs reads transactions encoded in columns into the batch.
+// The number of transactions is followed by the RLP items of each field of
+// every transaction, one field after the other.
+func readColumnarBatchTxs(r io.Reader, batch *sequencerBatch) error {
+	numTxs, err := readBatchUint(r, 3)
+	if err == io.EOF {
+		return fmt.Errorf("%w: missing number of transactions", errMalformedBatch)
+	} else if err != nil {
+		return err
+	}
+	// The columns are grown as items are decoded so that the memory used by
+	// a malformed batch is bounded by its size
+	columns := make([][]rlp.RawValue, numTxFields)
+	stream := rlp.NewStream(r, 0)
+	for j := range columns {
+		for i := uint64(0); i < numTxs; i++ {
+			item, err := stream.Raw()
+			if err != nil {
+				return fmt.Errorf("%w: truncated column %d", errMalformedBatch, j)
+			}
+			columns[j] = append(columns[j], item)
+		}
+	}
+	fields := make([]rlp.RawValue, numTxFields)
+	for i := uint64(0); i < numTxs; i++ {
+		for j := range columns {
+			fields[j] = columns[j][i]
+		}
+		raw, err := rlp.EncodeToBytes(fields)
+		if err != nil {
+			return err
+		}
+		if err := appendBatchTx(batch, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendBatchTx decodes a batched transaction and appends it to the batch
+func appendBatchTx(batch *sequencerBatch, raw []byte) error {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, tx); err != nil {
+		return fmt.Errorf("Cannot decode batched transaction: %w", err)
+	}
+	batch.Txs = append(batch.Txs, tx)
+	batch.Raw = append(batch.Raw, raw)
+	return nil
+}
+
+// zstdDecompress decompresses the remainder of a zstd batch. Batches that
+// were compressed with a dictionary cannot be decoded.
+func zstdDecompress(r io.Reader) ([]byte, error) {
+	zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return ioutil.ReadAll(zr)
+}
+
+// readBatchUint reads an n byte big endian integer. io.EOF is returned only
+// if the reader is consumed before the first byte is read.
+func readBatchUint(r io.Reader, n int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[8-n:]); err == io.EOF {
+		return 0, io.EOF
+	} else if err != nil {
+		return 0, fmt.Errorf("%w: %v", errMalformedBatch, err)
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// decodeTuringV1 splits the data of a batched transaction into the original
+// transaction data and the Turing payload. The batch submitter prefixes the
+// data with a version byte and a two byte Turing payload length, and appends
+// the Turing payload. Data that does not follow this format, including data
+// with another version byte, is returned unmodified.
+func decodeTuringV1(data []byte) ([]byte, []byte) {
+	if len(data) < 3 || data[0] != turingVersionV1 {
+		return data, nil
+	}
+	turingLength := int(binary.BigEndian.Uint16(data[1:3]))
+	if turingLength == 0 {
+		return data[3:], nil
+	}
+	if turingLength > len(data)-3 {
+		return data, nil
+	}
+	turing := data[len(data)-turingLength:]
+	for _, methodID := range turingMethodIDs {
+		if len(turing) >= len(methodID) && bytes.Equal(turing[:len(methodID)], methodID) {
+			return data[3 : len(data)-turingLength], turing
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,998 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum-optimism/optimism/l2geth"
+	"github.com/ethereum-optimism/optimism/l2geth/accounts/abi"
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/ethclient"
+	"github.com/ethereum-optimism/optimism/l2geth/ethdb"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+// ctcEventsABI is the ABI of the Canonical Transaction Chain events that are
+// ingested by the L1Client
+const ctcEventsABI = `[
+	{"type":"event","name":"TransactionEnqueued","anonymous":false,"inputs":[
+		{"name":"_l1TxOrigin","type":"address","indexed":true},
+		{"name":"_target","type":"address","indexed":true},
+		{"name":"_gasLimit","type":"uint256","indexed":false},
+		{"name":"_data","type":"bytes","indexed":false},
+		{"name":"_queueIndex","type":"uint256","indexed":true},
+		{"name":"_timestamp","type":"uint256","indexed":false}]},
+	{"type":"event","name":"TransactionBatchAppended","anonymous":false,"inputs":[
+		{"name":"_batchIndex","type":"uint256","indexed":true},
+		{"name":"_batchRoot","type":"bytes32","indexed":false},
+		{"name":"_batchSize","type":"uint256","indexed":false},
+		{"name":"_prevTotalElements","type":"uint256","indexed":false},
+		{"name":"_extraData","type":"bytes","indexed":false}]},
+	{"type":"event","name":"SequencerBatchAppended","anonymous":false,"inputs":[
+		{"name":"_startingQueueIndex","type":"uint256","indexed":false},
+		{"name":"_numQueueElements","type":"uint256","indexed":false},
+		{"name":"_totalElements","type":"uint256","indexed":false}]}
+]`
+
+var ctcABI = mustParseABI(ctcEventsABI)
+
+// The topics of the Canonical Transaction Chain events that are ingested
+var (
+	transactionEnqueuedTopic      = ctcABI.Events["TransactionEnqueued"].ID()
+	transactionBatchAppendedTopic = ctcABI.Events["TransactionBatchAppended"].ID()
+	sequencerBatchAppendedTopic   = ctcABI.Events["SequencerBatchAppended"].ID()
+)
+
+// errMissingElement represents the error case of an event that does not
+// directly follow the previously ingested element. This happens when the
+// layer one endpoint omits events.
+var errMissingElement = errors.New("missing element")
+
+// errL1ReorgTooDeep represents the error case of a layer one reorg that
+// replaced all of the blocks whose ingestion progress is retained
+var errL1ReorgTooDeep = errors.New("layer one reorg deeper than the ingestion checkpoints")
+
+// errL1BlockChanged represents the error case of a layer one block that was
+// replaced while its range was being ingested
+var errL1BlockChanged = errors.New("layer one block changed during ingestion")
+
+// errUnsupportedBackend represents the error case of querying the L1Client
+// for transactions that have not been batched to layer one
+var errUnsupportedBackend = errors.New("unsupported backend")
+
+const (
+	// defaultL1MaxBlockRange is the number of layer one blocks whose events
+	// are fetched and stored at once
+	defaultL1MaxBlockRange = 2000
+	// l1RequestTimeout bounds the time taken by a request to layer one
+	l1RequestTimeout = 30 * time.Second
+	// maxL1Checkpoints is the number of ingested ranges that can be rolled
+	// back when layer one reorgs
+	maxL1Checkpoints = 256
+)
+
+// L1Backend is the subset of a layer one JSON-RPC endpoint that is used by
+// the L1Client
+type L1Backend interface {
+	// EthContext returns the context of the block with the given number, or
+	// of the latest block if the number is nil
+	EthContext(ctx context.Context, number *big.Int) (*EthContext, error)
+	// FilterLogs returns the logs matching the query
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	// TransactionInput returns the sender and the calldata of a transaction
+	TransactionInput(ctx context.Context, hash common.Hash) (common.Address, []byte, error)
+}
+
+// L1ClientConfig configures the L1Client
+type L1ClientConfig struct {
+	// Address of the Canonical Transaction Chain
+	CanonicalTransactionChainAddress common.Address
+	// Layer one block from which events are ingested
+	CanonicalTransactionChainDeployHeight uint64
+	// Number of blocks to wait for before a block is ingested
+	Confirmations uint64
+	// Number of blocks whose events are fetched at once
+	MaxBlockRange uint64
+	// Layer two block height from which batched transactions carry the
+	// Turing v1 header
+	TuringV1Height uint64
+}
+
+// L1Client is a RollupClient that indexes the Canonical Transaction Chain by
+// reading its events and the calldata of the batches directly from layer one.
+// The index is stored in the node's database. Layer one is ingested up to the
+// latest confirmed block whenever the client is queried for the latest
+// elements or the sync status.
+type L1Client struct {
+	backend L1Backend
+	db      ethdb.Database
+	chainID *big.Int
+	cfg     L1ClientConfig
+	mu      sync.Mutex
+}
+
+// l1Progress represents the ingestion progress of the L1Client
+type l1Progress struct {
+	// Height is the last ingested layer one block
+	Height          uint64
+	NumEnqueues     uint64
+	NumTransactions uint64
+	NumBatches      uint64
+}
+
+// l1Checkpoint represents the ingestion progress after a range of layer one
+// blocks, along with the hash of the last block of the range
+type l1Checkpoint struct {
+	Progress l1Progress
+	Hash     common.Hash
+}
+
+// l1Enqueue represents an ingested `enqueue` transaction
+type l1Enqueue struct {
+	Origin      common.Address
+	Target      common.Address
+	GasLimit    uint64
+	Data        []byte
+	BlockNumber uint64
+	Timestamp   uint64
+	// Index is the CTC index, only valid once Confirmed
+	Confirmed bool
+	Index     uint64
+}
+
+// l1Transaction represents an ingested batched transaction. Sequencer
+// transactions hold their RLP encoding as it was batched, while L1 to L2
+// transactions reference the enqueue by queue index.
+type l1Transaction struct {
+	BatchIndex  uint64
+	BlockNumber uint64
+	Timestamp   uint64
+	QueueOrigin uint8
+	QueueIndex  uint64
+	Raw         []byte
+	Turing      []byte
+}
+
+// l1Batch represents an ingested transaction batch
+type l1Batch struct {
+	Root              common.Hash
+	Size              uint64
+	PrevTotalElements uint64
+	ExtraData         []byte
+	BlockNumber       uint64
+	Timestamp         uint64
+	Submitter         common.Address
+}
+
+// NewL1Client creates a new L1Client that reads from the given backend and
+// stores its index in db
+func NewL1Client(backend L1Backend, db ethdb.Database, chainID *big.Int, cfg L1ClientConfig) *L1Client {
+	if cfg.MaxBlockRange == 0 {
+		cfg.MaxBlockRange = defaultL1MaxBlockRange
+	}
+	return &L1Client{
+		backend: backend,
+		db:      db,
+		chainID: chainID,
+		cfg:     cfg,
+	}
+}
+
+// DialL1Client creates a new L1Client that reads from the layer one JSON-RPC
+// endpoint at the given url
+func DialL1Client(url string, db ethdb.Database, chainID *big.Int, cfg L1ClientConfig) (*L1Client, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot dial layer one: %w", err)
+	}
+	return NewL1Client(NewL1RPCBackend(client), db, chainID, cfg), nil
+}
+
+// GetEnqueue returns the ingested `enqueue` transaction by queue index
+func (c *L1Client) GetEnqueue(index uint64) (*types.Transaction, error) {
+	enqueue, err := c.readEnqueue(nil, index)
+	if err != nil {
+		return nil, err
+	}
+	return enqueue.toTransaction(index)
+}
+
+// GetLatestEnqueue ingests layer one and returns the `enqueue` transaction
+// with the greatest queue index
+func (c *L1Client) GetLatestEnqueue() (*types.Transaction, error) {
+	progress, err := c.sync()
+	if err != nil {
+		return nil, err
+	}
+	if progress.NumEnqueues == 0 {
+		return nil, errElementNotFound
+	}
+	return c.GetEnqueue(progress.NumEnqueues - 1)
+}
+
+// GetLatestEnqueueIndex ingests layer one and returns the greatest queue index
+func (c *L1Client) GetLatestEnqueueIndex() (*uint64, error) {
+	progress, err := c.sync()
+	if err != nil {
+		return nil, err
+	}
+	if progress.NumEnqueues == 0 {
+		return nil, errElementNotFound
+	}
+	index := progress.NumEnqueues - 1
+	return &index, nil
+}
+
+// GetTransaction returns the batched transaction by CTC index. Only
+// BackendL1 is supported as the sequencer is not queried.
+func (c *L1Client) GetTransaction(index uint64, backend Backend) (*types.Transaction, error) {
+	if backend != BackendL1 {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedBackend, backend)
+	}
+	return c.readTransaction(index)
+}
+
+// GetLatestTransaction ingests layer one and returns the batched transaction
+// with the greatest CTC index
+func (c *L1Client) GetLatestTransaction(backend Backend) (*types.Transaction, error) {
+	index, err := c.GetLatestTransactionIndex(backend)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTransaction(*index, backend)
+}
+
+// GetLatestTransactionIndex ingests layer one and returns the greatest CTC
+// index
+func (c *L1Client) GetLatestTransactionIndex(backend Backend) (*uint64, error) {
+	if backend != BackendL1 {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedBackend, backend)
+	}
+	progress, err := c.sync()
+	if err != nil {
+		return nil, err
+	}
+	if progress.NumTransactions == 0 {
+		return nil, errElementNotFound
+	}
+	index := progress.NumTransactions - 1
+	return &index, nil
+}
+
+// GetEthContext returns the EthContext of the layer one block by number
+func (c *L1Client) GetEthContext(blockNumber uint64) (*EthContext, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l1RequestTimeout)
+	defer cancel()
+	return c.backend.EthContext(ctx, new(big.Int).SetUint64(blockNumber))
+}
+
+// GetLatestEthContext returns the EthContext of the latest confirmed layer
+// one block
+func (c *L1Client) GetLatestEthContext() (*EthContext, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l1RequestTimeout)
+	defer cancel()
+	head, err := c.backend.EthContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot fetch eth context: %w", err)
+	}
+	if c.cfg.Confirmations == 0 {
+		return head, nil
+	}
+	number := uint64(0)
+	if head.BlockNumber > c.cfg.Confirmations {
+		number = head.BlockNumber - c.cfg.Confirmations
+	}
+	return c.backend.EthContext(ctx, new(big.Int).SetUint64(number))
+}
+
+// GetLastConfirmedEnqueue will get the last `enqueue` transaction that has
+// been batched up
+func (c *L1Client) GetLastConfirmedEnqueue() (*types.Transaction, error) {
+	return getLastConfirmedEnqueue(c)
+}
+
+// GetLatestTransactionBatch ingests layer one and returns the transaction
+// batch with the greatest index
+func (c *L1Client) GetLatestTransactionBatch() (*Batch, []*types.Transaction, error) {
+	index, err := c.GetLatestTransactionBatchIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.GetTransactionBatch(*index)
+}
+
+// GetLatestTransactionBatchIndex ingests layer one and returns the greatest
+// transaction batch index
+func (c *L1Client) GetLatestTransactionBatchIndex() (*uint64, error) {
+	progress, err := c.sync()
+	if err != nil {
+		return nil, err
+	}
+	if progress.NumBatches == 0 {
+		return nil, errElementNotFound
+	}
+	index := progress.NumBatches - 1
+	return &index, nil
+}
+
+// GetTransactionBatch returns the transaction batch by batch index
+func (c *L1Client) GetTransactionBatch(index uint64) (*Batch, []*types.Transaction, error) {
+	data := rawdb.ReadL1BatchRLP(c.db, index)
+	if len(data) == 0 {
+		return nil, nil, errElementNotFound
+	}
+	var batch l1Batch
+	if err := rlp.DecodeBytes(data, &batch); err != nil {
+		return nil, nil, fmt.Errorf("Cannot decode transaction batch %d: %w", index, err)
+	}
+	txs := make([]*types.Transaction, batch.Size)
+	for i := range txs {
+		tx, err := c.readTransaction(batch.PrevTotalElements + uint64(i))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Cannot read transaction batch %d: %w", index, err)
+		}
+		txs[i] = tx
+	}
+	return &Batch{
+		Index:             index,
+		Root:              batch.Root,
+		Size:              uint32(batch.Size),
+		PrevTotalElements: uint32(batch.PrevTotalElements),
+		ExtraData:         batch.ExtraData,
+		BlockNumber:       batch.BlockNumber,
+		Timestamp:         batch.Timestamp,
+		Submitter:         batch.Submitter,
+	}, txs, nil
+}
+
+// SyncStatus ingests layer one up to the latest confirmed block. As the
+// ingestion completes before returning, the client is never reported as
+// syncing.
+func (c *L1Client) SyncStatus(backend Backend) (*SyncStatus, error) {
+	if backend != BackendL1 {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedBackend, backend)
+	}
+	progress, err := c.sync()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot sync layer one: %w", err)
+	}
+	var index uint64
+	if progress.NumTransactions > 0 {
+		index = progress.NumTransactions - 1
+	}
+	return &SyncStatus{
+		Syncing:                      false,
+		HighestKnownTransactionIndex: index,
+		CurrentTransactionIndex:      index,
+	}, nil
+}
+
+// sync ingests the events of the Canonical Transaction Chain up to the latest
+// confirmed layer one block and returns the resulting progress. The events
+// are ingested in ranges of blocks, each of which is stored atomically. If
+// the last ingested block is no longer canonical, the ingestion is first
+// rolled back to the latest range that still is.
+func (c *L1Client) sync() (*l1Progress, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	progress, err := c.readProgress()
+	if err != nil {
+		return nil, err
+	}
+	checkpoints, err := c.readCheckpoints()
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		if progress, checkpoints, err = c.handleReorg(progress, checkpoints); err != nil {
+			return nil, err
+		}
+	}
+	head, err := c.GetLatestEthContext()
+	if err != nil {
+		return nil, err
+	}
+
+	start := c.cfg.CanonicalTransactionChainDeployHeight
+	if progress != nil {
+		start = progress.Height + 1
+	} else {
+		progress = &l1Progress{}
+	}
+	for start <= head.BlockNumber {
+		end := start + c.cfg.MaxBlockRange - 1
+		if end > head.BlockNumber {
+			end = head.BlockNumber
+		}
+		next, err := c.syncRange(progress, &checkpoints, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot ingest layer one blocks %d to %d: %w", start, end, err)
+		}
+		log.Debug("Ingested layer one blocks", "start", start, "end", end,
+			"enqueues", next.NumEnqueues, "transactions", next.NumTransactions, "batches", next.NumBatches)
+		progress = next
+		start = end + 1
+	}
+	return progress, nil
+}
+
+// syncRange ingests the events emitted by the Canonical Transaction Chain
+// within the given range of layer one blocks and stores them in a single
+// database batch along with the resulting progress, which is also appended
+// to the checkpoints.
+func (c *L1Client) syncRange(progress *l1Progress, checkpoints *[]l1Checkpoint, start, end uint64) (*l1Progress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l1RequestTimeout)
+	defer cancel()
+	block, err := c.backend.EthContext(ctx, new(big.Int).SetUint64(end))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot fetch block %d: %w", end, err)
+	}
+	logs, err := c.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(start),
+		ToBlock:   new(big.Int).SetUint64(end),
+		Addresses: []common.Address{c.cfg.CanonicalTransactionChainAddress},
+		Topics: [][]common.Hash{{
+			transactionEnqueuedTopic,
+			transactionBatchAppendedTopic,
+			sequencerBatchAppendedTopic,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot filter logs: %w", err)
+	}
+
+	next := *progress
+	next.Height = end
+	batch := &l1IngestionBatch{
+		Batch:    c.db.NewBatch(),
+		enqueues: make(map[uint64]*l1Enqueue),
+	}
+	var batchAppended *types.Log
+	for i := range logs {
+		event := &logs[i]
+		if len(event.Topics) == 0 {
+			continue
+		}
+		switch event.Topics[0] {
+		case transactionEnqueuedTopic:
+			if err := c.ingestEnqueue(batch, &next, event); err != nil {
+				return nil, err
+			}
+		case transactionBatchAppendedTopic:
+			batchAppended = event
+		case sequencerBatchAppendedTopic:
+			// The TransactionBatchAppended event is emitted immediately
+			// before the SequencerBatchAppended event
+			if batchAppended == nil || batchAppended.TxHash != event.TxHash ||
+				batchAppended.Index+1 != event.Index {
+				return nil, fmt.Errorf("No TransactionBatchAppended event for SequencerBatchAppended in %s", event.TxHash.Hex())
+			}
+			if err := c.ingestSequencerBatch(batch, &next, batchAppended, event); err != nil {
+				return nil, err
+			}
+			batchAppended = nil
+		}
+	}
+
+	// Make sure that the logs were not fetched from a different fork than
+	// the block hash recorded in the checkpoint
+	after, err := c.backend.EthContext(ctx, new(big.Int).SetUint64(end))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot fetch block %d: %w", end, err)
+	}
+	if after.BlockHash != block.BlockHash {
+		return nil, fmt.Errorf("%w: block %d", errL1BlockChanged, end)
+	}
+	for i := range logs {
+		if logs[i].Removed || (logs[i].BlockNumber == end && logs[i].BlockHash != (common.Hash{}) && logs[i].BlockHash != block.BlockHash) {
+			return nil, fmt.Errorf("%w: block %d", errL1BlockChanged, logs[i].BlockNumber)
+		}
+	}
+
+	updated := append(*checkpoints, l1Checkpoint{Progress: next, Hash: block.BlockHash})
+	if len(updated) > maxL1Checkpoints {
+		updated = updated[len(updated)-maxL1Checkpoints:]
+	}
+	if err := writeProgress(batch, &next, updated); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, fmt.Errorf("Cannot write ingested elements: %w", err)
+	}
+	*checkpoints = updated
+	return &next, nil
+}
+
+// handleReorg checks that the last ingested layer one block is canonical. If
+// it is not, the ingestion is rolled back to the latest checkpoint whose block
+// is still canonical, and the resulting progress and checkpoints are returned.
+func (c *L1Client) handleReorg(progress *l1Progress, checkpoints []l1Checkpoint) (*l1Progress, []l1Checkpoint, error) {
+	// Databases written before checkpoints were introduced can not be
+	// checked until the next range is ingested
+	if len(checkpoints) == 0 {
+		return progress, checkpoints, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), l1RequestTimeout)
+	defer cancel()
+	latest := &checkpoints[len(checkpoints)-1]
+	block, err := c.backend.EthContext(ctx, new(big.Int).SetUint64(latest.Progress.Height))
+	if errors.Is(err, ethereum.NotFound) {
+		// The layer one node is behind, the check is retried once it has
+		// caught up with the ingested blocks
+		return progress, checkpoints, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Cannot fetch block %d: %w", latest.Progress.Height, err)
+	}
+	if block.BlockHash == latest.Hash {
+		return progress, checkpoints, nil
+	}
+	for i := len(checkpoints) - 2; i >= 0; i-- {
+		checkpoint := &checkpoints[i]
+		block, err := c.backend.EthContext(ctx, new(big.Int).SetUint64(checkpoint.Progress.Height))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, nil, fmt.Errorf("Cannot fetch block %d: %w", checkpoint.Progress.Height, err)
+		}
+		if err == nil && block.BlockHash == checkpoint.Hash {
+			log.Warn("Layer one reorg detected, rolling back ingestion", "from", progress.Height,
+				"to", checkpoint.Progress.Height, "hash", checkpoint.Hash)
+			if err := c.rollback(progress, checkpoints[:i+1]); err != nil {
+				return nil, nil, err
+			}
+			return &checkpoint.Progress, checkpoints[:i+1], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: ingested up to block %d", errL1ReorgTooDeep, progress.Height)
+}
+
+// rollback removes the elements ingested after the last of the given
+// checkpoints and restores its progress in a single database batch. Enqueues
+// that were confirmed by a removed batch are unconfirmed.
+func (c *L1Client) rollback(progress *l1Progress, checkpoints []l1Checkpoint) error {
+	target := &checkpoints[len(checkpoints)-1].Progress
+	batch := &l1IngestionBatch{
+		Batch:    c.db.NewBatch(),
+		enqueues: make(map[uint64]*l1Enqueue),
+	}
+	for index := target.NumTransactions; index < progress.NumTransactions; index++ {
+		data := rawdb.ReadL1TransactionRLP(c.db, index)
+		if len(data) == 0 {
+			continue
+		}
+		var stored l1Transaction
+		if err := rlp.DecodeBytes(data, &stored); err != nil {
+			return fmt.Errorf("Cannot decode transaction %d: %w", index, err)
+		}
+		if types.QueueOrigin(stored.QueueOrigin) == types.QueueOriginL1ToL2 && stored.QueueIndex < target.NumEnqueues {
+			enqueue, err := c.readEnqueue(batch, stored.QueueIndex)
+			if err != nil {
+				return fmt.Errorf("Cannot read enqueue %d: %w", stored.QueueIndex, err)
+			}
+			enqueue.Confirmed = false
+			enqueue.Index = 0
+			if err := writeEnqueue(batch, stored.QueueIndex, enqueue); err != nil {
+				return err
+			}
+		}
+		rawdb.DeleteL1TransactionRLP(batch, index)
+	}
+	for index := target.NumEnqueues; index < progress.NumEnqueues; index++ {
+		rawdb.DeleteL1EnqueueRLP(batch, index)
+	}
+	for index := target.NumBatches; index < progress.NumBatches; index++ {
+		rawdb.DeleteL1BatchRLP(batch, index)
+	}
+	if err := writeProgress(batch, target, checkpoints); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("Cannot roll back ingested elements: %w", err)
+	}
+	return nil
+}
+
+// l1IngestionBatch is a database batch that keeps track of the enqueues
+// written to it, so that they can be read back before the batch is written
+type l1IngestionBatch struct {
+	ethdb.Batch
+	enqueues map[uint64]*l1Enqueue
+}
+
+// ingestEnqueue ingests a TransactionEnqueued event
+func (c *L1Client) ingestEnqueue(batch *l1IngestionBatch, progress *l1Progress, event *types.Log) error {
+	if len(event.Topics) != 4 {
+		return fmt.Errorf("Unexpected TransactionEnqueued topics in %s", event.TxHash.Hex())
+	}
+	var args struct {
+		GasLimit  *big.Int
+		Data      []byte
+		Timestamp *big.Int
+	}
+	if err := ctcABI.Unpack(&args, "TransactionEnqueued", event.Data); err != nil {
+		return fmt.Errorf("Cannot unpack TransactionEnqueued: %w", err)
+	}
+	queueIndex := event.Topics[3].Big().Uint64()
+	if queueIndex != progress.NumEnqueues {
+		return fmt.Errorf("%w: expected enqueue %d, got %d", errMissingElement, progress.NumEnqueues, queueIndex)
+	}
+	enqueue := &l1Enqueue{
+		Origin:      common.BytesToAddress(event.Topics[1].Bytes()),
+		Target:      common.BytesToAddress(event.Topics[2].Bytes()),
+		GasLimit:    args.GasLimit.Uint64(),
+		Data:        args.Data,
+		BlockNumber: event.BlockNumber,
+		Timestamp:   args.Timestamp.Uint64(),
+	}
+	if err := writeEnqueue(batch, queueIndex, enqueue); err != nil {
+		return err
+	}
+	progress.NumEnqueues++
+	return nil
+}
+
+// ingestSequencerBatch ingests a TransactionBatchAppended event along with
+// the SequencerBatchAppended event that follows it, decoding the batched
+// transactions from the calldata of the layer one transaction
+func (c *L1Client) ingestSequencerBatch(batch *l1IngestionBatch, progress *l1Progress, batchAppended, sequencerBatchAppended *types.Log) error {
+	if len(batchAppended.Topics) != 2 {
+		return fmt.Errorf("Unexpected TransactionBatchAppended topics in %s", batchAppended.TxHash.Hex())
+	}
+	var batchArgs struct {
+		BatchRoot         [32]byte
+		BatchSize         *big.Int
+		PrevTotalElements *big.Int
+		ExtraData         []byte
+	}
+	if err := ctcABI.Unpack(&batchArgs, "TransactionBatchAppended", batchAppended.Data); err != nil {
+		return fmt.Errorf("Cannot unpack TransactionBatchAppended: %w", err)
+	}
+	var sequencerArgs struct {
+		StartingQueueIndex *big.Int
+		NumQueueElements   *big.Int
+		TotalElements      *big.Int
+	}
+	if err := ctcABI.Unpack(&sequencerArgs, "SequencerBatchAppended", sequencerBatchAppended.Data); err != nil {
+		return fmt.Errorf("Cannot unpack SequencerBatchAppended: %w", err)
+	}
+
+	batchIndex := batchAppended.Topics[1].Big().Uint64()
+	if batchIndex != progress.NumBatches {
+		return fmt.Errorf("%w: expected batch %d, got %d", errMissingElement, progress.NumBatches, batchIndex)
+	}
+	prevTotalElements := batchArgs.PrevTotalElements.Uint64()
+	if prevTotalElements != progress.NumTransactions {
+		return fmt.Errorf("%w: expected batch %d to start at %d, got %d", errMissingElement,
+			batchIndex, progress.NumTransactions, prevTotalElements)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l1RequestTimeout)
+	defer cancel()
+	submitter, calldata, err := c.backend.TransactionInput(ctx, batchAppended.TxHash)
+	if err != nil {
+		return fmt.Errorf("Cannot fetch batch transaction %s: %w", batchAppended.TxHash.Hex(), err)
+	}
+	block, err := c.backend.EthContext(ctx, new(big.Int).SetUint64(batchAppended.BlockNumber))
+	if err != nil {
+		return fmt.Errorf("Cannot fetch batch block %d: %w", batchAppended.BlockNumber, err)
+	}
+	decoded, err := decodeSequencerBatch(calldata)
+	if err != nil {
+		return fmt.Errorf("Cannot decode batch %d: %w", batchIndex, err)
+	}
+	if decoded.TotalElementsToAppend != batchArgs.BatchSize.Uint64() {
+		return fmt.Errorf("%w: batch %d has %d elements, expected %d", errMalformedBatch,
+			batchIndex, decoded.TotalElementsToAppend, batchArgs.BatchSize.Uint64())
+	}
+
+	var (
+		index      = prevTotalElements
+		queueIndex = sequencerArgs.StartingQueueIndex.Uint64()
+		txIndex    = 0
+	)
+	for _, context := range decoded.Contexts {
+		for i := uint64(0); i < context.NumSequencedTxs; i++ {
+			tx := &l1Transaction{
+				BatchIndex:  batchIndex,
+				BlockNumber: context.BlockNumber,
+				Timestamp:   context.Timestamp,
+				QueueOrigin: uint8(types.QueueOriginSequencer),
+				Raw:         decoded.Raw[txIndex],
+			}
+			// The block number is one greater than the CTC index
+			if index+1 >= c.cfg.TuringV1Height {
+				_, tx.Turing = decodeTuringV1(decoded.Txs[txIndex].Data())
+			}
+			if err := writeTransaction(batch, index, tx); err != nil {
+				return err
+			}
+			index++
+			txIndex++
+		}
+		for i := uint64(0); i < context.NumSubsequentQueueTxs; i++ {
+			enqueue, err := c.readEnqueue(batch, queueIndex)
+			if err != nil {
+				return fmt.Errorf("Cannot read enqueue %d: %w", queueIndex, err)
+			}
+			enqueue.Confirmed = true
+			enqueue.Index = index
+			if err := writeEnqueue(batch, queueIndex, enqueue); err != nil {
+				return err
+			}
+			tx := &l1Transaction{
+				BatchIndex:  batchIndex,
+				BlockNumber: enqueue.BlockNumber,
+				Timestamp:   enqueue.Timestamp,
+				QueueOrigin: uint8(types.QueueOriginL1ToL2),
+				QueueIndex:  queueIndex,
+			}
+			if err := writeTransaction(batch, index, tx); err != nil {
+				return err
+			}
+			index++
+			queueIndex++
+		}
+	}
+
+	data, err := rlp.EncodeToBytes(&l1Batch{
+		Root:              batchArgs.BatchRoot,
+		Size:              decoded.TotalElementsToAppend,
+		PrevTotalElements: prevTotalElements,
+		ExtraData:         batchArgs.ExtraData,
+		BlockNumber:       batchAppended.BlockNumber,
+		Timestamp:         block.Timestamp,
+		Submitter:         submitter,
+	})
+	if err != nil {
+		return err
+	}
+	rawdb.WriteL1BatchRLP(batch, batchIndex, data)
+
+	progress.NumBatches++
+	progress.NumTransactions = index
+	return nil
+}
+
+// readProgress reads the ingestion progress, which is nil if nothing has
+// been ingested yet
+func (c *L1Client) readProgress() (*l1Progress, error) {
+	data := rawdb.ReadL1IngestionProgress(c.db)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var progress l1Progress
+	if err := rlp.DecodeBytes(data, &progress); err != nil {
+		return nil, fmt.Errorf("Cannot decode L1 ingestion progress: %w", err)
+	}
+	return &progress, nil
+}
+
+// readCheckpoints reads the checkpoints of the recently ingested ranges
+func (c *L1Client) readCheckpoints() ([]l1Checkpoint, error) {
+	data := rawdb.ReadL1IngestionCheckpoints(c.db)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var checkpoints []l1Checkpoint
+	if err := rlp.DecodeBytes(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("Cannot decode L1 ingestion checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// writeProgress writes the ingestion progress and checkpoints to the batch
+func writeProgress(batch ethdb.KeyValueWriter, progress *l1Progress, checkpoints []l1Checkpoint) error {
+	data, err := rlp.EncodeToBytes(progress)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteL1IngestionProgress(batch, data)
+	data, err = rlp.EncodeToBytes(checkpoints)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteL1IngestionCheckpoints(batch, data)
+	return nil
+}
+
+// readEnqueue reads an ingested enqueue, preferring those that are pending
+// in an ingestion batch
+func (c *L1Client) readEnqueue(batch *l1IngestionBatch, index uint64) (*l1Enqueue, error) {
+	if batch != nil {
+		if enqueue, ok := batch.enqueues[index]; ok {
+			return enqueue, nil
+		}
+	}
+	data := rawdb.ReadL1EnqueueRLP(c.db, index)
+	if len(data) == 0 {
+		return nil, errElementNotFound
+	}
+	var enqueue l1Enqueue
+	if err := rlp.DecodeBytes(data, &enqueue); err != nil {
+		return nil, fmt.Errorf("Cannot decode enqueue %d: %w", index, err)
+	}
+	return &enqueue, nil
+}
+
+// readTransaction reads an ingested batched transaction and converts it into
+// a types.Transaction that can be consumed by the SyncService
+func (c *L1Client) readTransaction(index uint64) (*types.Transaction, error) {
+	data := rawdb.ReadL1TransactionRLP(c.db, index)
+	if len(data) == 0 {
+		return nil, errElementNotFound
+	}
+	var stored l1Transaction
+	if err := rlp.DecodeBytes(data, &stored); err != nil {
+		return nil, fmt.Errorf("Cannot decode transaction %d: %w", index, err)
+	}
+
+	if types.QueueOrigin(stored.QueueOrigin) == types.QueueOriginL1ToL2 {
+		enqueue, err := c.readEnqueue(nil, stored.QueueIndex)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read enqueue %d: %w", stored.QueueIndex, err)
+		}
+		data := hexutil.Bytes(enqueue.Data)
+		return batchedTransactionToTransaction(&transaction{
+			Index:       index,
+			BatchIndex:  stored.BatchIndex,
+			BlockNumber: enqueue.BlockNumber,
+			Timestamp:   enqueue.Timestamp,
+			Value:       (*hexutil.Big)(new(big.Int)),
+			GasLimit:    enqueue.GasLimit,
+			Target:      enqueue.Target,
+			Origin:      &enqueue.Origin,
+			Data:        data,
+			QueueOrigin: l1,
+			QueueIndex:  &stored.QueueIndex,
+			Turing:      hexutil.Bytes{},
+		}, c.chainID)
+	}
+
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(stored.Raw, tx); err != nil {
+		return nil, fmt.Errorf("Cannot decode batched transaction %d: %w", index, err)
+	}
+	// Restore the data that was signed by stripping the Turing header
+	if index+1 >= c.cfg.TuringV1Height {
+		data, _ := decodeTuringV1(tx.Data())
+		tx.SetData(data)
+	}
+	turing := stored.Turing
+	if turing == nil {
+		turing = []byte{}
+	}
+	txMeta := types.NewTransactionMeta(
+		new(big.Int).SetUint64(stored.BlockNumber),
+		stored.Timestamp,
+		turing,
+		nil,
+		types.QueueOriginSequencer,
+		&index,
+		nil,
+		stored.Raw,
+	)
+	tx.SetTransactionMeta(txMeta)
+	return tx, nil
+}
+
+// toTransaction converts the enqueue into a types.Transaction that can be
+// consumed by the SyncService
+func (e *l1Enqueue) toTransaction(queueIndex uint64) (*types.Transaction, error) {
+	var index *uint64
+	if e.Confirmed {
+		index = &e.Index
+	}
+	data := hexutil.Bytes(e.Data)
+	return enqueueToTransaction(&Enqueue{
+		Index:       index,
+		Target:      &e.Target,
+		Data:        &data,
+		GasLimit:    &e.GasLimit,
+		Origin:      &e.Origin,
+		BlockNumber: &e.BlockNumber,
+		Timestamp:   &e.Timestamp,
+		QueueIndex:  &queueIndex,
+	})
+}
+
+// writeEnqueue writes an ingested enqueue to the ingestion batch
+func writeEnqueue(batch *l1IngestionBatch, index uint64, enqueue *l1Enqueue) error {
+	data, err := rlp.EncodeToBytes(enqueue)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteL1EnqueueRLP(batch, index, data)
+	batch.enqueues[index] = enqueue
+	return nil
+}
+
+// writeTransaction writes an ingested batched transaction to the ingestion
+// batch
+func writeTransaction(batch *l1IngestionBatch, index uint64, tx *l1Transaction) error {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteL1TransactionRLP(batch, index, data)
+	return nil
+}
+
+// mustParseABI parses the ABI, panicking if it is invalid
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// L1RPCBackend is an L1Backend that reads from a layer one JSON-RPC endpoint.
+// Transactions and blocks are decoded partially, so that typed transactions
+// and post-London headers are supported.
+type L1RPCBackend struct {
+	client *rpc.Client
+	eth    *ethclient.Client
+}
+
+// NewL1RPCBackend creates a new L1RPCBackend from an RPC client
+func NewL1RPCBackend(client *rpc.Client) *L1RPCBackend {
+	return &L1RPCBackend{
+		client: client,
+		eth:    ethclient.NewClient(client),
+	}
+}
+
+// EthContext returns the context of the block with the given number, or of
+// the latest block if the number is nil
+func (b *L1RPCBackend) EthContext(ctx context.Context, number *big.Int) (*EthContext, error) {
+	arg := "latest"
+	if number != nil {
+		arg = hexutil.EncodeBig(number)
+	}
+	var block *struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Hash      common.Hash    `json:"hash"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := b.client.CallContext(ctx, &block, "eth_getBlockByNumber", arg, false); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, ethereum.NotFound
+	}
+	return &EthContext{
+		BlockNumber: uint64(block.Number),
+		BlockHash:   block.Hash,
+		Timestamp:   uint64(block.Timestamp),
+	}, nil
+}
+
+// FilterLogs returns the logs matching the query
+func (b *L1RPCBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return b.eth.FilterLogs(ctx, query)
+}
+
+// TransactionInput returns the sender and the calldata of a transaction
+func (b *L1RPCBackend) TransactionInput(ctx context.Context, hash common.Hash) (common.Address, []byte, error) {
+	var tx *struct {
+		From  common.Address `json:"from"`
+		Input hexutil.Bytes  `json:"input"`
+	}
+	if err := b.client.CallContext(ctx, &tx, "eth_getTransactionByHash", hash); err != nil {
+		return common.Address{}, nil, err
+	}
+	if tx == nil {
+		return common.Address{}, nil, ethereum.NotFound
+	}
+	return tx.From, tx.Input, nil
+}
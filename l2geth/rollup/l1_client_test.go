@@ -0,0 +1,545 @@
+package rollup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	ethereum "github.com/ethereum-optimism/optimism/l2geth"
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/rawdb"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/crypto"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	testCTCAddress = common.HexToAddress("0x4200000000000000000000000000000000000042")
+	testSubmitter  = common.HexToAddress("0x5000000000000000000000000000000000000005")
+)
+
+// fakeL1Backend is an in memory L1Backend
+type fakeL1Backend struct {
+	blocks []*EthContext
+	logs   []types.Log
+	txs    map[common.Hash][]byte
+	fork   uint64
+}
+
+func newFakeL1Backend() *fakeL1Backend {
+	backend := &fakeL1Backend{txs: make(map[common.Hash][]byte)}
+	backend.mine()
+	return backend
+}
+
+func (b *fakeL1Backend) EthContext(ctx context.Context, number *big.Int) (*EthContext, error) {
+	if number == nil {
+		return b.blocks[len(b.blocks)-1], nil
+	}
+	if number.Uint64() >= uint64(len(b.blocks)) {
+		return nil, ethereum.NotFound
+	}
+	return b.blocks[number.Uint64()], nil
+}
+
+func (b *fakeL1Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, log := range b.logs {
+		if log.BlockNumber < query.FromBlock.Uint64() || log.BlockNumber > query.ToBlock.Uint64() {
+			continue
+		}
+		if log.Address != query.Addresses[0] {
+			continue
+		}
+		for _, topic := range query.Topics[0] {
+			if log.Topics[0] == topic {
+				logs = append(logs, log)
+				break
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (b *fakeL1Backend) TransactionInput(ctx context.Context, hash common.Hash) (common.Address, []byte, error) {
+	input, ok := b.txs[hash]
+	if !ok {
+		return common.Address{}, nil, ethereum.NotFound
+	}
+	return testSubmitter, input, nil
+}
+
+// mine adds a block with the given logs, which are emitted by the layer one
+// transaction with the given input
+func (b *fakeL1Backend) mine(logs ...types.Log) {
+	number := uint64(len(b.blocks))
+	b.blocks = append(b.blocks, &EthContext{
+		BlockNumber: number,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(number + 1000 + b.fork*1000000)),
+		Timestamp:   number * 15,
+	})
+	for i := range logs {
+		logs[i].BlockNumber = number
+		logs[i].Index = uint(len(b.logs))
+		b.logs = append(b.logs, logs[i])
+	}
+}
+
+// reorg drops the blocks from the given number onwards, along with their
+// logs, so that the blocks mined next are on a different fork
+func (b *fakeL1Backend) reorg(number uint64) {
+	b.blocks = b.blocks[:number]
+	logs := b.logs[:0]
+	for _, log := range b.logs {
+		if log.BlockNumber < number {
+			logs = append(logs, log)
+		}
+	}
+	b.logs = logs
+	b.fork++
+}
+
+// enqueue mines a block with a TransactionEnqueued event
+func (b *fakeL1Backend) enqueue(t *testing.T, queueIndex uint64, origin, target common.Address, data []byte) {
+	event := ctcABI.Events["TransactionEnqueued"]
+	packed, err := event.Inputs.NonIndexed().Pack(big.NewInt(1000000), data, big.NewInt(int64(len(b.blocks)*15)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.mine(types.Log{
+		Address: testCTCAddress,
+		Topics: []common.Hash{
+			event.ID(),
+			common.BytesToHash(origin.Bytes()),
+			common.BytesToHash(target.Bytes()),
+			common.BigToHash(new(big.Int).SetUint64(queueIndex)),
+		},
+		Data:   packed,
+		TxHash: common.BigToHash(big.NewInt(int64(len(b.logs) + 1))),
+	})
+}
+
+// appendBatch mines a block with an `appendSequencerBatch()` transaction
+func (b *fakeL1Backend) appendBatch(t *testing.T, batchIndex, prevTotalElements, startingQueueIndex uint64, calldata []byte) {
+	decoded, err := decodeSequencerBatch(calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := new(big.Int).SetUint64(decoded.TotalElementsToAppend)
+	numQueued := decoded.TotalElementsToAppend - uint64(len(decoded.Txs))
+
+	batchAppended := ctcABI.Events["TransactionBatchAppended"]
+	batchData, err := batchAppended.Inputs.NonIndexed().Pack(
+		[32]byte{byte(batchIndex + 1)}, size, new(big.Int).SetUint64(prevTotalElements), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sequencerBatchAppended := ctcABI.Events["SequencerBatchAppended"]
+	sequencerData, err := sequencerBatchAppended.Inputs.NonIndexed().Pack(
+		new(big.Int).SetUint64(startingQueueIndex), new(big.Int).SetUint64(numQueued),
+		new(big.Int).SetUint64(prevTotalElements+decoded.TotalElementsToAppend))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txHash := crypto.Keccak256Hash(calldata)
+	b.txs[txHash] = calldata
+	b.mine(
+		types.Log{
+			Address: testCTCAddress,
+			Topics:  []common.Hash{batchAppended.ID(), common.BigToHash(new(big.Int).SetUint64(batchIndex))},
+			Data:    batchData,
+			TxHash:  txHash,
+		},
+		types.Log{
+			Address: testCTCAddress,
+			Topics:  []common.Hash{sequencerBatchAppended.ID()},
+			Data:    sequencerData,
+			TxHash:  txHash,
+		},
+	)
+}
+
+// encodeTestBatch encodes `appendSequencerBatch()` calldata with the
+// transactions encoded as signaled by the batch type
+func encodeTestBatch(t *testing.T, start uint64, contexts []sequencerBatchContext, txs []*types.Transaction, batchType int) []byte {
+	var total uint64
+	for _, context := range contexts {
+		total += context.NumSequencedTxs + context.NumSubsequentQueueTxs
+	}
+	if batchType != batchTypeLegacy {
+		contexts = append([]sequencerBatchContext{{BlockNumber: uint64(batchType)}}, contexts...)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(appendSequencerBatchSelector)
+	writeTestUint(&buf, start, 5)
+	writeTestUint(&buf, total, 3)
+	writeTestUint(&buf, uint64(len(contexts)), 3)
+	for _, context := range contexts {
+		writeTestUint(&buf, context.NumSequencedTxs, 3)
+		writeTestUint(&buf, context.NumSubsequentQueueTxs, 3)
+		writeTestUint(&buf, context.Timestamp, 5)
+		writeTestUint(&buf, context.BlockNumber, 5)
+	}
+
+	var txBuf bytes.Buffer
+	if batchType == batchTypeZstdColumnar {
+		writeTestUint(&txBuf, uint64(len(txs)), 3)
+		for j := 0; j < numTxFields; j++ {
+			for _, tx := range txs {
+				raw, err := rlp.EncodeToBytes(tx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fields, err := decodeTestFields(raw)
+				if err != nil {
+					t.Fatal(err)
+				}
+				txBuf.Write(fields[j])
+			}
+		}
+	} else {
+		for _, tx := range txs {
+			raw, err := rlp.EncodeToBytes(tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeTestUint(&txBuf, uint64(len(raw)), 3)
+			txBuf.Write(raw)
+		}
+	}
+
+	var w io.WriteCloser
+	switch batchType {
+	case batchTypeLegacy:
+		buf.Write(txBuf.Bytes())
+		return buf.Bytes()
+	case batchTypeBrotli:
+		w = brotli.NewWriterLevel(&buf, 11)
+	case batchTypeZstd, batchTypeZstdColumnar:
+		enc, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = enc
+	}
+	if _, err := w.Write(txBuf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// decodeTestFields splits the RLP encoding of a transaction into its fields
+func decodeTestFields(raw []byte) ([]rlp.RawValue, error) {
+	var fields []rlp.RawValue
+	if err := rlp.DecodeBytes(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func writeTestUint(buf *bytes.Buffer, val uint64, n int) {
+	var enc [8]byte
+	for i := 0; i < 8; i++ {
+		enc[7-i] = byte(val >> (8 * i))
+	}
+	buf.Write(enc[8-n:])
+}
+
+// newTestBatchedTx signs a sequencer transaction and returns it along with
+// the transaction as it is batched, with the Turing v1 header
+func newTestBatchedTx(t *testing.T, chainID *big.Int, nonce uint64, data, turing []byte) (*types.Transaction, *types.Transaction) {
+	key, _ := crypto.GenerateKey()
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{0x01}, big.NewInt(0), 100000, big.NewInt(1), data), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batched := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, batched); err != nil {
+		t.Fatal(err)
+	}
+	header := []byte{1, byte(len(turing) >> 8), byte(len(turing))}
+	batched.SetData(append(append(header, data...), turing...))
+	return tx, batched
+}
+
+func TestL1ClientIngestsBatches(t *testing.T) {
+	chainID := big.NewInt(288)
+	db := rawdb.NewMemoryDatabase()
+	backend := newFakeL1Backend()
+
+	origin := common.Address{0xaa}
+	target := common.Address{0xbb}
+	turing := append([]byte{0x7d, 0x93, 0x61, 0x6c}, []byte("response")...)
+	tx0, batched0 := newTestBatchedTx(t, chainID, 0, []byte{0xde, 0xad}, nil)
+	tx1, batched1 := newTestBatchedTx(t, chainID, 1, []byte{0xbe, 0xef}, turing)
+
+	// Block 1 enqueues, block 2 batches a sequencer tx and the enqueue in a
+	// legacy batch, block 3 batches a Turing tx in a brotli batch
+	backend.enqueue(t, 0, origin, target, []byte{0x01})
+	backend.appendBatch(t, 0, 0, 0, encodeTestBatch(t, 0, []sequencerBatchContext{
+		{NumSequencedTxs: 1, NumSubsequentQueueTxs: 1, Timestamp: 10, BlockNumber: 1},
+	}, []*types.Transaction{batched0}, batchTypeLegacy))
+	backend.appendBatch(t, 1, 2, 1, encodeTestBatch(t, 2, []sequencerBatchContext{
+		{NumSequencedTxs: 1, Timestamp: 20, BlockNumber: 2},
+	}, []*types.Transaction{batched1}, batchTypeBrotli))
+
+	client := NewL1Client(backend, db, chainID, L1ClientConfig{
+		CanonicalTransactionChainAddress: testCTCAddress,
+		Confirmations:                    1,
+		MaxBlockRange:                    1,
+	})
+
+	// The last block is not yet confirmed
+	index, err := client.GetLatestTransactionBatchIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *index != 0 {
+		t.Fatalf("Unexpected latest batch index: got %d, expected 0", *index)
+	}
+
+	batch, txs, err := client.GetTransactionBatch(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batch.Size != 2 || batch.Submitter != testSubmitter || batch.Timestamp != 30 {
+		t.Fatalf("Unexpected batch: %v", batch)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Unexpected number of transactions: %d", len(txs))
+	}
+	if txs[0].Hash() != tx0.Hash() {
+		t.Fatal("Sequencer transaction hash mismatch")
+	}
+	if txs[0].QueueOrigin() != types.QueueOriginSequencer || *txs[0].GetMeta().Index != 0 {
+		t.Fatal("Unexpected sequencer transaction meta")
+	}
+	if txs[0].L1Timestamp() != 10 || txs[0].L1BlockNumber().Uint64() != 1 {
+		t.Fatal("Unexpected sequencer transaction context")
+	}
+	if txs[1].QueueOrigin() != types.QueueOriginL1ToL2 || *txs[1].GetMeta().QueueIndex != 0 {
+		t.Fatal("Unexpected queue transaction meta")
+	}
+	if *txs[1].L1MessageSender() != origin || *txs[1].To() != target {
+		t.Fatal("Unexpected queue transaction")
+	}
+
+	enqueue, err := client.GetLastConfirmedEnqueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *enqueue.GetMeta().Index != 1 || !isCtcTxEqual(enqueue, txs[1]) {
+		t.Fatal("Unexpected last confirmed enqueue")
+	}
+
+	// Ingest the last block
+	backend.mine()
+	latest, err := client.GetLatestTransaction(BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Hash() != tx1.Hash() {
+		t.Fatal("Turing transaction hash mismatch")
+	}
+	if !bytes.Equal(latest.GetMeta().L1Turing, turing) {
+		t.Fatalf("Unexpected Turing payload: %x", latest.GetMeta().L1Turing)
+	}
+	if _, err := client.GetTransaction(2, BackendL2); !errors.Is(err, errUnsupportedBackend) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The index is persisted in the database
+	restarted := NewL1Client(newFakeL1Backend(), db, chainID, L1ClientConfig{
+		CanonicalTransactionChainAddress: testCTCAddress,
+	})
+	tx, err := restarted.GetTransaction(2, BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash() != tx1.Hash() {
+		t.Fatal("Persisted transaction hash mismatch")
+	}
+}
+
+func TestL1ClientMissingElement(t *testing.T) {
+	backend := newFakeL1Backend()
+	backend.enqueue(t, 0, common.Address{}, common.Address{}, nil)
+	backend.enqueue(t, 2, common.Address{}, common.Address{}, nil)
+
+	client := NewL1Client(backend, rawdb.NewMemoryDatabase(), big.NewInt(288), L1ClientConfig{
+		CanonicalTransactionChainAddress: testCTCAddress,
+	})
+	if _, err := client.GetLatestEnqueueIndex(); !errors.Is(err, errMissingElement) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestL1ClientReorg(t *testing.T) {
+	chainID := big.NewInt(288)
+	backend := newFakeL1Backend()
+	tx0, batched0 := newTestBatchedTx(t, chainID, 0, []byte{0xde, 0xad}, nil)
+	tx1, batched1 := newTestBatchedTx(t, chainID, 1, []byte{0xbe, 0xef}, nil)
+
+	// Block 1 enqueues, block 2 batches a sequencer tx and the enqueue
+	backend.enqueue(t, 0, common.Address{0xaa}, common.Address{0xbb}, []byte{0x01})
+	backend.appendBatch(t, 0, 0, 0, encodeTestBatch(t, 0, []sequencerBatchContext{
+		{NumSequencedTxs: 1, NumSubsequentQueueTxs: 1, Timestamp: 10, BlockNumber: 1},
+	}, []*types.Transaction{batched0}, batchTypeLegacy))
+
+	client := NewL1Client(backend, rawdb.NewMemoryDatabase(), chainID, L1ClientConfig{
+		CanonicalTransactionChainAddress: testCTCAddress,
+		MaxBlockRange:                    1,
+	})
+	index, err := client.GetLatestTransactionIndex(BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *index != 1 {
+		t.Fatalf("Unexpected latest transaction index: got %d, expected 1", *index)
+	}
+	if _, err := client.GetLastConfirmedEnqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace block 2 with a batch of a single sequencer tx and a new enqueue
+	backend.reorg(2)
+	backend.appendBatch(t, 0, 0, 0, encodeTestBatch(t, 0, []sequencerBatchContext{
+		{NumSequencedTxs: 1, Timestamp: 10, BlockNumber: 1},
+	}, []*types.Transaction{batched1}, batchTypeLegacy))
+	backend.enqueue(t, 1, common.Address{0xaa}, common.Address{0xbb}, []byte{0x02})
+
+	index, err = client.GetLatestTransactionIndex(BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *index != 0 {
+		t.Fatalf("Unexpected latest transaction index: got %d, expected 0", *index)
+	}
+	tx, err := client.GetTransaction(0, BackendL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash() != tx1.Hash() || tx.Hash() == tx0.Hash() {
+		t.Fatal("Transaction was not rolled back")
+	}
+	if _, err := client.GetTransaction(1, BackendL1); err == nil {
+		t.Fatal("Expected removed transaction")
+	}
+	if _, err := client.GetLastConfirmedEnqueue(); !errors.Is(err, errElementNotFound) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	enqueue, err := client.GetLatestEnqueueIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *enqueue != 1 {
+		t.Fatalf("Unexpected latest enqueue index: got %d, expected 1", *enqueue)
+	}
+
+	// A reorg replacing every checkpointed block can not be rolled back
+	backend.reorg(0)
+	backend.mine()
+	backend.mine()
+	backend.mine()
+	backend.mine()
+	if _, err := client.GetLatestEnqueueIndex(); !errors.Is(err, errL1ReorgTooDeep) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDecodeSequencerBatchTypes(t *testing.T) {
+	chainID := big.NewInt(288)
+	tx0, batched0 := newTestBatchedTx(t, chainID, 0, []byte{0xde, 0xad}, nil)
+	tx1, batched1 := newTestBatchedTx(t, chainID, 1, nil, nil)
+	contexts := []sequencerBatchContext{
+		{NumSequencedTxs: 1, NumSubsequentQueueTxs: 1, Timestamp: 10, BlockNumber: 1},
+		{NumSequencedTxs: 1, Timestamp: 20, BlockNumber: 2},
+	}
+	for _, batchType := range []int{batchTypeLegacy, batchTypeBrotli, batchTypeZstd, batchTypeZstdColumnar} {
+		calldata := encodeTestBatch(t, 5, contexts, []*types.Transaction{batched0, batched1}, batchType)
+		batch, err := decodeSequencerBatch(calldata)
+		if err != nil {
+			t.Fatalf("Batch type %d: %v", batchType, err)
+		}
+		if batch.ShouldStartAtElement != 5 || batch.TotalElementsToAppend != 3 || len(batch.Contexts) != 2 {
+			t.Fatalf("Batch type %d: unexpected batch %v", batchType, batch)
+		}
+		if len(batch.Txs) != 2 {
+			t.Fatalf("Batch type %d: unexpected number of transactions: %d", batchType, len(batch.Txs))
+		}
+		// The batched transactions carry the Turing header
+		for i, want := range []*types.Transaction{tx0, tx1} {
+			data, _ := decodeTuringV1(batch.Txs[i].Data())
+			if !bytes.Equal(data, want.Data()) || batch.Txs[i].Nonce() != want.Nonce() {
+				t.Fatalf("Batch type %d: transaction %d mismatch", batchType, i)
+			}
+		}
+	}
+
+	// A columnar batch must contain every field of the transactions it counts,
+	// here a single transaction with only a nonce follows the header, which
+	// is made of the arguments and two contexts including the marker
+	calldata := encodeTestBatch(t, 0, contexts[1:], []*types.Transaction{batched1}, batchTypeZstdColumnar)
+	headerLen := 4 + 5 + 3 + 3 + 2*16
+	buf := bytes.NewBuffer(calldata[:headerLen:headerLen])
+	enc, err := zstd.NewWriter(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := enc.Write([]byte{0, 0, 1, 0x80}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decodeSequencerBatch(buf.Bytes()); !errors.Is(err, errMalformedBatch) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Unknown batch types are rejected, the last byte of the marker context
+	// is the low byte of its block number
+	calldata = encodeTestBatch(t, 0, contexts[1:], []*types.Transaction{batched1}, batchTypeBrotli)
+	calldata[4+5+3+3+16-1] = 3
+	if _, err := decodeSequencerBatch(calldata); !errors.Is(err, errUnsupportedBatchType) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDecodeTuringV1(t *testing.T) {
+	turing := []byte{0x49, 0x3d, 0x57, 0xd6, 0x01}
+	tests := []struct {
+		name   string
+		data   []byte
+		expect []byte
+		turing []byte
+	}{
+		{"no payload", []byte{1, 0, 0, 0xaa}, []byte{0xaa}, nil},
+		{"payload", append([]byte{1, 0, 5, 0xaa}, turing...), []byte{0xaa}, turing},
+		{"unknown payload", []byte{1, 0, 2, 0xaa, 0xbb, 0xcc}, []byte{1, 0, 2, 0xaa, 0xbb, 0xcc}, nil},
+		{"payload too long", []byte{1, 0, 9, 0xaa}, []byte{1, 0, 9, 0xaa}, nil},
+		{"too short", []byte{1}, []byte{1}, nil},
+		{"other version", append([]byte{2, 0, 5, 0xaa}, turing...), append([]byte{2, 0, 5, 0xaa}, turing...), nil},
+		{"other version without payload", []byte{0, 0, 0, 0xaa}, []byte{0, 0, 0, 0xaa}, nil},
+	}
+	for _, test := range tests {
+		data, turing := decodeTuringV1(test.data)
+		if !bytes.Equal(data, test.expect) || !bytes.Equal(turing, test.turing) {
+			t.Fatalf("%s: unexpected result: %x %x", test.name, data, turing)
+		}
+	}
+}
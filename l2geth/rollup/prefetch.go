@@ -0,0 +1,176 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/metrics"
+)
+
+var fetchRetriesCounter = metrics.NewRegisteredCounter("rollup/sync/retries", nil)
+
+const (
+	// maxFetchRetries is the number of times that a request to the remote
+	// server is retried after failing with an HTTP error
+	maxFetchRetries = 5
+	// fetchRetryBackoff is the delay before the first retry, doubling with
+	// each subsequent retry
+	fetchRetryBackoff = 250 * time.Millisecond
+	// maxFetchRetryBackoff is the maximum delay between retries
+	maxFetchRetryBackoff = 8 * time.Second
+	// syncProgressInterval is the minimum interval between progress logs
+	syncProgressInterval = 8 * time.Second
+)
+
+// elementFetcher fetches the transactions of the element at an index. An
+// element is a single transaction or enqueue, or a batch of transactions.
+type elementFetcher func(index uint64) ([]*types.Transaction, error)
+
+// elementApplier applies the transactions of the element at an index
+type elementApplier func(index uint64, txs []*types.Transaction) error
+
+// fetchResult is the outcome of fetching an element
+type fetchResult struct {
+	txs []*types.Transaction
+	err error
+}
+
+// fetchWithRetry fetches the element at an index, retrying with an
+// exponential backoff when the remote server returns an HTTP error
+func fetchWithRetry(ctx context.Context, fetch elementFetcher, index uint64) ([]*types.Transaction, error) {
+	backoff := fetchRetryBackoff
+	for attempt := 0; ; attempt++ {
+		txs, err := fetch(index)
+		if err == nil || !errors.Is(err, errHTTPError) || attempt == maxFetchRetries {
+			return txs, err
+		}
+		fetchRetriesCounter.Inc(1)
+		log.Debug("Retrying fetch", "index", index, "attempt", attempt+1, "backoff", backoff, "msg", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+		if backoff > maxFetchRetryBackoff {
+			backoff = maxFetchRetryBackoff
+		}
+	}
+}
+
+// fetchRange fetches the elements from start to end (inclusive) and applies
+// them strictly in index order. Up to concurrency elements are fetched ahead
+// of the element that is being applied, so that the latency of the remote
+// server is not paid for each element. The first error stops the range.
+func fetchRange(ctx context.Context, start, end uint64, concurrency int, fetch elementFetcher, apply elementApplier) error {
+	if concurrency <= 1 {
+		for i := start; i <= end; i++ {
+			txs, err := fetchWithRetry(ctx, fetch, i)
+			if err != nil {
+				return err
+			}
+			if err := apply(i, txs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each pending element gets a result channel that is queued in index
+	// order. The capacity of the queue bounds the number of elements that
+	// are fetched but not yet applied.
+	pending := make(chan chan fetchResult, concurrency)
+	go func() {
+		defer close(pending)
+		for i := start; i <= end; i++ {
+			result := make(chan fetchResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func(index uint64) {
+				txs, err := fetchWithRetry(ctx, fetch, index)
+				result <- fetchResult{txs: txs, err: err}
+			}(i)
+		}
+	}()
+
+	index := start
+	for result := range pending {
+		res := <-result
+		if res.err != nil {
+			return res.err
+		}
+		if err := apply(index, res.txs); err != nil {
+			return err
+		}
+		index++
+	}
+	return ctx.Err()
+}
+
+// syncProgress tracks the progress of syncing a range of elements and reports
+// the rate and estimated time remaining
+type syncProgress struct {
+	name      string
+	start     uint64
+	end       uint64
+	startTime time.Time
+	lastLog   time.Time
+	applied   metrics.Counter
+	rate      metrics.Gauge
+	eta       metrics.Gauge
+	remaining metrics.Gauge
+}
+
+// newSyncProgress creates a syncProgress for the range from start to end
+// (inclusive). The metrics are registered under rollup/sync/<name>.
+func newSyncProgress(name string, start, end uint64) *syncProgress {
+	now := time.Now()
+	p := &syncProgress{
+		name:      name,
+		start:     start,
+		end:       end,
+		startTime: now,
+		lastLog:   now,
+		applied:   metrics.GetOrRegisterCounter("rollup/sync/"+name+"/applied", nil),
+		rate:      metrics.GetOrRegisterGauge("rollup/sync/"+name+"/rate", nil),
+		eta:       metrics.GetOrRegisterGauge("rollup/sync/"+name+"/eta", nil),
+		remaining: metrics.GetOrRegisterGauge("rollup/sync/"+name+"/remaining", nil),
+	}
+	p.remaining.Update(int64(end - start + 1))
+	return p
+}
+
+// update records that the element at index was applied
+func (p *syncProgress) update(index uint64) {
+	p.applied.Inc(1)
+
+	now := time.Now()
+	done := index - p.start + 1
+	remaining := p.end - index
+	elapsed := now.Sub(p.startTime).Seconds()
+	if elapsed == 0 {
+		return
+	}
+	rate := float64(done) / elapsed
+	eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+
+	p.rate.Update(int64(rate))
+	p.eta.Update(int64(eta.Seconds()))
+	p.remaining.Update(int64(remaining))
+
+	if remaining > 0 && now.Sub(p.lastLog) >= syncProgressInterval {
+		log.Info("Sync progress", "type", p.name, "index", index, "end", p.end, "rate", fmt.Sprintf("%.2f/s", rate), "eta", common.PrettyDuration(eta))
+		p.lastLog = now
+	}
+}
@@ -0,0 +1,110 @@
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+)
+
+func TestFetchRangeAppliesInOrder(t *testing.T) {
+	for _, concurrency := range []int{1, 4} {
+		fetch := func(index uint64) ([]*types.Transaction, error) {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			return []*types.Transaction{types.NewTransaction(index, common.Address{}, nil, 0, nil, nil)}, nil
+		}
+		var applied []uint64
+		apply := func(index uint64, txs []*types.Transaction) error {
+			if txs[0].Nonce() != index {
+				return fmt.Errorf("Transaction %d applied at index %d", txs[0].Nonce(), index)
+			}
+			applied = append(applied, index)
+			return nil
+		}
+		if err := fetchRange(context.Background(), 3, 42, concurrency, fetch, apply); err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != 40 {
+			t.Fatalf("Unexpected number of elements applied: %d", len(applied))
+		}
+		for i, index := range applied {
+			if index != uint64(i+3) {
+				t.Fatalf("Element %d applied out of order", index)
+			}
+		}
+	}
+}
+
+func TestFetchRangeStopsOnError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	var fetched int64
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		atomic.AddInt64(&fetched, 1)
+		if index == 5 {
+			return nil, errFetch
+		}
+		return nil, nil
+	}
+	var applied uint64
+	apply := func(index uint64, txs []*types.Transaction) error {
+		applied++
+		return nil
+	}
+	err := fetchRange(context.Background(), 0, 1000, 4, fetch, apply)
+	if !errors.Is(err, errFetch) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if applied != 5 {
+		t.Fatalf("Unexpected number of elements applied: %d", applied)
+	}
+	// Fetching ahead is bounded by the concurrency
+	if n := atomic.LoadInt64(&fetched); n > 5+4+1 {
+		t.Fatalf("Too many elements fetched: %d", n)
+	}
+}
+
+func TestFetchWithRetry(t *testing.T) {
+	var calls int
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("503 cannot GET: %w", errHTTPError)
+		}
+		return nil, nil
+	}
+	if _, err := fetchWithRetry(context.Background(), fetch, 0); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("Unexpected number of calls: %d", calls)
+	}
+
+	// Other errors are not retried
+	calls = 0
+	fetch = func(index uint64) ([]*types.Transaction, error) {
+		calls++
+		return nil, errElementNotFound
+	}
+	if _, err := fetchWithRetry(context.Background(), fetch, 0); !errors.Is(err, errElementNotFound) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Unexpected number of calls: %d", calls)
+	}
+
+	// Retries stop when the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fetch = func(index uint64) ([]*types.Transaction, error) {
+		return nil, errHTTPError
+	}
+	if _, err := fetchWithRetry(ctx, fetch, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
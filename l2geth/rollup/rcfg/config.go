@@ -7,16 +7,35 @@ import (
 	"github.com/ethereum-optimism/optimism/l2geth/common"
 )
 
-var (
+// Config holds the settings that enable the OVM and locate the Boba
+// predeploys. It is carried on the chain config so that every component
+// executing transactions agrees on it.
+type Config struct {
 	// UsingOVM is used to enable or disable functionality necessary for the OVM.
-	UsingOVM bool
-	// OvmTuringCreditAddress is turing credit contract address
-	OvmTuringCreditAddress common.Address
-	// OvmBobaGasPricOracle is boba gas price oracle
-	OvmBobaGasPricOracle common.Address
-	// OvmL2BobaToken is boba token address
-	OvmL2BobaToken common.Address
-)
+	UsingOVM bool `json:"usingOVM"`
+	// TuringCreditAddress is the address of the Turing credit contract
+	TuringCreditAddress common.Address `json:"turingCreditAddress"`
+	// BobaGasPriceOracleAddress is the address of the Boba gas price oracle
+	BobaGasPriceOracleAddress common.Address `json:"bobaGasPriceOracleAddress"`
+	// L2BobaTokenAddress is the address of the L2 Boba token
+	L2BobaTokenAddress common.Address `json:"l2BobaTokenAddress"`
+}
+
+// Default is the Config used when none is configured, read from the
+// environment for backwards compatibility.
+var Default = FromEnv()
+
+// FromEnv reads a Config from the USING_OVM, TURING_CREDIT_ADDRESS,
+// BOBA_GAS_PRICE_ORACLE_ADDRESS and L2_BOBA_TOKEN_ADDRESS environment
+// variables.
+func FromEnv() *Config {
+	return &Config{
+		UsingOVM:                  os.Getenv("USING_OVM") == "true",
+		TuringCreditAddress:       common.HexToAddress(os.Getenv("TURING_CREDIT_ADDRESS")),
+		BobaGasPriceOracleAddress: common.HexToAddress(os.Getenv("BOBA_GAS_PRICE_ORACLE_ADDRESS")),
+		L2BobaTokenAddress:        common.HexToAddress(os.Getenv("L2_BOBA_TOKEN_ADDRESS")),
+	}
+}
 
 var (
 	// l2GasPriceSlot refers to the storage slot that the L2 gas price is stored
@@ -42,12 +61,4 @@ var (
 	// DecimalsSlot refers to the storage slot in the OVM_GasPriceOracle that
 	// holds the number of decimals in the fee scalar
 	DecimalsSlot = common.BigToHash(big.NewInt(5))
-	// Address of the Turing credit contract
 )
-
-func init() {
-	UsingOVM = os.Getenv("USING_OVM") == "true"
-	OvmTuringCreditAddress = common.HexToAddress(os.Getenv("TURING_CREDIT_ADDRESS"))
-	OvmBobaGasPricOracle = common.HexToAddress(os.Getenv("BOBA_GAS_PRICE_ORACLE_ADDRESS"))
-	OvmL2BobaToken = common.HexToAddress(os.Getenv("L2_BOBA_TOKEN_ADDRESS"))
-}
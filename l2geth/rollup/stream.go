@@ -0,0 +1,136 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common"
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/log"
+	"github.com/ethereum-optimism/optimism/l2geth/metrics"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+var (
+	streamedTxsCounter      = metrics.NewRegisteredCounter("rollup/stream/transactions", nil)
+	streamGapsCounter       = metrics.NewRegisteredCounter("rollup/stream/gaps", nil)
+	streamReconnectsCounter = metrics.NewRegisteredCounter("rollup/stream/reconnects", nil)
+	streamConnectedGauge    = metrics.NewRegisteredGauge("rollup/stream/connected", nil)
+)
+
+// streamReconnectInterval is the delay before reconnecting to the sequencer
+// after the subscription fails
+const streamReconnectInterval = 5 * time.Second
+
+// streamedTransaction represents a transaction that was applied by the
+// sequencer, as sent by the `rollup_subscribe("transactions")` subscription
+type streamedTransaction struct {
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	Index       *hexutil.Uint64 `json:"index"`
+	Transaction hexutil.Bytes   `json:"transaction"`
+	Meta        hexutil.Bytes   `json:"meta"`
+}
+
+// toTransaction turns a streamedTransaction into a types.Transaction with
+// its TransactionMeta set
+func (s *streamedTransaction) toTransaction() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(s.Transaction, tx); err != nil {
+		return nil, fmt.Errorf("Cannot decode streamed transaction: %w", err)
+	}
+	meta, err := types.TxMetaDecode(s.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode streamed transaction meta: %w", err)
+	}
+	if meta.Index == nil {
+		return nil, errors.New("Streamed transaction has no index")
+	}
+	tx.SetTransactionMeta(meta)
+	return tx, nil
+}
+
+// SequencerStreamLoop subscribes to the transactions applied by the sequencer
+// and applies them as they arrive, reconnecting when the subscription fails.
+// Polling continues to run alongside the subscription to fill any gaps.
+func (s *SyncService) SequencerStreamLoop() {
+	log.Info("Starting Sequencer Stream Loop", "url", s.sequencerClientWs)
+	for {
+		client, err := rpc.DialContext(s.ctx, s.sequencerClientWs)
+		if err == nil {
+			err = s.streamTransactions(client)
+			client.Close()
+		}
+		streamConnectedGauge.Update(0)
+		select {
+		case <-s.ctx.Done():
+			return
+		default:
+		}
+		log.Warn("Sequencer stream disconnected", "msg", err)
+		streamReconnectsCounter.Inc(1)
+		select {
+		case <-time.After(streamReconnectInterval):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// streamTransactions subscribes to the transactions applied by the sequencer
+// and applies them until the subscription fails or the SyncService is
+// stopped
+func (s *SyncService) streamTransactions(client *rpc.Client) error {
+	ch := make(chan *streamedTransaction, 128)
+	sub, err := client.Subscribe(s.ctx, "rollup", ch, "transactions")
+	if err != nil {
+		return fmt.Errorf("Cannot subscribe to sequencer: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	log.Info("Subscribed to sequencer transactions")
+	streamConnectedGauge.Update(1)
+	for {
+		select {
+		case streamed := <-ch:
+			// Leave the chain untouched for inspection once halted
+			if s.isHaltedOnDivergence() {
+				continue
+			}
+			tx, err := streamed.toTransaction()
+			if err != nil {
+				log.Error("Cannot decode streamed transaction", "block", uint64(streamed.BlockNumber), "msg", err)
+				continue
+			}
+			streamedTxsCounter.Inc(1)
+			if err := s.applyStreamedTransaction(tx); err != nil {
+				log.Error("Cannot apply streamed transaction", "index", *tx.GetMeta().Index, "msg", err)
+			}
+		case err := <-sub.Err():
+			return err
+		case <-s.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// applyStreamedTransaction applies a transaction streamed from the sequencer.
+// When the transaction is ahead of the local tip, the missing transactions
+// are polled for first.
+func (s *SyncService) applyStreamedTransaction(tx *types.Transaction) error {
+	index := *tx.GetMeta().Index
+	if next := s.GetNextIndex(); index > next {
+		log.Info("Filling gap in streamed transactions", "next", next, "index", index)
+		streamGapsCounter.Inc(1)
+		if err := s.syncTransactionsToTip(); err != nil {
+			return fmt.Errorf("Cannot fill gap in streamed transactions: %w", err)
+		}
+	}
+
+	s.loopLock.Lock()
+	defer s.loopLock.Unlock()
+	return s.applyIndexedTransaction(tx, false)
+}
@@ -0,0 +1,112 @@
+package rollup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/l2geth/common/hexutil"
+	"github.com/ethereum-optimism/optimism/l2geth/core"
+	"github.com/ethereum-optimism/optimism/l2geth/core/types"
+	"github.com/ethereum-optimism/optimism/l2geth/rlp"
+	"github.com/ethereum-optimism/optimism/l2geth/rpc"
+)
+
+// fakeSequencerAPI serves the `rollup_subscribe("transactions")`
+// subscription, notifying each of its transactions once subscribed
+type fakeSequencerAPI struct {
+	txs []*streamedTransaction
+}
+
+func (api *fakeSequencerAPI) Transactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		for _, tx := range api.txs {
+			notifier.Notify(rpcSub.ID, tx)
+		}
+	}()
+	return rpcSub, nil
+}
+
+func newStreamedTransaction(t *testing.T, tx *types.Transaction) *streamedTransaction {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := hexutil.Uint64(*tx.GetMeta().Index)
+	return &streamedTransaction{
+		BlockNumber: index + 1,
+		Index:       &index,
+		Transaction: raw,
+		Meta:        types.TxMetaEncode(tx.GetMeta()),
+	}
+}
+
+func TestSyncServiceStreamTransactions(t *testing.T) {
+	service, txCh, sub, err := newTestSyncService(true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	tx0 := setMockTxIndex(mockTx(), 0)
+	tx1 := setMockQueueIndex(setMockQueueOrigin(setMockTxIndex(mockTx(), 1), types.QueueOriginL1ToL2), 0)
+	// A zero timestamp would be replaced with the local timestamp
+	for _, tx := range []*types.Transaction{tx0, tx1} {
+		tx.GetMeta().L1Timestamp = 1
+	}
+	api := &fakeSequencerAPI{
+		txs: []*streamedTransaction{
+			newStreamedTransaction(t, tx0),
+			newStreamedTransaction(t, tx1),
+			// Transactions that were already applied are compared against
+			// the local chain and not applied again
+			newStreamedTransaction(t, tx0),
+		},
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("rollup", api); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- service.streamTransactions(client)
+	}()
+
+	for _, expected := range []*types.Transaction{tx0, tx1} {
+		ev := <-txCh
+		service.chainHeadCh <- core.ChainHeadEvent{}
+		if len(ev.Txs) != 1 {
+			t.Fatal("Unexpected number of transactions")
+		}
+		tx := ev.Txs[0]
+		if tx.Hash() != expected.Hash() {
+			t.Fatal("Unexpected transaction applied")
+		}
+		meta, expectedMeta := tx.GetMeta(), expected.GetMeta()
+		if !bytes.Equal(types.TxMetaEncode(meta), types.TxMetaEncode(expectedMeta)) {
+			t.Fatalf("Unexpected transaction meta at index %d", *meta.Index)
+		}
+	}
+	if index := service.GetLatestIndex(); index == nil || *index != 1 {
+		t.Fatal("Latest index not updated")
+	}
+
+	service.cancel()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-txCh:
+		t.Fatal("Transaction applied twice")
+	default:
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -35,7 +36,11 @@ var (
 	// errZeroGasPriceTx is the error for when a user submits a transaction
 	// with gas price zero and fees are currently enforced
 	errZeroGasPriceTx = errors.New("cannot accept 0 gas price transaction")
-	float1            = big.NewFloat(1)
+	// errReorgTooDeep is the error for when a batched transaction does not
+	// match the local chain, and rolling back to it would remove more blocks
+	// than the configured maximum reorg depth
+	errReorgTooDeep = errors.New("reorg exceeds max reorg depth")
+	float1          = big.NewFloat(1)
 )
 
 // SyncService implements the main functionality around pulling in transactions
@@ -68,6 +73,18 @@ type SyncService struct {
 	signer                         types.Signer
 	feeThresholdUp                 *big.Float
 	feeThresholdDown               *big.Float
+	maxReorgDepth                  uint64
+	reorgDryRun                    bool
+	divergence                     *divergenceDetector
+	syncConcurrency                int
+	sequencerClientWs              string
+	lastApplied                    atomic.Value
+	remoteIndex                    atomic.Value
+	remoteQueueIndex               atomic.Value
+	healthThresholds               healthThresholds
+	healthAddr                     string
+	healthServer                   *http.Server
+	admission                      *admissionController
 }
 
 // NewSyncService returns an initialized sync service
@@ -86,6 +103,22 @@ func NewSyncService(ctx context.Context, cfg Config, txpool *core.TxPool, bc *co
 		log.Info("Fees", "threshold-up", cfg.FeeThresholdUp, "threshold-down", cfg.FeeThresholdDown)
 		log.Info("Enforce Fees", "set", cfg.EnforceFees)
 	}
+	if cfg.MaxReorgDepth > 0 {
+		log.Info("Reorgs enabled", "max-depth", cfg.MaxReorgDepth, "dry-run", cfg.ReorgDryRun)
+	}
+	if cfg.SequencerClientWs != "" {
+		if !cfg.IsVerifier || cfg.Backend != BackendL2 {
+			return nil, fmt.Errorf("%w: streaming from the sequencer requires a verifier with the l2 backend", errBadConfig)
+		}
+		log.Info("Streaming transactions from the sequencer", "url", cfg.SequencerClientWs)
+	}
+	if cfg.SenderTxRate > 0 || cfg.IPTxRate > 0 || cfg.IngressQueueSize > 0 || cfg.MaxNonceGap > 0 {
+		log.Info("Admission control enabled", "sender-rate", cfg.SenderTxRate, "sender-burst", cfg.SenderTxBurst,
+			"ip-rate", cfg.IPTxRate, "ip-burst", cfg.IPTxBurst, "queue-size", cfg.IngressQueueSize, "max-nonce-gap", cfg.MaxNonceGap)
+	}
+	if cfg.SyncConcurrency > 1 {
+		log.Info("Fetching ahead while syncing", "concurrency", cfg.SyncConcurrency)
+	}
 
 	pollInterval := cfg.PollInterval
 	if pollInterval == 0 {
@@ -104,8 +137,31 @@ func NewSyncService(ctx context.Context, cfg Config, txpool *core.TxPool, bc *co
 		return nil, errors.New("Must configure with chain id")
 	}
 	// Initialize the rollup client
-	client := NewClient(cfg.RollupClientHttp, chainID)
-	log.Info("Configured rollup client", "url", cfg.RollupClientHttp, "chain-id", chainID.Uint64(), "ctc-deploy-height", cfg.CanonicalTransactionChainDeployHeight)
+	var client RollupClient
+	switch cfg.ClientType {
+	case ClientTypeL1:
+		if cfg.L1CanonicalTransactionChainAddress == (common.Address{}) {
+			return nil, fmt.Errorf("%w: canonical transaction chain address not set", errBadConfig)
+		}
+		var ctcDeployHeight uint64
+		if cfg.CanonicalTransactionChainDeployHeight != nil {
+			ctcDeployHeight = cfg.CanonicalTransactionChainDeployHeight.Uint64()
+		}
+		l1Client, err := DialL1Client(cfg.L1RpcHttp, db, chainID, L1ClientConfig{
+			CanonicalTransactionChainAddress:      cfg.L1CanonicalTransactionChainAddress,
+			CanonicalTransactionChainDeployHeight: ctcDeployHeight,
+			Confirmations:                         cfg.L1Confirmations,
+			TuringV1Height:                        cfg.TuringV1Height,
+		})
+		if err != nil {
+			return nil, err
+		}
+		client = l1Client
+		log.Info("Configured L1 rollup client", "url", cfg.L1RpcHttp, "chain-id", chainID.Uint64(), "ctc", cfg.L1CanonicalTransactionChainAddress.Hex(), "ctc-deploy-height", ctcDeployHeight, "confirmations", cfg.L1Confirmations)
+	default:
+		client = NewClient(cfg.RollupClientHttp, chainID)
+		log.Info("Configured rollup client", "url", cfg.RollupClientHttp, "chain-id", chainID.Uint64(), "ctc-deploy-height", cfg.CanonicalTransactionChainDeployHeight)
+	}
 
 	// Ensure sane values for the fee thresholds
 	if cfg.FeeThresholdDown != nil {
@@ -143,6 +199,38 @@ func NewSyncService(ctx context.Context, cfg Config, txpool *core.TxPool, bc *co
 		signer:                         types.NewEIP155Signer(chainID),
 		feeThresholdDown:               cfg.FeeThresholdDown,
 		feeThresholdUp:                 cfg.FeeThresholdUp,
+		maxReorgDepth:                  cfg.MaxReorgDepth,
+		reorgDryRun:                    cfg.ReorgDryRun,
+		syncConcurrency:                cfg.SyncConcurrency,
+		sequencerClientWs:              cfg.SequencerClientWs,
+		healthThresholds: healthThresholds{
+			MaxIndexLag:     cfg.HealthMaxIndexLag,
+			MaxQueueLag:     cfg.HealthMaxQueueLag,
+			MaxL1ContextAge: cfg.HealthMaxL1ContextAge,
+			MaxTxAge:        cfg.HealthMaxTxAge,
+		},
+		healthAddr: cfg.HealthAddr,
+		admission: newAdmissionController(admissionConfig{
+			SenderTxRate:     cfg.SenderTxRate,
+			SenderTxBurst:    cfg.SenderTxBurst,
+			IPTxRate:         cfg.IPTxRate,
+			IPTxBurst:        cfg.IPTxBurst,
+			IngressQueueSize: cfg.IngressQueueSize,
+			MaxNonceGap:      cfg.MaxNonceGap,
+		}),
+	}
+	service.setLastApplied(time.Now())
+
+	if cfg.StateDivergenceCheck {
+		stateRootClient, ok := client.(StateRootClient)
+		if !ok {
+			return nil, fmt.Errorf("%w: rollup client %s cannot query state roots", errBadConfig, cfg.ClientType)
+		}
+		if !cfg.IsVerifier {
+			log.Warn("State divergence checks only run in verifier mode")
+		}
+		service.divergence = newDivergenceDetector(stateRootClient, cfg.HaltOnStateDivergence)
+		log.Info("State divergence checks enabled", "halt", cfg.HaltOnStateDivergence)
 	}
 
 	// The chainHeadSub is used to synchronize the SyncService with the chain.
@@ -238,12 +326,20 @@ func (s *SyncService) Start() error {
 		return nil
 	}
 	log.Info("Initializing Sync Service")
+	if s.healthAddr != "" {
+		if err := s.startHealthServer(); err != nil {
+			return err
+		}
+	}
 	if err := s.updateGasPriceOracleCache(nil); err != nil {
 		return err
 	}
 
 	if s.verifier {
 		go s.VerifierLoop()
+		if s.sequencerClientWs != "" {
+			go s.SequencerStreamLoop()
+		}
 	} else {
 		// The sequencer must sync the transactions to the tip and the
 		// pending queue transactions on start before setting sync status
@@ -389,6 +485,11 @@ func (s *SyncService) Stop() error {
 	s.chainHeadSub.Unsubscribe()
 	close(s.chainHeadCh)
 
+	if s.healthServer != nil {
+		if err := s.healthServer.Close(); err != nil {
+			log.Error("Cannot close health server", "msg", err)
+		}
+	}
 	if s.cancel != nil {
 		defer s.cancel()
 	}
@@ -401,9 +502,18 @@ func (s *SyncService) VerifierLoop() {
 	t := time.NewTicker(s.pollInterval)
 	defer t.Stop()
 	for ; true; <-t.C {
+		// Leave the chain untouched for inspection once halted
+		if s.isHaltedOnDivergence() {
+			continue
+		}
 		if err := s.verify(); err != nil {
 			log.Error("Could not verify", "error", err)
 		}
+		if s.divergence != nil {
+			if err := s.checkStateRoots(); err != nil {
+				log.Error("Could not check state roots", "error", err)
+			}
+		}
 	}
 }
 
@@ -415,6 +525,11 @@ func (s *SyncService) verify() error {
 		if err := s.syncBatchesToTip(); err != nil {
 			return fmt.Errorf("Verifier cannot sync transaction batches to tip: %w", err)
 		}
+		// Batches are synced without polling the remote transaction index,
+		// so record it for the health endpoint
+		if _, err := s.getRemoteIndex(s.backend); err != nil {
+			log.Debug("Cannot get remote index", "msg", err)
+		}
 	case BackendL2:
 		if err := s.syncTransactionsToTip(); err != nil {
 			return fmt.Errorf("Verifier cannot sync transactions with BackendL2: %w", err)
@@ -445,19 +560,23 @@ func (s *SyncService) SequencerLoop() {
 // sequence is the main logic for the Sequencer. It will sync any `enqueue`
 // transactions it has yet to sync and then pull in transaction batches to
 // compare against the transactions it has in its local state. The sequencer
-// should reorg based on the transaction batches that are posted because
-// L1 is the source of truth. The sequencer concurrently accepts user
-// transactions via the RPC. When reorg logic is enabled, this should
-// also call `syncBatchesToTip`
+// reorgs based on the transaction batches that are posted because L1 is the
+// source of truth. The sequencer concurrently accepts user transactions via
+// the RPC. Transaction batches are only synced when reorgs are enabled.
 func (s *SyncService) sequence() error {
 	if err := s.syncQueueToTip(); err != nil {
 		return fmt.Errorf("Sequencer cannot sequence queue: %w", err)
 	}
+	if s.maxReorgDepth > 0 {
+		if err := s.syncBatchesToTip(); err != nil {
+			return fmt.Errorf("Sequencer cannot sync transaction batches: %w", err)
+		}
+	}
 	return nil
 }
 
 func (s *SyncService) syncQueueToTip() error {
-	if err := s.syncToTip(s.syncQueue, s.client.GetLatestEnqueueIndex); err != nil {
+	if err := s.syncToTip(s.syncQueue, s.getRemoteQueueIndex); err != nil {
 		return fmt.Errorf("Cannot sync queue to tip: %w", err)
 	}
 	return nil
@@ -475,7 +594,7 @@ func (s *SyncService) syncTransactionsToTip() error {
 		return s.syncTransactions(s.backend)
 	}
 	check := func() (*uint64, error) {
-		return s.client.GetLatestTransactionIndex(s.backend)
+		return s.getRemoteIndex(s.backend)
 	}
 	if err := s.syncToTip(sync, check); err != nil {
 		return fmt.Errorf("Verifier cannot sync transactions with backend %s: %w", s.backend.String(), err)
@@ -724,15 +843,16 @@ func (s *SyncService) SetLatestBatchIndex(index *uint64) {
 // applyTransaction is a higher level API for applying a transaction
 func (s *SyncService) applyTransaction(tx *types.Transaction) error {
 	if tx.GetMeta().Index != nil {
-		return s.applyIndexedTransaction(tx)
+		return s.applyIndexedTransaction(tx, false)
 	}
 	return s.applyTransactionToTip(tx)
 }
 
 // applyIndexedTransaction applys a transaction that has an index. This means
 // that the source of the transaction was either a L1 batch or from the
-// sequencer.
-func (s *SyncService) applyIndexedTransaction(tx *types.Transaction) error {
+// sequencer. If fromL1 is true, the transaction was read from a L1 batch and
+// is canonical.
+func (s *SyncService) applyIndexedTransaction(tx *types.Transaction, fromL1 bool) error {
 	if tx == nil {
 		return errors.New("Transaction is nil in applyIndexedTransaction")
 	}
@@ -743,17 +863,18 @@ func (s *SyncService) applyIndexedTransaction(tx *types.Transaction) error {
 	log.Trace("Applying indexed transaction", "index", *index)
 	next := s.GetNextIndex()
 	if *index == next {
-		return s.applyTransactionToTip(tx)
+		return s.applyTransactionToTipWithSource(tx, fromL1)
 	}
 	if *index < next {
-		return s.applyHistoricalTransaction(tx)
+		return s.applyHistoricalTransaction(tx, fromL1)
 	}
 	return fmt.Errorf("Received tx at index %d when looking for %d", *index, next)
 }
 
 // applyHistoricalTransaction will compare a historical transaction against what
-// is locally indexed. This will trigger a reorg in the future
-func (s *SyncService) applyHistoricalTransaction(tx *types.Transaction) error {
+// is locally indexed. If the transaction was read from a L1 batch and does not
+// match, the chain is reorged to the transaction when reorgs are enabled.
+func (s *SyncService) applyHistoricalTransaction(tx *types.Transaction, fromL1 bool) error {
 	if tx == nil {
 		return errors.New("Transaction is nil in applyHistoricalTransaction")
 	}
@@ -772,6 +893,9 @@ func (s *SyncService) applyHistoricalTransaction(tx *types.Transaction) error {
 	}
 	if !isCtcTxEqual(tx, txs[0]) {
 		log.Error("Mismatched transaction", "index", *index)
+		if fromL1 && s.maxReorgDepth > 0 {
+			return s.reorgToTransaction(tx)
+		}
 	} else {
 		log.Debug("Historical transaction matches", "index", *index, "hash", tx.Hash().Hex())
 	}
@@ -783,6 +907,13 @@ func (s *SyncService) applyHistoricalTransaction(tx *types.Transaction) error {
 // the chain. It is assumed that validation around the index has already
 // happened.
 func (s *SyncService) applyTransactionToTip(tx *types.Transaction) error {
+	return s.applyTransactionToTipWithSource(tx, false)
+}
+
+// applyTransactionToTipWithSource applies the transaction to the tip. If
+// fromL1 is true, the transaction was read from a L1 batch and its timestamp
+// is never malleated, so that the chain matches the canonical L1 data.
+func (s *SyncService) applyTransactionToTipWithSource(tx *types.Transaction, fromL1 bool) error {
 	if tx == nil {
 		return errors.New("nil transaction passed to applyTransactionToTip")
 	}
@@ -813,7 +944,7 @@ func (s *SyncService) applyTransactionToTip(tx *types.Transaction) error {
 	// network split.
 	// Note that it should never be possible for the timestamp to be set to
 	// 0 when running as a verifier.
-	shouldMalleateTimestamp := !s.verifier && tx.QueueOrigin() == types.QueueOriginL1ToL2
+	shouldMalleateTimestamp := !s.verifier && !fromL1 && tx.QueueOrigin() == types.QueueOriginL1ToL2
 	if tx.L1Timestamp() == 0 || shouldMalleateTimestamp {
 		// Get the latest known timestamp
 		current := time.Unix(int64(ts), 0)
@@ -902,6 +1033,7 @@ func (s *SyncService) applyTransactionToTip(tx *types.Transaction) error {
 				return err
 			}
 		}
+		s.setLastApplied(time.Now())
 		return nil
 	}
 }
@@ -919,7 +1051,7 @@ func (s *SyncService) applyBatchedTransaction(tx *types.Transaction) error {
 		return errors.New("No index found on transaction")
 	}
 	log.Trace("Applying batched transaction", "index", *index)
-	err := s.applyIndexedTransaction(tx)
+	err := s.applyIndexedTransaction(tx, true)
 	if err != nil {
 		return fmt.Errorf("Cannot apply batched transaction: %w", err)
 	}
@@ -927,6 +1059,83 @@ func (s *SyncService) applyBatchedTransaction(tx *types.Transaction) error {
 	return nil
 }
 
+// reorgToTransaction rolls the chain back to the block before the one holding
+// the index of the canonical transaction, resets the indices and L1 context to
+// match the new head and then applies the canonical transaction to the tip.
+// Subsequent transactions are then applied to the tip as they are synced from
+// L1. Reorgs deeper than the max reorg depth are refused, halting the sync
+// until an operator intervenes. In dry run mode, the reorg is only logged.
+func (s *SyncService) reorgToTransaction(tx *types.Transaction) error {
+	index := *tx.GetMeta().Index
+	// Handle the off by one, the block holding the index is index+1
+	head := s.bc.CurrentBlock().Number().Uint64()
+	depth := head - index
+	if depth > s.maxReorgDepth {
+		return fmt.Errorf("Cannot reorg to index %d with depth %d: %w", index, depth, errReorgTooDeep)
+	}
+	if s.reorgDryRun {
+		log.Warn("Skipping reorg in dry run mode", "index", index, "head", head, "depth", depth)
+		return nil
+	}
+
+	log.Warn("Reorging to mismatched transaction", "index", index, "head", head, "depth", depth)
+	if err := s.bc.SetHead(index); err != nil {
+		return fmt.Errorf("Cannot set head to %d: %w", index, err)
+	}
+	if err := s.resetToHead(); err != nil {
+		return fmt.Errorf("Cannot reset to head %d: %w", index, err)
+	}
+	return s.applyTransactionToTipWithSource(tx, true)
+}
+
+// resetToHead resets the indices and the L1 context to match the current head
+// of the chain, after it has been rolled back.
+func (s *SyncService) resetToHead() error {
+	block := s.bc.CurrentBlock()
+	num := block.Number().Uint64()
+
+	// The genesis block holds no transaction
+	if num == 0 {
+		rawdb.DeleteHeadIndex(s.db)
+		rawdb.DeleteHeadQueueIndex(s.db)
+		rawdb.DeleteHeadVerifiedIndex(s.db)
+		s.SetLatestL1Timestamp(0)
+		s.SetLatestL1BlockNumber(0)
+		return nil
+	}
+
+	txs := block.Transactions()
+	if len(txs) != 1 {
+		return fmt.Errorf("Unexpected number of transactions in block %d: %d", num, len(txs))
+	}
+	tx := txs[0]
+	index := num - 1
+	s.SetLatestIndex(&index)
+	if verified := s.GetLatestVerifiedIndex(); verified != nil && *verified > index {
+		s.SetLatestVerifiedIndex(&index)
+	}
+	s.SetLatestL1Timestamp(tx.L1Timestamp())
+	s.SetLatestL1BlockNumber(tx.L1BlockNumber().Uint64())
+
+	// Work backwards from the head to find the latest queue index
+	for ; num > 0; num-- {
+		block := s.bc.GetBlockByNumber(num)
+		if block == nil {
+			return fmt.Errorf("Block %d is not found", num)
+		}
+		txs := block.Transactions()
+		if len(txs) != 1 {
+			return fmt.Errorf("Unexpected number of transactions in block %d: %d", num, len(txs))
+		}
+		if queueIndex := txs[0].GetMeta().QueueIndex; queueIndex != nil {
+			s.SetLatestEnqueueIndex(queueIndex)
+			return nil
+		}
+	}
+	rawdb.DeleteHeadQueueIndex(s.db)
+	return nil
+}
+
 // verifyFee will verify that a valid fee is being paid.
 func (s *SyncService) verifyFee(tx *types.Transaction) error {
 	fee, err := fees.CalculateTotalFee(tx, s.RollupGpo)
@@ -1030,6 +1239,23 @@ func (s *SyncService) verifyFee(tx *types.Transaction) error {
 	return nil
 }
 
+// verifyNonceGap rejects transactions whose nonce is too far ahead of the
+// nonce of the sender when admission control is enabled
+func (s *SyncService) verifyNonceGap(tx *types.Transaction) error {
+	if s.admission == nil || s.admission.cfg.MaxNonceGap == 0 {
+		return nil
+	}
+	state, err := s.bc.State()
+	if err != nil {
+		return err
+	}
+	from, err := types.Sender(s.signer, tx)
+	if err != nil {
+		return fmt.Errorf("invalid transaction: %w", core.ErrInvalidSender)
+	}
+	return s.admission.checkNonceGap(tx.Nonce(), state.GetNonce(from))
+}
+
 // Validate that gas limit approved by the user is larger than the actual usage
 func (s *SyncService) validateGasLimit(tx *types.Transaction, l2GasPrice *big.Int, gpo *gasprice.RollupOracle, nextBlockNumber *big.Int) (*big.Int, error) {
 	intrGas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, true, s.bc.Config().IsIstanbul(nextBlockNumber))
@@ -1051,17 +1277,39 @@ func (s *SyncService) validateGasLimit(tx *types.Transaction, l2GasPrice *big.In
 
 // Higher level API for applying transactions. Should only be called for
 // queue origin sequencer transactions, as the contracts on L1 manage the same
-// validity checks that are done here.
-func (s *SyncService) ValidateAndApplySequencerTransaction(tx *types.Transaction) error {
+// validity checks that are done here. The context of the RPC request is used
+// to rate limit by remote address when admission control is enabled, and
+// cancels the wait in the ingress queue. The fee is verified before the rate
+// limits are applied, so that rejected transactions do not use up the quota
+// of their sender.
+func (s *SyncService) ValidateAndApplySequencerTransaction(ctx context.Context, tx *types.Transaction) error {
 	if s.verifier {
 		return errors.New("Verifier does not accept transactions out of band")
 	}
 	if tx == nil {
 		return errors.New("nil transaction passed to ValidateAndApplySequencerTransaction")
 	}
+	if err := s.verifyFee(tx); err != nil {
+		return err
+	}
+	if s.admission != nil {
+		from, err := types.Sender(s.signer, tx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction: %w", core.ErrInvalidSender)
+		}
+		if err := s.admission.allow(from, remoteFromContext(ctx)); err != nil {
+			return err
+		}
+		if s.admission.queue != nil {
+			if err := s.admission.queue.acquire(ctx, tx.GasPrice()); err != nil {
+				return err
+			}
+			defer s.admission.queue.release()
+		}
+	}
 	s.txLock.Lock()
 	defer s.txLock.Unlock()
-	if err := s.verifyFee(tx); err != nil {
+	if err := s.verifyNonceGap(tx); err != nil {
 		return err
 	}
 	log.Trace("Sequencer transaction validation", "hash", tx.Hash().Hex())
@@ -1187,29 +1435,35 @@ func (s *SyncService) syncBatches() (*uint64, error) {
 }
 
 // syncTransactionBatchRange will sync a range of batched transactions from
-// start to end (inclusive)
+// start to end (inclusive). Batches are fetched ahead of being applied.
 func (s *SyncService) syncTransactionBatchRange(start, end uint64) error {
 	log.Info("Syncing transaction batch range", "start", start, "end", end)
-	for i := start; i <= end; i++ {
-		log.Debug("Fetching transaction batch", "index", i)
-		_, txs, err := s.client.GetTransactionBatch(i)
+	progress := newSyncProgress("batches", start, end)
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		log.Debug("Fetching transaction batch", "index", index)
+		_, txs, err := s.client.GetTransactionBatch(index)
 		if err != nil {
-			return fmt.Errorf("Cannot get transaction batch: %w", err)
+			return nil, fmt.Errorf("Cannot get transaction batch: %w", err)
 		}
+		return txs, nil
+	}
+	apply := func(index uint64, txs []*types.Transaction) error {
 		for _, tx := range txs {
 			if err := s.applyBatchedTransaction(tx); err != nil {
 				return fmt.Errorf("cannot apply batched transaction: %w", err)
 			}
 		}
-		s.SetLatestBatchIndex(&i)
+		s.SetLatestBatchIndex(&index)
+		progress.update(index)
+		return nil
 	}
-	return nil
+	return fetchRange(s.ctx, start, end, s.syncConcurrency, fetch, apply)
 }
 
 // syncQueue will sync from the local tip to the known tip of the remote
 // enqueue transaction feed.
 func (s *SyncService) syncQueue() (*uint64, error) {
-	index, err := s.sync(s.client.GetLatestEnqueueIndex, s.GetNextEnqueueIndex, s.syncQueueTransactionRange)
+	index, err := s.sync(s.getRemoteQueueIndex, s.GetNextEnqueueIndex, s.syncQueueTransactionRange)
 	if err != nil {
 		return nil, fmt.Errorf("Cannot sync queue: %w", err)
 	}
@@ -1217,26 +1471,33 @@ func (s *SyncService) syncQueue() (*uint64, error) {
 }
 
 // syncQueueTransactionRange will apply a range of queue transactions from
-// start to end (inclusive)
+// start to end (inclusive). Queue transactions are fetched ahead of being
+// applied.
 func (s *SyncService) syncQueueTransactionRange(start, end uint64) error {
 	log.Info("Syncing enqueue transactions range", "start", start, "end", end)
-	for i := start; i <= end; i++ {
-		tx, err := s.client.GetEnqueue(i)
+	progress := newSyncProgress("queue", start, end)
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		tx, err := s.client.GetEnqueue(index)
 		if err != nil {
-			return fmt.Errorf("Canot get enqueue transaction; %w", err)
+			return nil, fmt.Errorf("Canot get enqueue transaction; %w", err)
 		}
-		if err := s.applyTransaction(tx); err != nil {
+		return []*types.Transaction{tx}, nil
+	}
+	apply := func(index uint64, txs []*types.Transaction) error {
+		if err := s.applyTransaction(txs[0]); err != nil {
 			return fmt.Errorf("Cannot apply transaction: %w", err)
 		}
+		progress.update(index)
+		return nil
 	}
-	return nil
+	return fetchRange(s.ctx, start, end, s.syncConcurrency, fetch, apply)
 }
 
 // syncTransactions will sync transactions to the remote tip based on the
 // backend
 func (s *SyncService) syncTransactions(backend Backend) (*uint64, error) {
 	getLatest := func() (*uint64, error) {
-		return s.client.GetLatestTransactionIndex(backend)
+		return s.getRemoteIndex(backend)
 	}
 	sync := func(start, end uint64) error {
 		return s.syncTransactionRange(start, end, backend)
@@ -1249,17 +1510,54 @@ func (s *SyncService) syncTransactions(backend Backend) (*uint64, error) {
 }
 
 // syncTransactionRange will sync a range of transactions from
-// start to end (inclusive) from a specific Backend
+// start to end (inclusive) from a specific Backend. Range queries are used
+// when the remote server supports them for the Backend, otherwise
+// transactions are fetched ahead of being applied.
 func (s *SyncService) syncTransactionRange(start, end uint64, backend Backend) error {
 	log.Info("Syncing transaction range", "start", start, "end", end, "backend", backend.String())
-	for i := start; i <= end; i++ {
-		tx, err := s.client.GetTransaction(i, backend)
+	progress := newSyncProgress("transactions", start, end)
+	if client, ok := s.client.(RangeClient); ok {
+		err := s.syncTransactionRangeQueries(client, start, end, backend, progress)
+		if !errors.Is(err, errRangeNotSupported) {
+			return err
+		}
+	}
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		tx, err := s.client.GetTransaction(index, backend)
 		if err != nil {
-			return fmt.Errorf("cannot fetch transaction %d: %w", i, err)
+			return nil, fmt.Errorf("cannot fetch transaction %d: %w", index, err)
 		}
-		if err := s.applyTransaction(tx); err != nil {
+		return []*types.Transaction{tx}, nil
+	}
+	apply := func(index uint64, txs []*types.Transaction) error {
+		if err := s.applyTransaction(txs[0]); err != nil {
 			return fmt.Errorf("Cannot apply transaction: %w", err)
 		}
+		progress.update(index)
+		return nil
+	}
+	return fetchRange(s.ctx, start, end, s.syncConcurrency, fetch, apply)
+}
+
+// syncTransactionRangeQueries will sync a range of transactions from start to
+// end (inclusive) using range queries. errRangeNotSupported is returned
+// before any transaction is applied if the Backend does not support them.
+func (s *SyncService) syncTransactionRangeQueries(client RangeClient, start, end uint64, backend Backend, progress *syncProgress) error {
+	fetch := func(index uint64) ([]*types.Transaction, error) {
+		return client.GetTransactionRange(index, end, backend)
+	}
+	for i := start; i <= end; {
+		txs, err := fetchWithRetry(s.ctx, fetch, i)
+		if err != nil {
+			return fmt.Errorf("cannot fetch transaction range %d - %d: %w", i, end, err)
+		}
+		for _, tx := range txs {
+			if err := s.applyTransaction(tx); err != nil {
+				return fmt.Errorf("Cannot apply transaction: %w", err)
+			}
+			progress.update(i)
+			i++
+		}
 	}
 	return nil
 }
@@ -385,7 +385,7 @@ func TestApplyIndexedTransaction(t *testing.T) {
 	tx1a := setMockTxIndex(mockTx(), 1)
 
 	go func() {
-		err = service.applyIndexedTransaction(tx0)
+		err = service.applyIndexedTransaction(tx0, false)
 	}()
 	<-txCh
 	if err != nil {
@@ -396,7 +396,7 @@ func TestApplyIndexedTransaction(t *testing.T) {
 	}
 
 	go func() {
-		err = service.applyIndexedTransaction(tx1)
+		err = service.applyIndexedTransaction(tx1, false)
 	}()
 	<-txCh
 	if err != nil {
@@ -406,7 +406,7 @@ func TestApplyIndexedTransaction(t *testing.T) {
 		t.Fatal("Latest index mismatch")
 	}
 
-	err = service.applyIndexedTransaction(tx1a)
+	err = service.applyIndexedTransaction(tx1a, false)
 	if err == nil {
 		t.Fatal(err)
 	}
@@ -449,6 +449,144 @@ func TestApplyBatchedTransaction(t *testing.T) {
 	}
 }
 
+// Test that a batched transaction that does not match the local chain
+// causes the chain to be rolled back and the canonical transaction applied
+func TestSyncServiceReorg(t *testing.T) {
+	service, txCh, sub, err := newTestSyncServiceWithChain(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	service.maxReorgDepth = 2
+
+	// The transaction at index 2 is held in block 3
+	tx := setMockTxL1Timestamp(setMockTxIndex(mockTx(), 2), 10)
+	go func() {
+		err = service.applyBatchedTransaction(tx)
+	}()
+	<-txCh
+
+	// The chain is rolled back to the block before the mismatched transaction
+	if num := service.bc.CurrentBlock().Number().Uint64(); num != 2 {
+		t.Fatalf("Unexpected head after reorg: %d", num)
+	}
+	if index := service.GetLatestIndex(); *index != 2 {
+		t.Fatalf("Unexpected latest index after reorg: %d", *index)
+	}
+	if index := service.GetLatestEnqueueIndex(); index == nil || *index != 0 {
+		t.Fatalf("Unexpected latest enqueue index after reorg: %s", stringify(index))
+	}
+	if ts := service.GetLatestL1Timestamp(); ts != 10 {
+		t.Fatalf("Unexpected latest L1 timestamp after reorg: %d", ts)
+	}
+
+	service.chainHeadCh <- core.ChainHeadEvent{}
+	for service.GetLatestVerifiedIndex() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index := service.GetLatestVerifiedIndex(); *index != 2 {
+		t.Fatalf("Unexpected latest verified index: %d", *index)
+	}
+}
+
+// Test that reorgs deeper than the max reorg depth are refused and that no
+// reorg is performed in dry run mode
+func TestSyncServiceReorgSafety(t *testing.T) {
+	service, _, sub, err := newTestSyncServiceWithChain(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// The transaction at index 0 is held in block 1, rolling back 4 blocks
+	tx := setMockTxL1Timestamp(setMockTxIndex(mockTx(), 0), 10)
+	service.maxReorgDepth = 3
+	err = service.applyBatchedTransaction(tx)
+	if !errors.Is(err, errReorgTooDeep) {
+		t.Fatalf("Expected reorg too deep, got %v", err)
+	}
+
+	service.maxReorgDepth = 4
+	service.reorgDryRun = true
+	if err := service.applyBatchedTransaction(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if num := service.bc.CurrentBlock().Number().Uint64(); num != 4 {
+		t.Fatalf("Unexpected head: %d", num)
+	}
+	if index := service.GetLatestIndex(); *index != 3 {
+		t.Fatalf("Unexpected latest index: %d", *index)
+	}
+}
+
+func TestSyncServiceStateDivergence(t *testing.T) {
+	for _, halt := range []bool{false, true} {
+		service, _, sub, err := newTestSyncServiceWithChain(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sub.Unsubscribe()
+
+		// The state root at index 3 diverges, index 4 has not been synced yet
+		roots := make([]common.Hash, 5)
+		for i := range roots[:4] {
+			roots[i] = service.bc.GetBlockByNumber(uint64(i + 1)).Root()
+		}
+		roots[3] = common.Hash{0x01}
+		roots[4] = common.Hash{0x02}
+		client := &mockStateRootClient{
+			batches: []*Batch{
+				{Index: 0, Size: 2, PrevTotalElements: 0},
+				{Index: 1, Size: 2, PrevTotalElements: 2},
+				{Index: 2, Size: 1, PrevTotalElements: 4},
+			},
+			roots: [][]common.Hash{roots[0:2], roots[2:4], roots[4:5]},
+		}
+		service.divergence = newDivergenceDetector(client, halt)
+
+		// Checking again does not record the divergence twice
+		for i := 0; i < 2; i++ {
+			if err := service.checkStateRoots(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		status := service.GetStateDivergence()
+		if !status.Enabled || status.Halted != halt {
+			t.Fatalf("Unexpected status: %v", status)
+		}
+		if len(status.Divergences) != 1 {
+			t.Fatalf("Unexpected number of divergences: %d", len(status.Divergences))
+		}
+		divergence := status.Divergences[0]
+		if divergence.Index != 3 || divergence.BatchIndex != 1 || divergence.PostedRoot != roots[3] {
+			t.Fatalf("Unexpected divergence: %v", divergence)
+		}
+		if divergence.LocalRoot != service.bc.GetBlockByNumber(4).Root() {
+			t.Fatal("Unexpected local root")
+		}
+
+		// Sync halts before the diverging batch is marked as checked
+		checkedIndex, headBatchIndex := uint64(3), uint64(1)
+		if halt {
+			checkedIndex, headBatchIndex = 2, 0
+		}
+		if *status.CheckedIndex != checkedIndex {
+			t.Fatalf("Unexpected checked index: %d", *status.CheckedIndex)
+		}
+		if index := rawdb.ReadHeadStateBatchIndex(service.db); *index != headBatchIndex {
+			t.Fatalf("Unexpected head state batch index: %d", *index)
+		}
+		if service.isHaltedOnDivergence() != halt {
+			t.Fatal("Unexpected halt")
+		}
+	}
+}
+
 func TestIsAtTip(t *testing.T) {
 	service, _, _, err := newTestSyncService(true, nil)
 	if err != nil {
@@ -835,7 +973,9 @@ func TestZeroGasPriceTransactionsUsingBobaAsFeeToken(t *testing.T) {
 	}
 
 	// Pick Boba as the fee token
-	rcfg.OvmBobaGasPricOracle = common.HexToAddress("0x4200000000000000000000000000000000000024")
+	state.SetOVMConfig(&rcfg.Config{
+		BobaGasPriceOracleAddress: common.HexToAddress("0x4200000000000000000000000000000000000024"),
+	})
 	isFeeTokenSelected := state.GetFeeTokenSelection(from)
 	if isFeeTokenSelected.Cmp(big.NewInt(0)) != 0 {
 		t.Fatal("Cannot get fee token selection")
@@ -1131,6 +1271,64 @@ func newTestSyncService(isVerifier bool, alloc *common.Address) (*SyncService, c
 	return service, txCh, sub, nil
 }
 
+// newTestSyncServiceWithChain creates a verifier SyncService whose chain holds
+// the given number of blocks, each with a single indexed transaction. The
+// transaction at index 1 is an enqueue transaction.
+func newTestSyncServiceWithChain(n int) (*SyncService, chan core.NewTxsEvent, event.Subscription, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	service, txCh, sub, err := newTestSyncService(true, &sender)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signer := types.NewEIP155Signer(service.bc.Config().ChainID)
+	blocks, _ := core.GenerateChain(service.bc.Config(), service.bc.CurrentBlock(), ethash.NewFaker(), service.db, n, func(i int, gen *core.BlockGen) {
+		tx := types.NewTransaction(uint64(i), common.Address{}, big.NewInt(0), params.TxGas, big.NewInt(0), nil)
+		tx, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			panic(err)
+		}
+		index := uint64(i)
+		var queueIndex *uint64
+		if i == 1 {
+			queueIndex = newUint64(0)
+		}
+		tx.SetTransactionMeta(types.NewTransactionMeta(big.NewInt(int64(i)), uint64(i)*10, nil, &sender, types.QueueOriginSequencer, &index, queueIndex, nil))
+		gen.AddTx(tx)
+	})
+	if _, err := service.bc.InsertChain(blocks); err != nil {
+		return nil, nil, nil, fmt.Errorf("Cannot insert chain: %w", err)
+	}
+	service.SetLatestIndex(newUint64(uint64(n - 1)))
+	service.SetLatestEnqueueIndex(newUint64(0))
+
+	return service, txCh, sub, nil
+}
+
+type mockStateRootClient struct {
+	batches []*Batch
+	roots   [][]common.Hash
+}
+
+func (m *mockStateRootClient) GetStateRootBatch(index uint64) (*Batch, []common.Hash, error) {
+	if index >= uint64(len(m.batches)) {
+		return nil, nil, errElementNotFound
+	}
+	return m.batches[index], m.roots[index], nil
+}
+
+func (m *mockStateRootClient) GetLatestStateRootBatchIndex() (*uint64, error) {
+	if len(m.batches) == 0 {
+		return nil, errElementNotFound
+	}
+	index := uint64(len(m.batches) - 1)
+	return &index, nil
+}
+
 type mockClient struct {
 	getEnqueueCallCount            int
 	getEnqueue                     []*types.Transaction
@@ -1395,3 +1593,30 @@ func setMockQueueOrigin(tx *types.Transaction, qo types.QueueOrigin) *types.Tran
 func newUint64(n uint64) *uint64 {
 	return &n
 }
+
+// Tests that transactions with an invalid fee are rejected before they use
+// up the rate limit of their sender
+func TestAdmissionAfterFeeCheck(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	service, _, _, err := newTestSyncService(false, &sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.enforceFees = true
+	service.admission = newAdmissionController(admissionConfig{SenderTxRate: 0.001, SenderTxBurst: 1})
+
+	signer := types.NewEIP155Signer(big.NewInt(420))
+	badTx, err := types.SignTx(mockNoneZeroGasLimitGasPriceTx(100000000000000, big.NewInt(2)), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := service.ValidateAndApplySequencerTransaction(context.Background(), badTx); !errors.Is(err, core.ErrInsufficientFunds) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := service.admission.allow(sender, ""); err != nil {
+		t.Fatalf("Rejected transactions used up the sender quota: %v", err)
+	}
+}
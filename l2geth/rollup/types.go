@@ -56,6 +56,43 @@ const (
 	BackendL2
 )
 
+// ClientType represents the source that the RollupClient reads the
+// Canonical Transaction Chain from.
+type ClientType uint
+
+// String implements the Stringer interface
+func (c ClientType) String() string {
+	switch c {
+	case ClientTypeDTL:
+		return "dtl"
+	case ClientTypeL1:
+		return "l1"
+	default:
+		return ""
+	}
+}
+
+// NewClientType creates a ClientType from a human readable string
+func NewClientType(typ string) (ClientType, error) {
+	switch typ {
+	case "dtl":
+		return ClientTypeDTL, nil
+	case "l1":
+		return ClientTypeL1, nil
+	default:
+		return 0, fmt.Errorf("Unknown ClientType: %s", typ)
+	}
+}
+
+const (
+	// ClientTypeDTL reads from the HTTP API of the data transport layer,
+	// which indexes the layer one contracts.
+	ClientTypeDTL ClientType = iota
+	// ClientTypeL1 indexes the layer one contracts itself by reading events
+	// and batch calldata directly from a layer one JSON-RPC endpoint.
+	ClientTypeL1
+)
+
 func isCtcTxEqual(a, b *types.Transaction) bool {
 	if a.To() == nil && b.To() != nil {
 		if !bytes.Equal(b.To().Bytes(), common.Address{}.Bytes()) {